@@ -22,8 +22,10 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
       infracost breakdown --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
+			if !usingPriceSnapshot(cmd) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
 			}
 
 			err := loadRunFlags(ctx.Config, cmd)
@@ -48,8 +50,14 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().String("out-file", "", "Save output to a file, helpful with format flag")
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
 	cmd.Flags().String("format", "table", "Output format: json, table, html")
+	cmd.Flags().String("pricing-snapshot", "", "Path to a price snapshot file to use instead of the Cloud Pricing API, see 'infracost prices export'")
+	cmd.Flags().Bool("strict-pricing", false, "Fail if any cost component has a pricing warning, e.g. no price was found so it was priced at 0.00")
+	cmd.Flags().Bool("estimate-usage", false, "Estimate usage for supported resources from cloud provider metrics, e.g. AWS CloudWatch (experimental)")
+	cmd.Flags().Bool("include-cloud-resource-ids", false, "Include the IDs and ARNs of deployed resources in the JSON output, needed by 'infracost reconcile'")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
 
+	_ = cmd.MarkFlagFilename("pricing-snapshot", "json")
+
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return validRunFormats, cobra.ShellCompDirectiveDefault
 	})
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func cacheCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the price query cache",
+		Long:  "Manage the price query cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Show the help
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(cacheClearCmd(ctx), cacheStatsCmd(ctx))
+
+	return cmd
+}
+
+func cacheClearCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached price query results",
+		Long:  "Remove all cached price query results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			count, err := apiclient.ClearPriceCache(apiclient.DefaultPriceCacheDir)
+			if err != nil {
+				return err
+			}
+
+			ui.PrintSuccessf(cmd.ErrOrStderr(), "Removed %d cached prices from %s", count, ui.DisplayPath(apiclient.DefaultPriceCacheDir))
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func cacheStatsCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show stats for the cached price query results",
+		Long:  "Show stats for the cached price query results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stats, err := apiclient.ReadPriceCacheStats(apiclient.DefaultPriceCacheDir, ctx.Config.PricingCacheTTL)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Directory: %s\n", ui.DisplayPath(apiclient.DefaultPriceCacheDir))
+			cmd.Printf("TTL:       %s\n", ctx.Config.PricingCacheTTL)
+			cmd.Printf("Entries:   %d (%d expired)\n", stats.Entries, stats.Expired)
+			cmd.Printf("Size:      %s\n", formatByteSize(stats.SizeBytes))
+
+			if stats.Entries > 0 {
+				cmd.Printf("Oldest:    %s\n", stats.Oldest.Format("2006-01-02 15:04:05"))
+				cmd.Printf("Newest:    %s\n", stats.Newest.Format("2006-01-02 15:04:05"))
+			}
+
+			if ctx.Config.PricingCacheDisabled {
+				cmd.Println()
+				ui.PrintWarning(cmd.ErrOrStderr(), "The price cache is disabled by INFRACOST_PRICING_CACHE_DISABLED.")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func formatByteSize(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validCompareFormats = []string{"diff", "json", "github-comment", "gitlab-comment", "azure-repos-comment"}
+
+func compareCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Show diff of monthly costs between two Infracost JSON files",
+		Long:  "Show diff of monthly costs between two Infracost JSON files",
+		Example: `  Compare last week's run to today's:
+
+      infracost compare --old infracost-last-week.json --new infracost.json
+
+  Create markdown report of the diff to post in a GitHub comment:
+
+      infracost compare --old infracost-base.json --new infracost.json --format github-comment`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			ctx.SetContextValue("outputFormat", format)
+
+			if format != "" && !contains(validCompareFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validCompareFormats, ", "))
+			}
+
+			oldPath, _ := cmd.Flags().GetString("old")
+			newPath, _ := cmd.Flags().GetString("new")
+
+			oldOut, err := loadCompareFile(oldPath)
+			if err != nil {
+				return err
+			}
+
+			newOut, err := loadCompareFile(newPath)
+			if err != nil {
+				return err
+			}
+
+			_, err = checkCurrency(oldOut.Currency, newOut.Currency)
+			if err != nil {
+				return err
+			}
+
+			compared, err := output.Compare(oldOut, newOut)
+			if err != nil {
+				return errors.Wrap(err, "Error comparing Infracost JSON files")
+			}
+
+			opts := output.Options{
+				DashboardEnabled: ctx.Config.EnableDashboard,
+				NoColor:          ctx.Config.NoColor,
+			}
+
+			var b []byte
+
+			switch strings.ToLower(format) {
+			case "json":
+				b, err = output.ToJSON(compared, opts)
+			case "github-comment", "gitlab-comment", "azure-repos-comment":
+				opts.IncludeHTML = true
+				b, err = output.ToMarkdown(compared, opts)
+			default:
+				b, err = output.ToDiff(compared, opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-compare", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				return saveOutFile(cmd, outFile, b)
+			}
+
+			cmd.Println(string(b))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("old", "", "Path to the Infracost JSON file to compare from")
+	cmd.Flags().String("new", "", "Path to the Infracost JSON file to compare to")
+	cmd.Flags().StringP("out-file", "o", "", "Save output to a file, helpful with format flag")
+	cmd.Flags().String("format", "diff", "Output format: diff, json, github-comment, gitlab-comment, azure-repos-comment")
+
+	_ = cmd.MarkFlagRequired("old")
+	_ = cmd.MarkFlagRequired("new")
+	_ = cmd.MarkFlagFilename("old", "json")
+	_ = cmd.MarkFlagFilename("new", "json")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validCompareFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
+
+func loadCompareFile(path string) (output.Root, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return output.Root{}, errors.Wrap(err, "Error reading JSON file")
+	}
+
+	out, err := output.Load(data)
+	if err != nil {
+		return output.Root{}, errors.Wrapf(err, "Error parsing JSON file %s", path)
+	}
+
+	if !checkOutputVersion(out.Version) {
+		return output.Root{}, fmt.Errorf("Invalid Infracost JSON file version in %s. Supported versions are %s ≤ x ≤ %s", path, minOutputVersion, maxOutputVersion)
+	}
+
+	return out, nil
+}
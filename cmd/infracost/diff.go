@@ -1,9 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -20,11 +29,23 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 
       terraform plan -out tfplan.binary
       terraform show -json tfplan.binary > plan.json
-      infracost diff --path plan.json`,
+      infracost diff --path plan.json
+
+  Compare against the Infracost JSON output of another branch:
+
+      infracost breakdown --path /path/to/code --format json --out-file infracost-base.json
+      infracost diff --path plan.json --compare-to infracost-base.json
+
+  Compare a CloudFormation template against the deployed template:
+
+      aws cloudformation get-template --stack-name my-stack --query TemplateBody > deployed.json
+      infracost diff --path template.yml --previous-template deployed.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
+			if !usingPriceSnapshot(cmd) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
 			}
 
 			err := loadRunFlags(ctx.Config, cmd)
@@ -32,6 +53,8 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
+			ctx.Config.CompareToPath, _ = cmd.Flags().GetString("compare-to")
+
 			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
 			if err != nil {
 				ui.PrintUsage(cmd)
@@ -53,16 +76,155 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 	addRunFlags(cmd)
 
 	cmd.Flags().String("out-file", "", "Save output to a file")
+	cmd.Flags().String("pricing-snapshot", "", "Path to a price snapshot file to use instead of the Cloud Pricing API, see 'infracost prices export'")
+	cmd.Flags().Bool("strict-pricing", false, "Fail if any cost component has a pricing warning, e.g. no price was found so it was priced at 0.00")
+	cmd.Flags().Bool("estimate-usage", false, "Estimate usage for supported resources from cloud provider metrics, e.g. AWS CloudWatch (experimental)")
+	cmd.Flags().String("compare-to", "", "Path to a Terraform state JSON file or Infracost JSON file to diff against instead of the current state")
+	cmd.Flags().String("previous-template", "", "Path to the deployed CloudFormation template or a change set JSON description to diff against, or the deployed cloud assembly directory when path is a CDK app. Applicable when path is a CloudFormation template or CDK app")
+
+	_ = cmd.MarkFlagFilename("pricing-snapshot", "json")
+	_ = cmd.MarkFlagFilename("compare-to", "json")
+	_ = cmd.MarkFlagFilename("previous-template", "json", "yaml", "yml")
 
 	return cmd
 }
 
 func checkDiffConfig(cfg *config.Config) error {
+	if cfg.CompareToPath != "" && !config.FileExists(cfg.CompareToPath) {
+		return fmt.Errorf("--compare-to file %s does not exist", cfg.CompareToPath)
+	}
+
 	for _, projectConfig := range cfg.Projects {
 		if projectConfig.TerraformUseState {
 			return errors.New("terraform_use_state cannot be used with `infracost diff` as the Terraform state only contains the current state")
 		}
+
+		if projectConfig.PreviousTemplatePath != "" && cfg.CompareToPath != "" {
+			return errors.New("--previous-template cannot be used with --compare-to")
+		}
+
+		if projectConfig.PreviousTemplatePath != "" {
+			// The previous template of a CDK app is a cloud assembly directory
+			if _, err := os.Stat(projectConfig.PreviousTemplatePath); err != nil {
+				return fmt.Errorf("Previous template file %s does not exist", projectConfig.PreviousTemplatePath)
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareToBaseline is the set of projects loaded from the --compare-to path
+// that the projects are diffed against.
+type compareToBaseline struct {
+	projects []*schema.Project
+	// needsPricing is true if the baseline was loaded from a Terraform state
+	// JSON file, since an Infracost JSON file already has the costs.
+	needsPricing bool
+}
+
+// loadCompareTo loads the baseline from the --compare-to path, which is either
+// the Infracost JSON output of a previous run or a Terraform state JSON file.
+func loadCompareTo(cmd *cobra.Command, runCtx *config.RunContext) (*compareToBaseline, error) {
+	path := runCtx.Config.CompareToPath
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading --compare-to file")
+	}
+
+	if isInfracostJSON(b) {
+		out, err := output.Load(b)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing --compare-to Infracost JSON file")
+		}
+
+		log.Infof("Comparing against Infracost JSON file at %s", path)
+
+		return &compareToBaseline{projects: output.ToSchemaProjects(out)}, nil
+	}
+
+	if len(runCtx.Config.Projects) > 1 {
+		return nil, errors.New("--compare-to can only be used with multiple projects if it is an Infracost JSON file")
+	}
+
+	projectCfg := *runCtx.Config.Projects[0]
+	projectCfg.Path = path
+
+	ctx := config.NewProjectContext(runCtx, &projectCfg)
+
+	provider, err := providers.Detect(ctx)
+	if err != nil || provider.Type() != "terraform_state_json" {
+		m := fmt.Sprintf("Could not detect the type of the --compare-to file %s\n\n", path)
+		m += "The --compare-to file must be one of the following:\n"
+		m += " - Infracost JSON file, e.g. from infracost breakdown --format json\n - Terraform state JSON file"
+		return nil, clierror.NewSanitizedError(errors.New(m), "Could not detect --compare-to file type")
+	}
+
+	m := fmt.Sprintf("Comparing against %s at %s", provider.DisplayType(), ui.DisplayPath(path))
+	if runCtx.Config.IsLogging() {
+		log.Info(m)
+	} else {
+		fmt.Fprintln(os.Stderr, m)
+	}
+
+	projects, err := loadProjectResources(cmd, ctx, &projectCfg, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range projects {
+		p.HasDiff = false
+	}
+
+	return &compareToBaseline{projects: projects, needsPricing: true}, nil
+}
+
+// apply replaces the past resources of each project with the resources of the
+// matching baseline project and recalculates the diff. Projects are matched by
+// name, or if there is only one project on each side those are compared. Any
+// projects that aren't in the baseline are treated as new.
+func (b *compareToBaseline) apply(projects []*schema.Project) {
+	for _, p := range projects {
+		past := b.findProject(p, len(projects) == 1)
+
+		p.HasDiff = true
+		p.PastResources = nil
+
+		if past != nil {
+			p.PastResources = past.Resources
+		} else {
+			log.Debugf("Project %s not found in --compare-to file, treating all its resources as new", p.Name)
+		}
+
+		p.CalculateDiff()
+	}
+}
+
+func (b *compareToBaseline) findProject(p *schema.Project, single bool) *schema.Project {
+	if single && len(b.projects) == 1 {
+		return b.projects[0]
+	}
+
+	for _, past := range b.projects {
+		if past.Name == p.Name {
+			return past
+		}
 	}
 
 	return nil
 }
+
+func isInfracostJSON(b []byte) bool {
+	var jsonFormat struct {
+		Version  string      `json:"version"`
+		Projects interface{} `json:"projects"`
+	}
+
+	err := json.Unmarshal(b, &jsonFormat)
+	if err != nil {
+		return false
+	}
+
+	return jsonFormat.Version != "" && jsonFormat.Projects != nil
+}
@@ -69,3 +69,7 @@ func TestDiffTerraform_v0_12(t *testing.T) {
 func TestDiffTerraform_v0_14(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"diff", "--path", "./testdata/terraform_v0.14_plan.json"}, nil)
 }
+
+func TestDiffCompareToMissingFile(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"diff", "--path", "./testdata/example_plan.json", "--compare-to", "./testdata/does_not_exist.json"}, nil)
+}
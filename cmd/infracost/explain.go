@@ -0,0 +1,104 @@
+package main
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/explain"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func explainCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain [resource address...]",
+		Short: "Explain how the cost of each cost component is calculated",
+		Long: `Explain how the cost of each cost component is calculated.
+
+For each cost component this shows the filters sent to the Cloud Pricing API,
+the price hash returned, the unit multiplier, the quantities and where they
+came from, and the arithmetic that produced the hourly and monthly costs.`,
+		Example: `  Explain all the resources in a Terraform directory:
+
+      infracost explain --path /path/to/code
+
+  Explain a single resource, or all the resources in a module:
+
+      infracost explain aws_instance.web --path plan.json
+      infracost explain module.app --path plan.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !usingPriceSnapshot(cmd) {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			return runExplain(cmd, ctx, args)
+		},
+	}
+
+	addRunFlags(cmd)
+
+	cmd.Flags().String("out-file", "", "Save output to a file")
+	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
+	cmd.Flags().String("pricing-snapshot", "", "Path to a price snapshot file to use instead of the Cloud Pricing API, see 'infracost prices export'")
+
+	_ = cmd.MarkFlagFilename("pricing-snapshot", "json")
+
+	return cmd
+}
+
+func runExplain(cmd *cobra.Command, runCtx *config.RunContext, addresses []string) error {
+	pricingClient, err := newPricingAPIClient(runCtx)
+	if err != nil {
+		return err
+	}
+
+	projects, _, err := runProjects(cmd, runCtx, pricingClient)
+	if err != nil {
+		return err
+	}
+
+	opts := explain.Options{
+		Addresses: addresses,
+		Currency:  pricingClient.Currency,
+	}
+
+	resources, err := explain.Explain(projects, opts)
+	if err != nil {
+		return err
+	}
+
+	runCtx.SetContextValue("explainedResourceCount", len(resources))
+
+	err = pricingClient.AddEvent("infracost-explain", runCtx.EventEnv())
+	if err != nil {
+		log.Errorf("Error reporting event: %s", err)
+	}
+
+	b := []byte(explain.ToText(resources, opts))
+
+	// Print a new line to separate the logs from the output
+	if runCtx.Config.IsLogging() {
+		cmd.PrintErrln()
+	}
+
+	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+		return saveOutFile(cmd, outFile, b)
+	}
+
+	cmd.Print(string(b))
+
+	return nil
+}
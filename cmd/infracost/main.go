@@ -8,6 +8,7 @@ import (
 	"runtime/debug"
 
 	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/update"
@@ -33,19 +34,29 @@ func main() {
 	}
 
 	defer func() {
-		if appErr != nil {
+		exitCode := 1
+
+		var exitErr *clierror.ExitError
+		if errors.As(appErr, &exitErr) {
+			// Expected failures, e.g. policy violations, aren't reported upstream
+			if appErr.Error() != "" {
+				ui.PrintError(os.Stderr, appErr.Error())
+			}
+			exitCode = exitErr.ExitCode()
+		} else if appErr != nil {
 			handleCLIError(ctx, appErr)
 		}
 
 		unexpectedErr := recover()
 		if unexpectedErr != nil {
 			handleUnexpectedErr(ctx, unexpectedErr)
+			exitCode = 1
 		}
 
 		handleUpdateMessage(updateMessageChan)
 
 		if appErr != nil || unexpectedErr != nil {
-			os.Exit(1)
+			os.Exit(exitCode)
 		}
 	}()
 
@@ -91,7 +102,13 @@ func NewRootCommand(ctx *config.RunContext) *cobra.Command {
 	rootCmd.AddCommand(configureCmd(ctx))
 	rootCmd.AddCommand(diffCmd(ctx))
 	rootCmd.AddCommand(breakdownCmd(ctx))
+	rootCmd.AddCommand(explainCmd(ctx))
 	rootCmd.AddCommand(outputCmd(ctx))
+	rootCmd.AddCommand(compareCmd(ctx))
+	rootCmd.AddCommand(reconcileCmd(ctx))
+	rootCmd.AddCommand(pricesCmd(ctx))
+	rootCmd.AddCommand(cacheCmd(ctx))
+	rootCmd.AddCommand(usageCmd(ctx))
 	rootCmd.AddCommand(completionCmd())
 
 	rootCmd.SetUsageTemplate(fmt.Sprintf(`%s{{if .Runnable}}
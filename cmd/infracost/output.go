@@ -15,13 +15,14 @@ import (
 	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
 	"github.com/infracost/infracost/internal/ui"
 )
 
 var minOutputVersion = "0.2"
 var maxOutputVersion = "0.2"
 
-var validOutputFormats = []string{"table", "diff", "json", "html", "github-comment", "gitlab-comment", "azure-repos-comment", "slack-message"}
+var validOutputFormats = []string{"table", "diff", "json", "html", "github-comment", "gitlab-comment", "azure-repos-comment", "slack-message", "policy"}
 
 func outputCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
@@ -50,7 +51,11 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
   Create markdown report to post in a Azure DevOps Repos comment:
 
-      infracost output --format azure-repos-comment --path "out*.json" # glob needs quotes`,
+      infracost output --format azure-repos-comment --path "out*.json" # glob needs quotes
+
+  Create markdown report of cost policy results to post in a pull request comment:
+
+      infracost output --format policy --policy-file policies.yml --path "out*.json" # glob needs quotes`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			format, _ := cmd.Flags().GetString("format")
@@ -61,6 +66,21 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				return fmt.Errorf("--format only supports %s", strings.Join(validOutputFormats, ", "))
 			}
 
+			var policyFile *policy.File
+			if format == "policy" {
+				policyPath, _ := cmd.Flags().GetString("policy-file")
+				if policyPath == "" {
+					ui.PrintUsage(cmd)
+					return errors.New("--policy-file is required with the policy format")
+				}
+
+				var err error
+				policyFile, err = policy.LoadFile(policyPath)
+				if err != nil {
+					return err
+				}
+			}
+
 			inputFiles := []string{}
 
 			paths, _ := cmd.Flags().GetStringArray("path")
@@ -175,6 +195,8 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				b, err = output.ToMarkdown(combined, opts)
 			case "slack-message":
 				b, err = output.ToSlackMessage(combined, opts)
+			case "policy":
+				b = policy.ToMarkdown(policy.Evaluate(policyFile, combined))
 			default:
 				b, err = output.ToTable(combined, opts)
 			}
@@ -204,12 +226,14 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
 	cmd.Flags().StringP("out-file", "o", "", "Save output to a file, helpful with format flag")
 
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, slack-message")
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, slack-message, policy")
+	cmd.Flags().String("policy-file", "", "Path to cost policy file, used by the policy output format")
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
 
 	_ = cmd.MarkFlagRequired("path")
 	_ = cmd.MarkFlagFilename("path", "json")
+	_ = cmd.MarkFlagFilename("policy-file", "yml")
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return validOutputFormats, cobra.ShellCompDirectiveDefault
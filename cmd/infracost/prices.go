@@ -0,0 +1,88 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func pricesCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prices",
+		Short: "Manage price snapshots for offline runs",
+		Long:  "Manage price snapshots for offline runs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Show the help
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(pricesExportCmd(ctx))
+
+	return cmd
+}
+
+func pricesExportCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the prices needed by a run to a price snapshot file",
+		Long: `Export the prices needed by a run to a price snapshot file.
+
+The snapshot can be passed to the breakdown and diff commands using the
+--pricing-snapshot flag so they can run without access to the Cloud Pricing API.`,
+		Example: `  Export the prices for a Terraform directory:
+
+      infracost prices export --path /path/to/code --out-file prices.json
+
+  Use the price snapshot without access to the Cloud Pricing API:
+
+      infracost breakdown --path /path/to/code --pricing-snapshot prices.json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+				return err
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			snapshot := apiclient.NewPriceSnapshot(pricingClient.Currency)
+			pricingClient.RecordSnapshot(snapshot)
+
+			_, _, err = runProjects(cmd, ctx, pricingClient)
+			if err != nil {
+				return err
+			}
+
+			outFile, _ := cmd.Flags().GetString("out-file")
+			err = snapshot.WriteToPath(outFile)
+			if err != nil {
+				return err
+			}
+
+			cmd.PrintErrf("Price snapshot with %d prices saved to %s\n", snapshot.Len(), outFile)
+
+			return nil
+		},
+	}
+
+	addRunFlags(cmd)
+
+	cmd.Flags().String("out-file", "", "Save the price snapshot to a file")
+
+	_ = cmd.MarkFlagRequired("out-file")
+	_ = cmd.MarkFlagFilename("out-file", "json")
+
+	return cmd
+}
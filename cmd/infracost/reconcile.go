@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/reconcile"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage/billing"
+)
+
+var validReconcileFormats = []string{"table", "json"}
+
+func reconcileCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Compare estimated costs with actual costs from a billing export",
+		Long: `Compare estimated costs with actual costs from a billing export.
+
+Resources in the Infracost JSON file are matched to line items in the export
+by their resource IDs and ARNs, or by their tags, and the estimated monthly
+costs are compared to the actual monthly costs. Resource types whose estimates
+are consistently off by more than the threshold are flagged, since their
+pricing or default usage is likely to be unrealistic. AWS Cost and Usage
+Report CSV and Parquet files, and Azure cost export and Google Cloud billing
+export CSV files are supported.`,
+		Example: `  Generate an Infracost JSON file from the Terraform state, then compare it with an AWS Cost and Usage Report:
+
+      infracost breakdown --path /path/to/code --terraform-use-state --include-cloud-resource-ids --format json --out-file infracost.json
+      infracost reconcile --path infracost.json --from cur.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			ctx.SetContextValue("outputFormat", format)
+
+			if format != "" && !contains(validReconcileFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validReconcileFormats, ", "))
+			}
+
+			path, _ := cmd.Flags().GetString("path")
+			out, err := loadCompareFile(path)
+			if err != nil {
+				return err
+			}
+
+			from, _ := cmd.Flags().GetString("from")
+			export, err := billing.LoadExport(from)
+			if err != nil {
+				return errors.Wrap(err, "Error loading billing export")
+			}
+
+			threshold, _ := cmd.Flags().GetFloat64("threshold")
+
+			report, err := reconcile.Reconcile(out, export, reconcile.Options{Threshold: threshold})
+			if err != nil {
+				return err
+			}
+
+			ctx.SetContextValue("reconciledResourceCount", len(report.Resources))
+
+			var b []byte
+
+			switch strings.ToLower(format) {
+			case "json":
+				b, err = reconcile.ToJSON(report)
+				if err != nil {
+					return err
+				}
+			default:
+				b = reconcile.ToTable(report)
+			}
+
+			pricingClient := apiclient.NewPricingAPIClient(ctx)
+			err = pricingClient.AddEvent("infracost-reconcile", ctx.EventEnv())
+			if err != nil {
+				log.Errorf("Error reporting event: %s", err)
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				return saveOutFile(cmd, outFile, b)
+			}
+
+			cmd.Println(string(b))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Infracost JSON file with the estimated costs")
+	cmd.Flags().String("from", "", "Path to a billing export CSV file, which can be gzipped, or an AWS Cost and Usage Report Parquet file")
+	cmd.Flags().Float64("threshold", reconcile.DefaultThreshold, "Percentage the estimates can be off by before resource types are flagged")
+	cmd.Flags().StringP("out-file", "o", "", "Save output to a file, helpful with format flag")
+	cmd.Flags().String("format", "table", "Output format: table, json")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagFilename("path", "json")
+	_ = cmd.MarkFlagFilename("from", "csv", "gz", "parquet")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validReconcileFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
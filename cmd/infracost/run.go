@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/Rhymond/go-money"
+	"github.com/manifoldco/promptui"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pkg/errors"
@@ -21,11 +22,17 @@ import (
 	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
+	"github.com/infracost/infracost/internal/priceoverrides"
 	"github.com/infracost/infracost/internal/prices"
 	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/savingsplans"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/usage"
+	awsusage "github.com/infracost/infracost/internal/usage/aws"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -51,16 +58,24 @@ func addRunFlags(cmd *cobra.Command) {
 
 	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
 	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse the Terraform HCL files instead of running Terraform. Applicable when path is a Terraform directory")
+	cmd.Flags().String("parameter-overrides", "", "CloudFormation parameter values, e.g. \"Env=prod AWS::Region=eu-west-1\". Applicable when path is a CloudFormation template")
+	cmd.Flags().String("parameters-file", "", "Path to a JSON file with CloudFormation parameter values. Applicable when path is a CloudFormation template")
 
-	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans or prices")
+	cmd.Flags().String("price-overrides-file", "", "Path to a price overrides file with negotiated discounts and custom prices")
 
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources")
 
 	cmd.Flags().Bool("sync-usage-file", false, "Sync usage-file with missing resources, needs usage-file too (experimental)")
+	cmd.Flags().Bool("remediate", false, "Make the changes in the cloud that are needed to estimate usage when syncing, e.g. enable S3 bucket metrics. Prompts before each change when run in a terminal (experimental)")
+	cmd.Flags().Bool("remediate-dry-run", false, "Show the cloud API calls that remediate would make without making them (experimental)")
 
 	_ = cmd.MarkFlagFilename("path", "json", "tf")
 	_ = cmd.MarkFlagFilename("config-file", "yml")
 	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("price-overrides-file", "yml")
+	_ = cmd.MarkFlagFilename("parameters-file", "json")
 }
 
 func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
@@ -68,10 +83,127 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		ui.PrintWarning(cmd.ErrOrStderr(), "The dashboard is part of Infracost's hosted services. Contact hello@infracost.io for help.")
 	}
 
-	parallelism, err := getParallelism(cmd, runCtx)
+	var policyFile *policy.File
+	if runCtx.Config.PolicyFile != "" {
+		var err error
+		policyFile, err = policy.LoadFile(runCtx.Config.PolicyFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	pricingClient, err := newPricingAPIClient(runCtx)
+	if err != nil {
+		return err
+	}
+
+	projects, projectContexts, err := runProjects(cmd, runCtx, pricingClient)
 	if err != nil {
 		return err
 	}
+
+	r, err := output.ToOutputFormat(projects)
+	if err != nil {
+		return err
+	}
+
+	r.Currency = runCtx.Config.Currency
+
+	savingsplans.Apply(runCtx.Config.SavingsPlans, projects, &r)
+
+	dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
+	result, err := dashboardClient.AddRun(runCtx, projectContexts, r)
+	if err != nil {
+		log.Errorf("Error reporting run: %s", err)
+	}
+
+	r.RunID, r.ShareURL = result.RunID, result.ShareURL
+
+	if !runCtx.Config.IncludeCloudResourceIDs {
+		r = output.StripCloudResourceIDs(r)
+	}
+
+	opts := output.Options{
+		DashboardEnabled: runCtx.Config.EnableDashboard,
+		ShowSkipped:      runCtx.Config.ShowSkipped,
+		NoColor:          runCtx.Config.NoColor,
+		Fields:           runCtx.Config.Fields,
+	}
+
+	var b []byte
+
+	switch strings.ToLower(runCtx.Config.Format) {
+	case "json":
+		b, err = output.ToJSON(r, opts)
+	case "html":
+		b, err = output.ToHTML(r, opts)
+	case "diff":
+		b, err = output.ToDiff(r, opts)
+	default:
+		b, err = output.ToTable(r, opts)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "Error generating output")
+	}
+
+	if runCtx.Config.Format == "diff" || runCtx.Config.Format == "table" {
+		lines := bytes.Count(b, []byte("\n")) + 1
+		runCtx.SetContextValue("lineCount", lines)
+	}
+
+	var policyResults policy.Results
+	if policyFile != nil {
+		policyResults = policy.Evaluate(policyFile, r)
+		runCtx.SetContextValue("policyCount", len(policyResults))
+		runCtx.SetContextValue("policyViolationCount", policyResults.ViolationCount())
+	}
+
+	runCtx.SetContextValue("priceWarningCount", len(r.Warnings))
+
+	env := buildRunEnv(runCtx, projectContexts, r)
+	err = pricingClient.AddEvent("infracost-run", env)
+	if err != nil {
+		log.Errorf("Error reporting event: %s", err)
+	}
+
+	// Print a new line to separate the logs from the output
+	if runCtx.Config.IsLogging() {
+		cmd.PrintErrln()
+	}
+
+	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+		err = saveOutFile(cmd, outFile, b)
+		if err != nil {
+			return err
+		}
+	} else {
+		cmd.Println(string(b))
+	}
+
+	if policyFile != nil {
+		cmd.PrintErrln()
+		cmd.PrintErr(policy.ToText(policyResults))
+
+		if count := policyResults.ViolationCount(); count > 0 {
+			return clierror.NewExitError(fmt.Errorf("Cost policy check failed with %d violations", count), policy.ExitCode)
+		}
+	}
+
+	if runCtx.Config.StrictPricing && len(r.Warnings) > 0 {
+		return fmt.Errorf("Strict pricing failed: %d cost components had pricing warnings, their costs might be wrong", len(r.Warnings))
+	}
+
+	return nil
+}
+
+// runProjects loads the resources for all the projects in the config, then
+// prices them using the pricing client and calculates their costs.
+func runProjects(cmd *cobra.Command, runCtx *config.RunContext, pricingClient *apiclient.PricingAPIClient) ([]*schema.Project, []*config.ProjectContext, error) {
+	parallelism, err := getParallelism(cmd, runCtx)
+	if err != nil {
+		return nil, nil, err
+	}
 	runCtx.SetContextValue("parallelism", parallelism)
 
 	numJobs := len(runCtx.Config.Projects)
@@ -89,7 +221,7 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		runCtx.Config.LogLevel = "info"
 		err := runCtx.Config.ConfigureLogger()
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
@@ -132,7 +264,7 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 
 	err = errGroup.Wait()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	close(projectContextChan)
@@ -155,72 +287,32 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		projects = append(projects, projectResults.projects...)
 	}
 
-	r, err := output.ToOutputFormat(projects)
-	if err != nil {
-		return err
-	}
-
-	r.Currency = runCtx.Config.Currency
-
-	dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
-	result, err := dashboardClient.AddRun(runCtx, projectContexts, r)
-	if err != nil {
-		log.Errorf("Error reporting run: %s", err)
-	}
-
-	r.RunID, r.ShareURL = result.RunID, result.ShareURL
-
-	opts := output.Options{
-		DashboardEnabled: runCtx.Config.EnableDashboard,
-		ShowSkipped:      runCtx.Config.ShowSkipped,
-		NoColor:          runCtx.Config.NoColor,
-		Fields:           runCtx.Config.Fields,
-	}
-
-	var b []byte
+	// Resources from a Terraform state JSON baseline are priced along with the
+	// projects so any identical price queries are only run once.
+	pricedProjects := projects
 
-	switch strings.ToLower(runCtx.Config.Format) {
-	case "json":
-		b, err = output.ToJSON(r, opts)
-	case "html":
-		b, err = output.ToHTML(r, opts)
-	case "diff":
-		b, err = output.ToDiff(r, opts)
-	default:
-		b, err = output.ToTable(r, opts)
-	}
-
-	if err != nil {
-		return errors.Wrap(err, "Error generating output")
-	}
+	var baseline *compareToBaseline
+	if runCtx.Config.CompareToPath != "" {
+		baseline, err = loadCompareTo(cmd, runCtx)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	if runCtx.Config.Format == "diff" || runCtx.Config.Format == "table" {
-		lines := bytes.Count(b, []byte("\n")) + 1
-		runCtx.SetContextValue("lineCount", lines)
+		if baseline.needsPricing {
+			pricedProjects = append(append([]*schema.Project{}, projects...), baseline.projects...)
+		}
 	}
 
-	env := buildRunEnv(runCtx, projectContexts, r)
-	pricingClient := apiclient.NewPricingAPIClient(runCtx)
-	err = pricingClient.AddEvent("infracost-run", env)
+	err = populatePrices(cmd, runCtx, pricedProjects, pricingClient)
 	if err != nil {
-		log.Errorf("Error reporting event: %s", err)
+		return nil, nil, err
 	}
 
-	// Print a new line to separate the logs from the output
-	if runCtx.Config.IsLogging() {
-		cmd.PrintErrln()
-	}
-
-	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
-		err = saveOutFile(cmd, outFile, b)
-		if err != nil {
-			return err
-		}
-	} else {
-		cmd.Println(string(b))
+	if baseline != nil {
+		baseline.apply(projects)
 	}
 
-	return nil
+	return projects, projectContexts, nil
 }
 
 func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config.ProjectContext, projectCfg *config.Project, mux *sync.Mutex) ([]*schema.Project, error) {
@@ -251,6 +343,7 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 		m := "Cannot use Terraform state JSON with the infracost diff command.\n\n"
 		m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
 		m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file"
+		m += fmt.Sprintf("\n\nTo diff against a Terraform state JSON file use the %s flag.", ui.PrimaryString("--compare-to"))
 		return []*schema.Project{}, clierror.NewSanitizedError(errors.New(m), "Cannot use Terraform state JSON with the infracost diff command")
 	}
 
@@ -269,6 +362,12 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 		}
 	}
 
+	return loadProjectResources(cmd, ctx, projectCfg, provider)
+}
+
+// loadProjectResources loads the usage file for the project and then uses it
+// to load the resources from the provider.
+func loadProjectResources(cmd *cobra.Command, ctx *config.ProjectContext, projectCfg *config.Project, provider schema.Provider) ([]*schema.Project, error) {
 	// Load usage data
 	usageData := make(map[string]*schema.UsageData)
 	var usageFile *usage.UsageFile
@@ -328,38 +427,115 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 		return projects, err
 	}
 
+	if ctx.RunContext.Config.EstimateUsage {
+		estimatedUsageData := estimateUsage(cmd, ctx, projects, usageData)
+		usage.ApplyEstimatedUsage(projects, usageData, estimatedUsageData)
+	}
+
+	return projects, nil
+}
+
+// estimateUsage estimates the usage of the supported resources from the cloud
+// provider and returns the usage data with the estimates added. Resources that
+// fail estimation are reported and keep their existing usage data.
+func estimateUsage(cmd *cobra.Command, ctx *config.ProjectContext, projects []*schema.Project, usageData map[string]*schema.UsageData) map[string]*schema.UsageData {
+	runCtx := ctx.RunContext
+
 	spinnerOpts := ui.SpinnerOptions{
 		EnableLogging: runCtx.Config.IsLogging(),
 		NoColor:       runCtx.Config.NoColor,
 		Indent:        "  ",
 	}
-	spinner := ui.NewSpinner("Calculating monthly cost estimate", spinnerOpts)
+
+	spinner := ui.NewSpinner("Estimating usage data from cloud", spinnerOpts)
 	defer spinner.Fail()
 
-	for _, project := range projects {
-		if err := prices.PopulatePrices(runCtx, project); err != nil {
-			spinner.Fail()
-			fmt.Fprintln(os.Stderr, "")
-
-			if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
-				return projects, fmt.Errorf("%v\n%s %s %s %s %s\n%s",
-					e.Error(),
-					"Please check your",
-					ui.PrimaryString(config.CredentialsFilePath()),
-					"file or",
-					ui.PrimaryString("INFRACOST_API_KEY"),
-					"environment variable.",
-					"If you continue having issues please email hello@infracost.io",
-				)
-			}
+	estimatedUsageData, result := usage.EstimateUsageData(usageEstimationContext(runCtx), projects, usageData)
+	ctx.SetFrom(result)
 
-			if e, ok := err.(*apiclient.APIError); ok {
-				return projects, fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
-			}
+	spinner.Success()
+
+	pluralized := ""
+	if result.ResourceCount != 1 {
+		pluralized = "s"
+	}
+
+	cmd.PrintErrln(fmt.Sprintf("    %s Estimated %d of %d resource%s",
+		ui.FaintString("└─"),
+		result.ResourceCount-len(result.EstimationErrors),
+		result.ResourceCount,
+		pluralized))
 
-			return projects, err
+	if len(result.EstimationErrors) > 0 {
+		names := make([]string, 0, len(result.EstimationErrors))
+		for name := range result.EstimationErrors {
+			names = append(names, name)
 		}
+		sort.Strings(names)
 
+		msg := "Could not estimate usage for the following resources, they will use the usage file or default values:"
+		for _, name := range names {
+			msg += fmt.Sprintf("\n  ∙ %s: %s", name, result.EstimationErrors[name])
+		}
+		ui.PrintWarning(cmd.ErrOrStderr(), msg)
+	}
+
+	return estimatedUsageData
+}
+
+// populatePrices prices the resources from all the projects together so that
+// identical price queries across projects are only run once, then calculates
+// the costs for each project.
+func populatePrices(cmd *cobra.Command, runCtx *config.RunContext, projects []*schema.Project, pricingClient *apiclient.PricingAPIClient) error {
+	var overrides *priceoverrides.File
+	if runCtx.Config.PriceOverridesFile != "" {
+		var err error
+		overrides, err = priceoverrides.LoadFile(runCtx.Config.PriceOverridesFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	spinnerOpts := ui.SpinnerOptions{
+		EnableLogging: runCtx.Config.IsLogging(),
+		NoColor:       runCtx.Config.NoColor,
+	}
+	spinner := ui.NewSpinner("Calculating monthly cost estimate", spinnerOpts)
+	defer spinner.Fail()
+
+	if err := prices.PopulatePrices(pricingClient, projects, overrides); err != nil {
+		spinner.Fail()
+		fmt.Fprintln(os.Stderr, "")
+
+		if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
+			return fmt.Errorf("%v\n%s %s %s %s %s\n%s",
+				e.Error(),
+				"Please check your",
+				ui.PrimaryString(config.CredentialsFilePath()),
+				"file or",
+				ui.PrimaryString("INFRACOST_API_KEY"),
+				"environment variable.",
+				"If you continue having issues please email hello@infracost.io",
+			)
+		}
+
+		if e, ok := err.(*apiclient.APIError); ok {
+			return fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
+		}
+
+		return err
+	}
+
+	stats := pricingClient.QueryStats()
+	log.Debugf("Ran %d price queries, %d were sent to the Cloud Pricing API in %d requests (%d saved by de-duplication and caching, %d retries)",
+		stats.Total, stats.Sent, stats.Batches, stats.Saved(), stats.Retries)
+
+	runCtx.SetContextValue("priceQueryCount", stats.Total)
+	runCtx.SetContextValue("priceQueriesSent", stats.Sent)
+	runCtx.SetContextValue("priceQueriesSaved", stats.Saved())
+	runCtx.SetContextValue("priceQueryRetries", stats.Retries)
+
+	for _, project := range projects {
 		schema.CalculateCosts(project)
 		project.CalculateDiff()
 	}
@@ -370,7 +546,7 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 		cmd.PrintErrln()
 	}
 
-	return projects, nil
+	return nil
 }
 
 func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx *config.ProjectContext, projectCfg *config.Project, provider schema.Provider) error {
@@ -407,7 +583,7 @@ func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx
 	spinner := ui.NewSpinner("Syncing usage data from cloud", spinnerOpts)
 	defer spinner.Fail()
 
-	syncResult, err := usage.SyncUsageData(usageFile, providerProjects)
+	syncResult, err := usage.SyncUsageData(usageEstimationContext(runCtx), usageFile, providerProjects)
 
 	if err != nil {
 		spinner.Fail()
@@ -431,8 +607,8 @@ func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx
 	} else {
 		resources := syncResult.ResourceCount
 		attempts := syncResult.EstimationCount
-		errors := len(syncResult.EstimationErrors)
-		successes := attempts - errors
+		estimationErrors := len(syncResult.EstimationErrors)
+		successes := attempts - estimationErrors
 
 		pluralized := ""
 		if resources > 1 {
@@ -445,10 +621,170 @@ func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx
 			successes,
 			resources,
 			pluralized))
+
+		err = remediateUsageEstimation(cmd, runCtx, syncResult)
+		if err != nil {
+			return errors.Wrap(err, "Error remediating usage estimation")
+		}
+
+		projectCtx.SetFrom(syncResult)
 	}
 	return nil
 }
 
+// usageEstimationContext returns the context for estimating usage from the
+// cloud providers, using the endpoints from the config if they're set.
+func usageEstimationContext(runCtx *config.RunContext) context.Context {
+	ctx := context.Background()
+
+	if runCtx.Config.AWSEndpoint != "" {
+		ctx = awsusage.WithEndpoint(ctx, runCtx.Config.AWSEndpoint)
+	}
+
+	if runCtx.Config.GoogleMonitoringEndpoint != "" {
+		ctx = googleusage.WithEndpoint(ctx, runCtx.Config.GoogleMonitoringEndpoint)
+	}
+
+	if runCtx.Config.AzureEndpoint != "" {
+		ctx = azureusage.WithEndpoint(ctx, runCtx.Config.AzureEndpoint)
+	}
+
+	return ctx
+}
+
+// remediateUsageEstimation handles the resources whose usage couldn't be
+// estimated until a change is made in the cloud. By default these are only
+// reported. With --remediate-dry-run the API calls are printed, and with
+// --remediate the changes are made, prompting before each one if we're
+// running in a terminal.
+func remediateUsageEstimation(cmd *cobra.Command, runCtx *config.RunContext, syncResult *usage.SyncResult) error {
+	remediations := syncResult.Remediations()
+	if len(remediations) == 0 {
+		return nil
+	}
+
+	if runCtx.Config.RemediateDryRun {
+		msg := "Dry run, remediate would make the following API calls:"
+		for _, r := range remediations {
+			msg += fmt.Sprintf("\n  ∙ %s: %s", r.ResourceName, r.Remediater.Describe())
+			for _, call := range r.Remediater.APICalls() {
+				msg += fmt.Sprintf("\n      %s", call)
+			}
+		}
+		cmd.PrintErrln(msg)
+
+		return nil
+	}
+
+	if !runCtx.Config.Remediate {
+		msg := fmt.Sprintf("The following resources need changes in the cloud before their usage can be estimated, run with %s to make them or %s to see the API calls:",
+			ui.PrimaryString("--remediate"),
+			ui.PrimaryString("--remediate-dry-run"),
+		)
+		for _, r := range remediations {
+			msg += fmt.Sprintf("\n  ∙ %s: %s", r.ResourceName, r.Remediater.Describe())
+		}
+		ui.PrintWarning(cmd.ErrOrStderr(), msg)
+
+		return nil
+	}
+
+	var confirm func(usage.Remediation) (bool, error)
+	if isInteractive(runCtx) {
+		confirm = promptRemediation
+	}
+
+	err := syncResult.Remediate(confirm)
+	if err != nil {
+		return err
+	}
+
+	attempts := syncResult.RemediationAttempts
+	successes := attempts - len(syncResult.RemediationErrors)
+
+	pluralized := ""
+	if attempts != 1 {
+		pluralized = "s"
+	}
+
+	cmd.PrintErrln(fmt.Sprintf("    %s Remediated %d of %d resource%s, usage can be estimated once the cloud provider has collected the metrics",
+		ui.FaintString("└─"),
+		successes,
+		attempts,
+		pluralized))
+
+	return nil
+}
+
+func promptRemediation(r usage.Remediation) (bool, error) {
+	p := promptui.Prompt{
+		Label:     fmt.Sprintf("%s: May we %s", r.ResourceName, r.Remediater.Describe()),
+		IsConfirm: true,
+	}
+
+	_, err := p.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrAbort) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isInteractive returns true if we can prompt the user, i.e. we're not running
+// in CI and stdin is a terminal.
+func isInteractive(runCtx *config.RunContext) bool {
+	if runCtx.IsCIRun() {
+		return false
+	}
+
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// newPricingAPIClient returns the pricing client for the run. If a price
+// snapshot has been specified then all prices are read from it instead of
+// the Cloud Pricing API.
+func newPricingAPIClient(runCtx *config.RunContext) (*apiclient.PricingAPIClient, error) {
+	c := apiclient.NewPricingAPIClient(runCtx)
+
+	if runCtx.Config.PricingSnapshotPath == "" {
+		return c, nil
+	}
+
+	snapshot, err := apiclient.LoadPriceSnapshot(runCtx.Config.PricingSnapshotPath)
+	if err != nil {
+		return c, err
+	}
+
+	if snapshot.Currency != c.Currency {
+		return c, fmt.Errorf("Price snapshot %s was exported with currency %s but the run is using %s.\nRe-export the snapshot with %s",
+			runCtx.Config.PricingSnapshotPath,
+			snapshot.Currency,
+			c.Currency,
+			ui.PrimaryString("infracost prices export"),
+		)
+	}
+
+	runCtx.SetContextValue("usingPriceSnapshot", true)
+	c.UseSnapshot(snapshot)
+
+	return c, nil
+}
+
+// usingPriceSnapshot returns true if the prices for the run should be read
+// from a price snapshot, in which case no API key is needed.
+func usingPriceSnapshot(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("pricing-snapshot")
+}
+
 func getParallelism(cmd *cobra.Command, runCtx *config.RunContext) (int, error) {
 	var parallelism int
 
@@ -493,11 +829,15 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		cmd.Flags().Changed("usage-file") ||
 		cmd.Flags().Changed("terraform-plan-flags") ||
 		cmd.Flags().Changed("terraform-workspace") ||
-		cmd.Flags().Changed("terraform-use-state"))
+		cmd.Flags().Changed("terraform-use-state") ||
+		cmd.Flags().Changed("terraform-parse-hcl") ||
+		cmd.Flags().Changed("parameter-overrides") ||
+		cmd.Flags().Changed("parameters-file") ||
+		cmd.Flags().Changed("previous-template"))
 
 	if hasConfigFile && hasProjectFlags {
 		m := "--config-file flag cannot be used with the following flags: "
-		m += "--path, --terraform-*, --usage-file"
+		m += "--path, --terraform-*, --usage-file, --parameter-overrides, --parameters-file, --previous-template"
 		ui.PrintUsage(cmd)
 		return errors.New(m)
 	}
@@ -513,6 +853,14 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		if cmd.Flags().Changed("terraform-workspace") {
 			projectCfg.TerraformWorkspace, _ = cmd.Flags().GetString("terraform-workspace")
 		}
+
+		if cmd.Flags().Changed("terraform-parse-hcl") {
+			projectCfg.TerraformParseHCL, _ = cmd.Flags().GetBool("terraform-parse-hcl")
+		}
+
+		projectCfg.ParameterOverrides, _ = cmd.Flags().GetString("parameter-overrides")
+		projectCfg.ParametersFile, _ = cmd.Flags().GetString("parameters-file")
+		projectCfg.PreviousTemplatePath, _ = cmd.Flags().GetString("previous-template")
 	}
 
 	if hasConfigFile {
@@ -526,6 +874,10 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 
 	cfg.NoCache, _ = cmd.Flags().GetBool("no-cache")
 
+	if cmd.Flags().Changed("price-overrides-file") {
+		cfg.PriceOverridesFile, _ = cmd.Flags().GetString("price-overrides-file")
+	}
+
 	cfg.Format, _ = cmd.Flags().GetString("format")
 
 	if cfg.Format != "" && !contains(validRunFormats, cfg.Format) {
@@ -534,7 +886,23 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	}
 
 	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+
+	if cmd.Flags().Changed("strict-pricing") {
+		cfg.StrictPricing, _ = cmd.Flags().GetBool("strict-pricing")
+	}
+
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
+	cfg.Remediate, _ = cmd.Flags().GetBool("remediate")
+	cfg.RemediateDryRun, _ = cmd.Flags().GetBool("remediate-dry-run")
+	cfg.EstimateUsage, _ = cmd.Flags().GetBool("estimate-usage")
+	cfg.IncludeCloudResourceIDs, _ = cmd.Flags().GetBool("include-cloud-resource-ids")
+
+	if usingPriceSnapshot(cmd) {
+		cfg.PricingSnapshotPath, _ = cmd.Flags().GetString("pricing-snapshot")
+
+		// The run is offline so don't try to send any events to the Cloud Pricing API
+		cfg.EventsDisabled = true
+	}
 
 	includeAllFields := "all"
 	validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
@@ -585,6 +953,10 @@ func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
 		}
 	}
 
+	if (cfg.Remediate || cfg.RemediateDryRun) && !cfg.SyncUsageFile {
+		ui.PrintWarning(warningWriter, "Ignoring remediate as sync-usage-file is not specified.\n")
+	}
+
 	if money.GetCurrency(cfg.Currency) == nil {
 		ui.PrintWarning(warningWriter, fmt.Sprintf("Ignoring unknown currency '%s', using USD.\n", cfg.Currency))
 		cfg.Currency = "USD"
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/billing"
+)
+
+func usageCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Manage usage files",
+		Long:  "Manage usage files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Show the help
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(usageImportCmd(ctx))
+
+	return cmd
+}
+
+func usageImportCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import usage from a cloud billing export into a usage file",
+		Long: `Import usage from a cloud billing export into a usage file.
+
+Line items in the export are matched to resources by the resource IDs and ARNs
+in the Terraform state, or by the resource tags, and the monthly quantities
+observed are written to the usage file. AWS Cost and Usage Report CSV and
+Parquet files, and Azure cost export and Google Cloud billing export CSV files
+are supported.`,
+		Example: `  Import usage from an AWS Cost and Usage Report:
+
+      infracost usage import --path /path/to/code --usage-file infracost-usage.yml --from cur.csv
+
+  Use the imported usage:
+
+      infracost breakdown --path /path/to/code --usage-file infracost-usage.yml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			from, _ := cmd.Flags().GetString("from")
+			export, err := billing.LoadExport(from)
+			if err != nil {
+				return errors.Wrap(err, "Error loading billing export")
+			}
+
+			projectCfg := ctx.Config.Projects[0]
+			projectCtx := config.NewProjectContext(ctx, projectCfg)
+
+			provider, err := providers.Detect(projectCtx)
+			if err != nil {
+				m := fmt.Sprintf("%s\n\n", err)
+				m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
+				m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file\n - Terraform state JSON file"
+
+				return clierror.NewSanitizedError(errors.New(m), "Could not detect path type")
+			}
+
+			fmt.Fprintf(os.Stderr, "Detected %s at %s\n", provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
+
+			return importUsageFile(cmd, ctx, projectCtx, projectCfg, provider, export)
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file to import the usage into, it is created if it does not exist")
+	cmd.Flags().String("from", "", "Path to the AWS Cost and Usage Report CSV or Parquet file, or the Azure cost export or Google Cloud billing export CSV file")
+
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
+
+	_ = cmd.MarkFlagRequired("usage-file")
+	_ = cmd.MarkFlagRequired("from")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("from", "csv", "gz", "parquet")
+
+	return cmd
+}
+
+func importUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx *config.ProjectContext, projectCfg *config.Project, provider schema.Provider, export *billing.Export) error {
+	err := usage.CreateUsageFile(projectCfg.UsageFile)
+	if err != nil {
+		return errors.Wrap(err, "Error creating usage file")
+	}
+
+	usageFile, err := usage.LoadUsageFile(projectCfg.UsageFile)
+	if err != nil {
+		return errors.Wrap(err, "Error loading usage file")
+	}
+
+	projects, err := provider.LoadResources(usageFile.ToUsageDataMap())
+	if err != nil {
+		return errors.Wrap(err, "Error loading resources")
+	}
+
+	spinnerOpts := ui.SpinnerOptions{
+		EnableLogging: runCtx.Config.IsLogging(),
+		NoColor:       runCtx.Config.NoColor,
+		Indent:        "  ",
+	}
+
+	spinner := ui.NewSpinner("Importing usage data from billing export", spinnerOpts)
+	defer spinner.Fail()
+
+	result, err := usage.ImportUsageData(context.Background(), usageFile, projects, export)
+	if err != nil {
+		return errors.Wrap(err, "Error importing usage data")
+	}
+
+	err = usageFile.WriteToPath(projectCfg.UsageFile)
+	if err != nil {
+		return errors.Wrap(err, "Error writing usage file")
+	}
+
+	projectCtx.SetFrom(result)
+
+	pluralized := ""
+	if result.ResourceCount > 1 {
+		pluralized = "s"
+	}
+
+	spinner.Success()
+	cmd.PrintErrln(fmt.Sprintf("    %s Imported usage for %d of %d resource%s",
+		ui.FaintString("└─"),
+		result.EstimationCount,
+		result.ResourceCount,
+		pluralized))
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// DefaultPriceCacheDir is where price query results are cached between runs.
+// It sits in the same .infracost directory used by the Terraform plan cache.
+var DefaultPriceCacheDir = filepath.Join(".infracost", "pricing-cache")
+
+var priceCacheFileExt = ".json"
+
+// PriceCache de-duplicates price queries within a run and, if it has a
+// directory, persists their results between runs. Results are stored by a
+// hash of the pricing API endpoint, the query variables and the currency so
+// identical queries from different resources and projects share the same
+// entry.
+type PriceCache struct {
+	dir string
+	ttl time.Duration
+
+	results map[string]gjson.Result
+	mux     *sync.RWMutex
+}
+
+// PriceCacheStats summarises the entries stored in a price cache directory.
+type PriceCacheStats struct {
+	Entries   int
+	Expired   int
+	SizeBytes int64
+	Oldest    time.Time
+	Newest    time.Time
+}
+
+// NewPriceCache returns a cache that persists results to dir for the given
+// TTL. If dir is empty results are only cached in memory for the run.
+func NewPriceCache(dir string, ttl time.Duration) *PriceCache {
+	return &PriceCache{
+		dir:     dir,
+		ttl:     ttl,
+		results: make(map[string]gjson.Result),
+		mux:     &sync.RWMutex{},
+	}
+}
+
+// Get returns the cached result for the key, checking the in-memory results
+// first and then the cache directory.
+func (c *PriceCache) Get(key string) (gjson.Result, bool) {
+	c.mux.RLock()
+	result, ok := c.results[key]
+	c.mux.RUnlock()
+
+	if ok {
+		return result, true
+	}
+
+	result, ok = c.readFile(key)
+	if !ok {
+		return gjson.Result{}, false
+	}
+
+	c.mux.Lock()
+	c.results[key] = result
+	c.mux.Unlock()
+
+	return result, true
+}
+
+// Set caches the result for the key. Failures writing to the cache directory
+// are logged and otherwise ignored since the cache is only an optimisation.
+func (c *PriceCache) Set(key string, result gjson.Result) {
+	c.mux.Lock()
+	c.results[key] = result
+	c.mux.Unlock()
+
+	c.writeFile(key, result)
+}
+
+func (c *PriceCache) readFile(key string) (gjson.Result, bool) {
+	if c.dir == "" {
+		return gjson.Result{}, false
+	}
+
+	p := c.path(key)
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return gjson.Result{}, false
+	}
+
+	if c.expired(info.ModTime()) {
+		log.Debugf("Skipping price cache entry %s: entry is too old", key)
+		return gjson.Result{}, false
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		log.Debugf("Skipping price cache entry %s: error reading file: %v", key, err)
+		return gjson.Result{}, false
+	}
+
+	if !gjson.ValidBytes(data) {
+		log.Debugf("Skipping price cache entry %s: bad format", key)
+		return gjson.Result{}, false
+	}
+
+	return gjson.ParseBytes(data), true
+}
+
+func (c *PriceCache) writeFile(key string, result gjson.Result) {
+	if c.dir == "" || result.Raw == "" {
+		return
+	}
+
+	err := os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		log.Debugf("Couldn't create price cache directory %s: %v", c.dir, err)
+		return
+	}
+
+	err = os.WriteFile(c.path(key), []byte(result.Raw), 0600)
+	if err != nil {
+		log.Debugf("Failed to write price cache entry %s: %v", key, err)
+	}
+}
+
+func (c *PriceCache) path(key string) string {
+	return filepath.Join(c.dir, key+priceCacheFileExt)
+}
+
+func (c *PriceCache) expired(modified time.Time) bool {
+	return c.ttl > 0 && time.Since(modified) > c.ttl
+}
+
+// priceCacheKey returns a hash of the pricing API endpoint, the query
+// variables and the currency, since self-hosted pricing APIs can have
+// different prices. encoding/json sorts map keys and writes struct fields in
+// order so the same filters always produce the same key.
+func priceCacheKey(endpoint string, q GraphQLQuery, currency string) (string, error) {
+	b, err := json.Marshal(q.Variables)
+	if err != nil {
+		return "", errors.Wrap(err, "Error generating price cache key")
+	}
+
+	h := sha256.New()
+	h.Write([]byte(endpoint))
+	h.Write([]byte{0})
+	h.Write(b)
+	h.Write([]byte{0})
+	h.Write([]byte(currency))
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ReadPriceCacheStats returns the stats for the price cache in dir. Entries
+// older than the TTL are counted as expired.
+func ReadPriceCacheStats(dir string, ttl time.Duration) (PriceCacheStats, error) {
+	stats := PriceCacheStats{}
+	c := NewPriceCache(dir, ttl)
+
+	err := walkPriceCache(dir, func(path string, info os.FileInfo) error {
+		stats.Entries++
+		stats.SizeBytes += info.Size()
+
+		if c.expired(info.ModTime()) {
+			stats.Expired++
+		}
+
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return stats, err
+}
+
+// ClearPriceCache removes all the entries from the price cache in dir and
+// returns the number of entries that were removed.
+func ClearPriceCache(dir string) (int, error) {
+	count := 0
+
+	err := walkPriceCache(dir, func(path string, info os.FileInfo) error {
+		err := os.Remove(path)
+		if err != nil {
+			return errors.Wrap(err, "Error removing price cache entry")
+		}
+
+		count++
+		return nil
+	})
+
+	return count, err
+}
+
+func walkPriceCache(dir string, fn func(path string, info os.FileInfo) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "Error reading price cache directory")
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), priceCacheFileExt) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		err = fn(filepath.Join(dir, entry.Name()), info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
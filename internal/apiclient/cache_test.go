@@ -0,0 +1,96 @@
+package apiclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestPriceCacheKey(t *testing.T) {
+	endpoint := "https://pricing.api.infracost.io"
+
+	k1, err := priceCacheKey(endpoint, snapshotTestQuery("m5.large"), "USD")
+	require.NoError(t, err)
+
+	k2, err := priceCacheKey(endpoint, snapshotTestQuery("m5.large"), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, k1, k2)
+
+	k3, err := priceCacheKey(endpoint, snapshotTestQuery("m5.large"), "EUR")
+	require.NoError(t, err)
+	assert.NotEqual(t, k1, k3)
+
+	k4, err := priceCacheKey(endpoint, snapshotTestQuery("t3.micro"), "USD")
+	require.NoError(t, err)
+	assert.NotEqual(t, k1, k4)
+
+	k5, err := priceCacheKey("https://pricing.example.com", snapshotTestQuery("m5.large"), "USD")
+	require.NoError(t, err)
+	assert.NotEqual(t, k1, k5)
+}
+
+func TestPriceCachePersistsResults(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pricing-cache")
+	result := gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.096"}]}]}}`)
+
+	NewPriceCache(dir, time.Hour).Set("abc", result)
+
+	got, ok := NewPriceCache(dir, time.Hour).Get("abc")
+	require.True(t, ok)
+	assert.Equal(t, "0.096", got.Get("data.products.0.prices.0.USD").String())
+
+	_, ok = NewPriceCache(dir, time.Hour).Get("def")
+	assert.False(t, ok)
+}
+
+func TestPriceCacheExpiresResults(t *testing.T) {
+	dir := t.TempDir()
+	c := NewPriceCache(dir, time.Hour)
+	c.Set("abc", gjson.Parse(`{"data":{"products":[]}}`))
+
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(c.path("abc"), old, old))
+
+	_, ok := NewPriceCache(dir, time.Hour).Get("abc")
+	assert.False(t, ok)
+
+	stats, err := ReadPriceCacheStats(dir, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, 1, stats.Expired)
+}
+
+func TestPriceCacheWithoutDir(t *testing.T) {
+	c := NewPriceCache("", time.Hour)
+	c.Set("abc", gjson.Parse(`{"data":{"products":[]}}`))
+
+	_, ok := c.Get("abc")
+	assert.True(t, ok)
+
+	_, ok = NewPriceCache("", time.Hour).Get("abc")
+	assert.False(t, ok)
+}
+
+func TestClearPriceCache(t *testing.T) {
+	dir := t.TempDir()
+	c := NewPriceCache(dir, time.Hour)
+	c.Set("abc", gjson.Parse(`{"data":{"products":[]}}`))
+	c.Set("def", gjson.Parse(`{"data":{"products":[]}}`))
+
+	count, err := ClearPriceCache(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	stats, err := ReadPriceCacheStats(dir, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Entries)
+
+	count, err = ClearPriceCache(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
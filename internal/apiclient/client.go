@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -31,6 +34,9 @@ type GraphQLQuery struct {
 type APIError struct {
 	err error
 	msg string
+
+	statusCode int
+	retryAfter time.Duration
 }
 
 func (e *APIError) Error() string {
@@ -43,6 +49,18 @@ type APIErrorResponse struct {
 
 var ErrInvalidAPIKey = errors.New("Invalid API key")
 
+var (
+	maxRequestRetries     = 4
+	requestRetryBaseDelay = 500 * time.Millisecond
+	requestRetryMaxDelay  = 10 * time.Second
+)
+
+// retryable returns true if the request that caused the error can be sent
+// again, i.e. the API is rate limiting us or had a server error.
+func (e *APIError) retryable() bool {
+	return e.statusCode == http.StatusTooManyRequests || e.statusCode >= 500
+}
+
 func (c *APIClient) doQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
 	if len(queries) == 0 {
 		log.Debug("Skipping GraphQL request as no queries have been specified")
@@ -53,6 +71,47 @@ func (c *APIClient) doQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
 	return gjson.ParseBytes(respBody).Array(), err
 }
 
+// doRequestWithRetry sends the request, retrying with exponential backoff if
+// the API responds with a 429 or 5xx status. It returns the number of retries
+// that were needed. This should only be used for idempotent requests.
+func (c *APIClient) doRequestWithRetry(method string, path string, d interface{}) ([]byte, int, error) {
+	for attempt := 0; ; attempt++ {
+		respBody, err := c.doRequest(method, path, d)
+		if err == nil || attempt >= maxRequestRetries {
+			return respBody, attempt, err
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !apiErr.retryable() {
+			return respBody, attempt, err
+		}
+
+		delay := retryDelay(attempt, apiErr.retryAfter)
+		log.Debugf("Retrying request to %s in %s: %v", path, delay, err)
+		time.Sleep(delay)
+	}
+}
+
+// retryDelay returns the delay before the next retry. The delay doubles on
+// each attempt with some jitter so concurrent requests don't retry in step.
+// If the API sent a Retry-After header that is used instead.
+func retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if retryAfter > requestRetryMaxDelay {
+			return requestRetryMaxDelay
+		}
+		return retryAfter
+	}
+
+	delay := requestRetryBaseDelay << uint(attempt)
+	if delay <= 0 || delay > requestRetryMaxDelay {
+		delay = requestRetryMaxDelay
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1)) // nolint:gosec
+	return delay/2 + jitter
+}
+
 func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte, error) {
 	reqBody, err := json.Marshal(d)
 	if err != nil {
@@ -76,7 +135,7 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return []byte{}, &APIError{err, "Invalid API response"}
+		return []byte{}, &APIError{err: err, msg: "Invalid API response"}
 	}
 
 	if resp.StatusCode != 200 {
@@ -84,13 +143,23 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 
 		err = json.Unmarshal(respBody, &r)
 		if err != nil {
-			return []byte{}, &APIError{fmt.Errorf(resp.Status), "Invalid API response"}
+			return []byte{}, &APIError{
+				err:        fmt.Errorf(resp.Status),
+				msg:        "Invalid API response",
+				statusCode: resp.StatusCode,
+				retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			}
 		}
 
 		if r.Error == "Invalid API key" {
 			return []byte{}, ErrInvalidAPIKey
 		}
-		return []byte{}, &APIError{fmt.Errorf("%v %v", resp.Status, r.Error), "Received error from API"}
+		return []byte{}, &APIError{
+			err:        fmt.Errorf("%v %v", resp.Status, r.Error),
+			msg:        "Received error from API",
+			statusCode: resp.StatusCode,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
 	return respBody, nil
@@ -109,6 +178,17 @@ func (c *APIClient) AddAuthHeaders(req *http.Request) {
 	}
 }
 
+// parseRetryAfter parses the delay in seconds from a Retry-After header. The
+// HTTP date form isn't supported since the Cloud Pricing API doesn't use it.
+func parseRetryAfter(v string) time.Duration {
+	secs, err := strconv.Atoi(v)
+	if err != nil || secs < 0 {
+		return 0
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
 func userAgent() string {
 	userAgent := "infracost"
 
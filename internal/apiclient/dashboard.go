@@ -79,6 +79,9 @@ func (c *DashboardAPIClient) AddRun(ctx *config.RunContext, projectContexts []*c
 		return response, nil
 	}
 
+	// The IDs of deployed resources are only used locally for reconciling
+	out = output.StripCloudResourceIDs(out)
+
 	projectResultInputs := make([]projectResultInput, len(out.Projects))
 	for i, project := range out.Projects {
 		projectResultInputs[i] = projectResultInput{
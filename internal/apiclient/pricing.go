@@ -1,24 +1,62 @@
 package apiclient
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"sync"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
+	"golang.org/x/sync/errgroup"
 )
 
 type PricingAPIClient struct {
 	APIClient
 	Currency       string
 	EventsDisabled bool
+
+	cache           *PriceCache
+	snapshot        *PriceSnapshot
+	offlineSnapshot bool
+
+	stats    PriceQueryStats
+	statsMux *sync.Mutex
+}
+
+// PriceQueryStats counts the price queries run by a PricingAPIClient and how
+// many of them were answered without being sent to the Cloud Pricing API.
+type PriceQueryStats struct {
+	// Total is the number of cost component price queries.
+	Total int
+	// Unique is the number of queries left after de-duplication.
+	Unique int
+	// Cached is the number of unique queries answered by the price cache.
+	Cached int
+	// Sent is the number of queries sent to the Cloud Pricing API.
+	Sent int
+	// Batches is the number of requests the sent queries were split into.
+	Batches int
+	// Retries is the number of times a request was retried.
+	Retries int
 }
 
+// Saved returns the number of queries that didn't need to be sent to the
+// Cloud Pricing API.
+func (s PriceQueryStats) Saved() int {
+	return s.Total - s.Sent
+}
+
+var (
+	priceQueryBatchSize    = 100
+	priceQueryBatchWorkers = 4
+)
+
 type PriceQueryKey struct {
 	Resource      *schema.Resource
 	CostComponent *schema.CostComponent
@@ -63,6 +101,11 @@ func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 		tlsConfig.InsecureSkipVerify = *ctx.Config.TLSInsecureSkipVerify
 	}
 
+	cacheDir := DefaultPriceCacheDir
+	if ctx.Config.NoCache || ctx.Config.PricingCacheDisabled {
+		cacheDir = ""
+	}
+
 	return &PricingAPIClient{
 		APIClient: APIClient{
 			endpoint:  ctx.Config.PricingAPIEndpoint,
@@ -72,6 +115,8 @@ func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 		},
 		Currency:       currency,
 		EventsDisabled: ctx.Config.EventsDisabled,
+		cache:          NewPriceCache(cacheDir, ctx.Config.PricingCacheTTL),
+		statsMux:       &sync.Mutex{},
 	}
 }
 
@@ -89,17 +134,40 @@ func (c *PricingAPIClient) AddEvent(name string, env map[string]interface{}) err
 	return err
 }
 
-func (c *PricingAPIClient) RunQueries(r *schema.Resource) ([]PriceQueryResult, error) {
-	keys, queries := c.batchQueries(r)
+// RunQueries gets the prices for the cost components of all the resources.
+// Queries are collected across all the resources and de-duplicated so each
+// unique query is only run once, then the results are fanned back out to
+// each cost component.
+func (c *PricingAPIClient) RunQueries(resources []*schema.Resource) ([]PriceQueryResult, error) {
+	keys, queries := c.batchQueries(resources)
 
 	if len(queries) == 0 {
-		log.Debugf("Skipping getting pricing details for %s since there are no queries to run", r.Name)
+		log.Debugf("Skipping getting pricing details since there are no queries to run")
 		return []PriceQueryResult{}, nil
 	}
 
-	log.Debugf("Getting pricing details from %s for %s", c.endpoint, r.Name)
+	results, err := c.runPriceQueries(queries)
+	if err != nil {
+		return []PriceQueryResult{}, err
+	}
+
+	return c.zipQueryResults(keys, results), nil
+}
 
-	results, err := c.doQueries(queries)
+// RunFallbackQueries gets the prices for the cost components using their
+// FallbackPriceFilter. This is used for the cost components whose
+// PriceFilter didn't match any prices.
+func (c *PricingAPIClient) RunFallbackQueries(keys []PriceQueryKey) ([]PriceQueryResult, error) {
+	queries := make([]GraphQLQuery, 0, len(keys))
+	for _, k := range keys {
+		queries = append(queries, c.buildQuery(k.CostComponent.ProductFilter, k.CostComponent.FallbackPriceFilter))
+	}
+
+	if len(queries) == 0 {
+		return []PriceQueryResult{}, nil
+	}
+
+	results, err := c.runPriceQueries(queries)
 	if err != nil {
 		return []PriceQueryResult{}, err
 	}
@@ -107,6 +175,198 @@ func (c *PricingAPIClient) RunQueries(r *schema.Resource) ([]PriceQueryResult, e
 	return c.zipQueryResults(keys, results), nil
 }
 
+// QueryStats returns the stats for the price queries run by the client.
+func (c *PricingAPIClient) QueryStats() PriceQueryStats {
+	c.statsMux.Lock()
+	defer c.statsMux.Unlock()
+
+	return c.stats
+}
+
+// UseSnapshot sets the client to answer all price queries from the snapshot
+// instead of sending them to the Cloud Pricing API.
+func (c *PricingAPIClient) UseSnapshot(s *PriceSnapshot) {
+	c.snapshot = s
+	c.offlineSnapshot = true
+}
+
+// RecordSnapshot sets the client to add the results of all price queries it
+// sends to the Cloud Pricing API to the snapshot.
+func (c *PricingAPIClient) RecordSnapshot(s *PriceSnapshot) {
+	c.snapshot = s
+	c.offlineSnapshot = false
+}
+
+func (c *PricingAPIClient) runPriceQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
+	keys := make([]string, 0, len(queries))
+	uniqueKeys := make([]string, 0)
+	uniqueQueries := make([]GraphQLQuery, 0)
+	uniqueIndex := make(map[string]int)
+
+	for _, q := range queries {
+		key, err := priceCacheKey(c.endpoint, q, c.Currency)
+		if err != nil {
+			return []gjson.Result{}, err
+		}
+		keys = append(keys, key)
+
+		if _, ok := uniqueIndex[key]; !ok {
+			uniqueIndex[key] = len(uniqueQueries)
+			uniqueKeys = append(uniqueKeys, key)
+			uniqueQueries = append(uniqueQueries, q)
+		}
+	}
+
+	c.addStats(PriceQueryStats{Total: len(queries), Unique: len(uniqueQueries)})
+
+	var uniqueResults []gjson.Result
+	var err error
+
+	if c.snapshot != nil && c.offlineSnapshot {
+		log.Debugf("Getting pricing details for %d queries from price snapshot", len(uniqueQueries))
+		uniqueResults, err = c.snapshot.Results(uniqueQueries)
+		if err != nil {
+			return []gjson.Result{}, err
+		}
+	} else {
+		uniqueResults, err = c.runCachedQueries(uniqueKeys, uniqueQueries)
+		if err != nil {
+			return []gjson.Result{}, err
+		}
+
+		if c.snapshot != nil {
+			err = c.snapshot.Add(uniqueQueries, uniqueResults)
+			if err != nil {
+				return []gjson.Result{}, err
+			}
+		}
+	}
+
+	results := make([]gjson.Result, 0, len(queries))
+	for _, key := range keys {
+		results = append(results, uniqueResults[uniqueIndex[key]])
+	}
+
+	return results, nil
+}
+
+// runCachedQueries only sends the queries that aren't already cached to the
+// Cloud Pricing API. The queries should already be de-duplicated.
+func (c *PricingAPIClient) runCachedQueries(keys []string, queries []GraphQLQuery) ([]gjson.Result, error) {
+	results := make([]gjson.Result, len(queries))
+
+	uncachedIndexes := make([]int, 0)
+	uncachedQueries := make([]GraphQLQuery, 0)
+
+	for i, key := range keys {
+		if result, ok := c.cache.Get(key); ok {
+			results[i] = result
+			continue
+		}
+
+		uncachedIndexes = append(uncachedIndexes, i)
+		uncachedQueries = append(uncachedQueries, queries[i])
+	}
+
+	c.addStats(PriceQueryStats{Cached: len(queries) - len(uncachedQueries)})
+
+	if len(uncachedQueries) == 0 {
+		log.Debugf("Using cached pricing details for all %d queries", len(queries))
+		return results, nil
+	}
+
+	log.Debugf("Getting pricing details from %s for %d queries (%d cached)", c.endpoint, len(uncachedQueries), len(queries)-len(uncachedQueries))
+
+	apiResults, err := c.sendQueryBatches(uncachedQueries)
+	if err != nil {
+		return []gjson.Result{}, err
+	}
+
+	for j, i := range uncachedIndexes {
+		c.cache.Set(keys[i], apiResults[j])
+		results[i] = apiResults[j]
+	}
+
+	return results, nil
+}
+
+// sendQueryBatches splits the queries into batches of at most
+// priceQueryBatchSize queries and sends them concurrently. The results are
+// returned in the same order as the queries.
+func (c *PricingAPIClient) sendQueryBatches(queries []GraphQLQuery) ([]gjson.Result, error) {
+	results := make([]gjson.Result, len(queries))
+
+	type batch struct {
+		offset  int
+		queries []GraphQLQuery
+	}
+
+	batches := make(chan batch)
+	errGroup, ctx := errgroup.WithContext(context.Background())
+
+	for i := 0; i < priceQueryBatchWorkers; i++ {
+		errGroup.Go(func() error {
+			for b := range batches {
+				respBody, retries, err := c.doRequestWithRetry("POST", "/graphql", b.queries)
+				c.addStats(PriceQueryStats{Sent: len(b.queries), Batches: 1, Retries: retries})
+				if err != nil {
+					return err
+				}
+
+				batchResults := gjson.ParseBytes(respBody).Array()
+				if len(batchResults) != len(b.queries) {
+					return &APIError{
+						err: fmt.Errorf("expected %d results, got %d", len(b.queries), len(batchResults)),
+						msg: "Invalid API response",
+					}
+				}
+
+				copy(results[b.offset:], batchResults)
+			}
+
+			return nil
+		})
+	}
+
+	errGroup.Go(func() error {
+		defer close(batches)
+
+		for offset := 0; offset < len(queries); offset += priceQueryBatchSize {
+			end := offset + priceQueryBatchSize
+			if end > len(queries) {
+				end = len(queries)
+			}
+
+			select {
+			case batches <- batch{offset: offset, queries: queries[offset:end]}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	err := errGroup.Wait()
+	if err != nil {
+		return []gjson.Result{}, err
+	}
+
+	return results, nil
+}
+
+func (c *PricingAPIClient) addStats(s PriceQueryStats) {
+	c.statsMux.Lock()
+	defer c.statsMux.Unlock()
+
+	c.stats.Total += s.Total
+	c.stats.Unique += s.Unique
+	c.stats.Cached += s.Cached
+	c.stats.Sent += s.Sent
+	c.stats.Batches += s.Batches
+	c.stats.Retries += s.Retries
+}
+
 func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *schema.PriceFilter) GraphQLQuery {
 	v := map[string]interface{}{}
 	v["productFilter"] = product
@@ -115,6 +375,7 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 	query := fmt.Sprintf(`
 		query($productFilter: ProductFilter!, $priceFilter: PriceFilter) {
 			products(filter: $productFilter) {
+				sku
 				prices(filter: $priceFilter) {
 					priceHash
 					%s
@@ -126,22 +387,29 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 	return GraphQLQuery{query, v}
 }
 
-// Batch all the queries for this resource so we can use one GraphQL call.
-// Use PriceQueryKeys to keep track of which query maps to which sub-resource and price component.
-func (c *PricingAPIClient) batchQueries(r *schema.Resource) ([]PriceQueryKey, []GraphQLQuery) {
+// Batch all the queries for the resources so we can de-duplicate them and
+// send them in as few GraphQL calls as possible. Use PriceQueryKeys to keep
+// track of which query maps to which sub-resource and price component.
+func (c *PricingAPIClient) batchQueries(resources []*schema.Resource) ([]PriceQueryKey, []GraphQLQuery) {
 	keys := make([]PriceQueryKey, 0)
 	queries := make([]GraphQLQuery, 0)
 
-	for _, component := range r.CostComponents {
-		keys = append(keys, PriceQueryKey{r, component})
-		queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
-	}
+	for _, r := range resources {
+		if r.IsSkipped {
+			continue
+		}
 
-	for _, subresource := range r.FlattenedSubResources() {
-		for _, component := range subresource.CostComponents {
-			keys = append(keys, PriceQueryKey{subresource, component})
+		for _, component := range r.CostComponents {
+			keys = append(keys, PriceQueryKey{r, component})
 			queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
 		}
+
+		for _, subresource := range r.FlattenedSubResources() {
+			for _, component := range subresource.CostComponents {
+				keys = append(keys, PriceQueryKey{subresource, component})
+				queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
+			}
+		}
 	}
 
 	return keys, queries
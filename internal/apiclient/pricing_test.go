@@ -0,0 +1,198 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func newTestPricingAPIClient(endpoint string) *PricingAPIClient {
+	return &PricingAPIClient{
+		APIClient: APIClient{endpoint: endpoint},
+		Currency:  "USD",
+		cache:     NewPriceCache("", time.Hour),
+		statsMux:  &sync.Mutex{},
+	}
+}
+
+func testResource(name string, instanceTypes ...string) *schema.Resource {
+	r := &schema.Resource{Name: name}
+	for _, instanceType := range instanceTypes {
+		r.CostComponents = append(r.CostComponents, &schema.CostComponent{
+			Name: fmt.Sprintf("Instance usage (%s)", instanceType),
+			ProductFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonEC2"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr(instanceType)},
+				},
+			},
+		})
+	}
+	return r
+}
+
+// pricingAPIStub responds to each query with the instance type as the price
+// hash, so the results can be matched back to the queries.
+type pricingAPIStub struct {
+	mux      sync.Mutex
+	requests int
+	queries  int
+	failures int
+}
+
+func (s *pricingAPIStub) handler(w http.ResponseWriter, r *http.Request) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.requests++
+
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": "Too many requests"}`))
+		return
+	}
+
+	var queries []struct {
+		Variables struct {
+			ProductFilter schema.ProductFilter `json:"productFilter"`
+		} `json:"variables"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&queries)
+	s.queries += len(queries)
+
+	results := make([]interface{}, 0, len(queries))
+	for _, q := range queries {
+		hash := *q.Variables.ProductFilter.AttributeFilters[0].Value
+		results = append(results, map[string]interface{}{
+			"data": map[string]interface{}{
+				"products": []interface{}{
+					map[string]interface{}{
+						"prices": []interface{}{
+							map[string]interface{}{"priceHash": hash, "USD": "0.1"},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	_ = json.NewEncoder(w).Encode(results)
+}
+
+func TestRunQueriesDeduplicatesAcrossResources(t *testing.T) {
+	stub := &pricingAPIStub{}
+	ts := httptest.NewServer(http.HandlerFunc(stub.handler))
+	defer ts.Close()
+
+	c := newTestPricingAPIClient(ts.URL)
+
+	resources := []*schema.Resource{
+		testResource("aws_instance.a", "m5.large", "t3.micro"),
+		testResource("aws_instance.b", "m5.large"),
+		testResource("aws_instance.c", "t3.micro", "m5.large"),
+		{Name: "aws_instance.skipped", IsSkipped: true, CostComponents: testResource("", "c5.large").CostComponents},
+	}
+
+	results, err := c.RunQueries(resources)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	for _, r := range results {
+		expected := *r.CostComponent.ProductFilter.AttributeFilters[0].Value
+		assert.Equal(t, expected, r.Result.Get("data.products.0.prices.0.priceHash").String())
+	}
+
+	assert.Equal(t, 1, stub.requests)
+	assert.Equal(t, 2, stub.queries)
+
+	stats := c.QueryStats()
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, 2, stats.Unique)
+	assert.Equal(t, 2, stats.Sent)
+	assert.Equal(t, 3, stats.Saved())
+
+	// The second run should be answered from the cache
+	_, err = c.RunQueries([]*schema.Resource{testResource("aws_instance.d", "t3.micro")})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stub.requests)
+	assert.Equal(t, 1, c.QueryStats().Cached)
+}
+
+func TestRunQueriesSplitsBatches(t *testing.T) {
+	stub := &pricingAPIStub{}
+	ts := httptest.NewServer(http.HandlerFunc(stub.handler))
+	defer ts.Close()
+
+	origBatchSize := priceQueryBatchSize
+	priceQueryBatchSize = 2
+	defer func() { priceQueryBatchSize = origBatchSize }()
+
+	c := newTestPricingAPIClient(ts.URL)
+
+	r := testResource("aws_instance.a", "a1.large", "a2.large", "a3.large", "a4.large", "a5.large")
+	results, err := c.RunQueries([]*schema.Resource{r})
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	for _, r := range results {
+		expected := *r.CostComponent.ProductFilter.AttributeFilters[0].Value
+		assert.Equal(t, expected, r.Result.Get("data.products.0.prices.0.priceHash").String())
+	}
+
+	assert.Equal(t, 3, stub.requests)
+	assert.Equal(t, 3, c.QueryStats().Batches)
+}
+
+func TestRunQueriesRetriesRateLimitedRequests(t *testing.T) {
+	stub := &pricingAPIStub{failures: 2}
+	ts := httptest.NewServer(http.HandlerFunc(stub.handler))
+	defer ts.Close()
+
+	origDelay := requestRetryBaseDelay
+	requestRetryBaseDelay = time.Millisecond
+	defer func() { requestRetryBaseDelay = origDelay }()
+
+	c := newTestPricingAPIClient(ts.URL)
+
+	results, err := c.RunQueries([]*schema.Resource{testResource("aws_instance.a", "m5.large")})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	assert.Equal(t, 3, stub.requests)
+	assert.Equal(t, 2, c.QueryStats().Retries)
+}
+
+func TestRunQueriesGivesUpAfterMaxRetries(t *testing.T) {
+	stub := &pricingAPIStub{failures: maxRequestRetries + 1}
+	ts := httptest.NewServer(http.HandlerFunc(stub.handler))
+	defer ts.Close()
+
+	origDelay := requestRetryBaseDelay
+	requestRetryBaseDelay = time.Millisecond
+	defer func() { requestRetryBaseDelay = origDelay }()
+
+	c := newTestPricingAPIClient(ts.URL)
+
+	_, err := c.RunQueries([]*schema.Resource{testResource("aws_instance.a", "m5.large")})
+	require.Error(t, err)
+
+	apiErr, ok := err.(*APIError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.statusCode)
+	assert.Equal(t, maxRequestRetries+1, stub.requests)
+}
@@ -0,0 +1,212 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"golang.org/x/mod/semver"
+)
+
+var snapshotVersion = "0.1"
+
+var (
+	minSnapshotVersion = "0.1"
+	maxSnapshotVersion = "0.1"
+)
+
+// ErrSnapshotMissingPrice is returned when a price snapshot is used as the
+// pricing source and it doesn't contain the result for a query.
+var ErrSnapshotMissingPrice = errors.New("Price snapshot does not contain all the required prices")
+
+// SnapshotEntry is a single price query stored in a PriceSnapshot along with
+// the result the Cloud Pricing API returned for it.
+type SnapshotEntry struct {
+	ProductFilter json.RawMessage `json:"productFilter"`
+	PriceFilter   json.RawMessage `json:"priceFilter"`
+	Result        json.RawMessage `json:"result"`
+}
+
+// PriceSnapshot holds the results of price queries so that runs can be
+// reproduced without access to the Cloud Pricing API. Entries are keyed by a
+// hash of the query variables so they can be looked up regardless of which
+// resource the query came from.
+type PriceSnapshot struct {
+	Version       string                    `json:"version"`
+	Currency      string                    `json:"currency"`
+	TimeGenerated time.Time                 `json:"timeGenerated"`
+	Prices        map[string]*SnapshotEntry `json:"prices"`
+
+	mux *sync.RWMutex
+}
+
+func NewPriceSnapshot(currency string) *PriceSnapshot {
+	return &PriceSnapshot{
+		Version:       snapshotVersion,
+		Currency:      currency,
+		TimeGenerated: time.Now(),
+		Prices:        make(map[string]*SnapshotEntry),
+		mux:           &sync.RWMutex{},
+	}
+}
+
+func LoadPriceSnapshot(path string) (*PriceSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading price snapshot file")
+	}
+
+	s := &PriceSnapshot{}
+	err = json.Unmarshal(data, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing price snapshot file")
+	}
+
+	if !checkSnapshotVersion(s.Version) {
+		return nil, fmt.Errorf("Invalid price snapshot file version. Supported versions are %s ≤ x ≤ %s", minSnapshotVersion, maxSnapshotVersion)
+	}
+
+	if s.Prices == nil {
+		s.Prices = make(map[string]*SnapshotEntry)
+	}
+	s.mux = &sync.RWMutex{}
+
+	return s, nil
+}
+
+// WriteToPath writes the snapshot as JSON. The prices are keyed by hash so
+// the file contents are stable for the same set of queries.
+func (s *PriceSnapshot) WriteToPath(path string) error {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Error generating price snapshot")
+	}
+
+	err = os.WriteFile(path, data, 0644) // nolint:gosec
+	if err != nil {
+		return errors.Wrap(err, "Error writing price snapshot file")
+	}
+
+	return nil
+}
+
+// Len returns the number of unique price queries in the snapshot.
+func (s *PriceSnapshot) Len() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return len(s.Prices)
+}
+
+// Add records the results of the given queries. The results must be in the
+// same order as the queries.
+func (s *PriceSnapshot) Add(queries []GraphQLQuery, results []gjson.Result) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for i, q := range queries {
+		if i >= len(results) {
+			break
+		}
+
+		key, entry, err := newSnapshotEntry(q, results[i])
+		if err != nil {
+			return err
+		}
+
+		s.Prices[key] = entry
+	}
+
+	return nil
+}
+
+// Results returns the recorded results for the given queries in the same
+// order as the queries. If any of the queries are missing from the snapshot
+// an error wrapping ErrSnapshotMissingPrice is returned.
+func (s *PriceSnapshot) Results(queries []GraphQLQuery) ([]gjson.Result, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	results := make([]gjson.Result, 0, len(queries))
+	missing := make([]string, 0)
+
+	for _, q := range queries {
+		key, err := snapshotKey(q)
+		if err != nil {
+			return results, err
+		}
+
+		entry, ok := s.Prices[key]
+		if !ok {
+			b, _ := json.Marshal(q.Variables["productFilter"])
+			missing = append(missing, string(b))
+			continue
+		}
+
+		results = append(results, gjson.ParseBytes(entry.Result))
+	}
+
+	if len(missing) > 0 {
+		return results, fmt.Errorf("%w, re-export the snapshot to include the product filters:\n  %s", ErrSnapshotMissingPrice, strings.Join(missing, "\n  "))
+	}
+
+	return results, nil
+}
+
+func newSnapshotEntry(q GraphQLQuery, result gjson.Result) (string, *SnapshotEntry, error) {
+	key, err := snapshotKey(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	productFilter, err := json.Marshal(q.Variables["productFilter"])
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Error generating price snapshot entry")
+	}
+
+	priceFilter, err := json.Marshal(q.Variables["priceFilter"])
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Error generating price snapshot entry")
+	}
+
+	raw := result.Raw
+	if raw == "" {
+		raw = "null"
+	}
+
+	return key, &SnapshotEntry{
+		ProductFilter: productFilter,
+		PriceFilter:   priceFilter,
+		Result:        json.RawMessage(raw),
+	}, nil
+}
+
+// snapshotKey returns a hash of the query variables. encoding/json sorts map
+// keys and writes struct fields in order so the same filters always produce
+// the same key.
+func snapshotKey(q GraphQLQuery) (string, error) {
+	b, err := json.Marshal(q.Variables)
+	if err != nil {
+		return "", errors.Wrap(err, "Error generating price snapshot key")
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func checkSnapshotVersion(v string) bool {
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return semver.Compare(v, "v"+minSnapshotVersion) >= 0 && semver.Compare(v, "v"+maxSnapshotVersion) <= 0
+}
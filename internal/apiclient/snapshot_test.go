@@ -0,0 +1,71 @@
+package apiclient
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func snapshotTestQuery(instanceType string) GraphQLQuery {
+	return GraphQLQuery{
+		Query: "query($productFilter: ProductFilter!, $priceFilter: PriceFilter) {}",
+		Variables: map[string]interface{}{
+			"productFilter": map[string]interface{}{
+				"vendorName": "aws",
+				"service":    "AmazonEC2",
+				"attributeFilters": []map[string]interface{}{
+					{"key": "instanceType", "value": instanceType},
+				},
+			},
+			"priceFilter": map[string]interface{}{
+				"purchaseOption": "on_demand",
+			},
+		},
+	}
+}
+
+func TestPriceSnapshotRoundTrip(t *testing.T) {
+	queries := []GraphQLQuery{snapshotTestQuery("m5.large"), snapshotTestQuery("t3.micro")}
+	results := []gjson.Result{
+		gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.096"}]}]}}`),
+		gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"b","USD":"0.0104"}]}]}}`),
+	}
+
+	s := NewPriceSnapshot("USD")
+	require.NoError(t, s.Add(queries, results))
+	assert.Equal(t, 2, s.Len())
+
+	path := filepath.Join(t.TempDir(), "prices.json")
+	require.NoError(t, s.WriteToPath(path))
+
+	loaded, err := LoadPriceSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", loaded.Currency)
+	assert.Equal(t, 2, loaded.Len())
+
+	// Look the queries up in the reverse order to check results are matched
+	// by their variables and not by position.
+	got, err := loaded.Results([]GraphQLQuery{queries[1], queries[0]})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "0.0104", got[0].Get("data.products.0.prices.0.USD").String())
+	assert.Equal(t, "0.096", got[1].Get("data.products.0.prices.0.USD").String())
+}
+
+func TestPriceSnapshotMissingPrice(t *testing.T) {
+	s := NewPriceSnapshot("USD")
+	err := s.Add(
+		[]GraphQLQuery{snapshotTestQuery("m5.large")},
+		[]gjson.Result{gjson.Parse(`{"data":{"products":[]}}`)},
+	)
+	require.NoError(t, err)
+
+	_, err = s.Results([]GraphQLQuery{snapshotTestQuery("m5.large"), snapshotTestQuery("m5.xlarge")})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSnapshotMissingPrice))
+	assert.Contains(t, err.Error(), "m5.xlarge")
+}
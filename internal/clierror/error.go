@@ -20,3 +20,30 @@ func NewSanitizedError(err error, sanitizedMsg string) *SanitizedError {
 		err:          err,
 	}
 }
+
+// ExitError allows errors to set the exit code of the CLI. It is used for
+// expected failures, such as policy violations, which shouldn't be reported
+// upstream as CLI errors.
+type ExitError struct {
+	code int
+	err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.err
+}
+
+func (e *ExitError) ExitCode() int {
+	return e.code
+}
+
+func NewExitError(err error, code int) *ExitError {
+	return &ExitError{
+		code: code,
+		err:  err,
+	}
+}
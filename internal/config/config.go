@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -37,8 +38,20 @@ type Project struct {
 	// UsageFile is the full path to usage file that specifies values for usage-based resources
 	UsageFile string `yaml:"usage_file,omitempty" ignored:"true"`
 	// TerraformUseState sets if the users wants to use the terraform state for infracost ops.
-	TerraformUseState bool              `yaml:"terraform_use_state,omitempty" ignored:"true"`
-	Env               map[string]string `yaml:"env,omitempty" ignored:"true"`
+	TerraformUseState bool `yaml:"terraform_use_state,omitempty" ignored:"true"`
+	// TerraformParseHCL sets if Terraform directories should be parsed directly from the HCL files
+	// instead of running Terraform, so no Terraform binary or credentials are needed.
+	TerraformParseHCL bool `yaml:"terraform_parse_hcl,omitempty" envconfig:"INFRACOST_TERRAFORM_PARSE_HCL"`
+	// ParameterOverrides are the CloudFormation parameter values, e.g. "Env=prod Mode=PAY_PER_REQUEST".
+	// These take precedence over the values in the ParametersFile.
+	ParameterOverrides string `yaml:"parameter_overrides,omitempty" ignored:"true"`
+	// ParametersFile is the path to a JSON file with the CloudFormation parameter values.
+	ParametersFile string `yaml:"parameters_file,omitempty" ignored:"true"`
+	// PreviousTemplatePath is the path to the deployed CloudFormation template, or a change set
+	// JSON description, that the diff command compares the template against. For CDK apps it's
+	// the cloud assembly directory of the deployed app.
+	PreviousTemplatePath string            `yaml:"previous_template_path,omitempty" ignored:"true"`
+	Env                  map[string]string `yaml:"env,omitempty" ignored:"true"`
 }
 
 type Config struct {
@@ -62,6 +75,21 @@ type Config struct {
 
 	Currency string `envconfig:"INFRACOST_CURRENCY"`
 
+	// StrictPricing fails the run if the price lookup for any cost component
+	// didn't return exactly one price, e.g. if it was priced at 0.00.
+	StrictPricing bool `envconfig:"INFRACOST_STRICT_PRICING"`
+
+	// AWSEndpoint, GoogleMonitoringEndpoint and AzureEndpoint are the endpoints
+	// used for the cloud provider API calls when estimating usage, e.g. a local
+	// stub. If they're empty the cloud provider endpoints are used.
+	AWSEndpoint              string `envconfig:"INFRACOST_AWS_ENDPOINT"`
+	GoogleMonitoringEndpoint string `envconfig:"INFRACOST_GOOGLE_MONITORING_ENDPOINT"`
+	AzureEndpoint            string `envconfig:"INFRACOST_AZURE_ENDPOINT"`
+
+	// PricingCacheTTL is how long price query results are cached for on disk.
+	PricingCacheTTL      time.Duration `envconfig:"INFRACOST_PRICING_CACHE_TTL"`
+	PricingCacheDisabled bool          `envconfig:"INFRACOST_PRICING_CACHE_DISABLED"`
+
 	Projects      []*Project `yaml:"projects" ignored:"true"`
 	Format        string     `yaml:"format,omitempty" ignored:"true"`
 	ShowSkipped   bool       `yaml:"show_skipped,omitempty" ignored:"true"`
@@ -70,6 +98,42 @@ type Config struct {
 
 	NoCache bool `yaml:"fields,omitempty" ignored:"true"`
 
+	// Remediate sets if the changes needed in the cloud to estimate usage, e.g.
+	// enabling S3 bucket metrics, should be made when syncing the usage file.
+	// RemediateDryRun shows the API calls that would be made instead.
+	Remediate       bool `yaml:"remediate,omitempty" ignored:"true"`
+	RemediateDryRun bool `yaml:"remediate_dry_run,omitempty" ignored:"true"`
+
+	// EstimateUsage sets if the usage of supported resources should be
+	// estimated from the cloud provider, e.g. AWS CloudWatch metrics.
+	EstimateUsage bool `yaml:"estimate_usage,omitempty" ignored:"true"`
+
+	// IncludeCloudResourceIDs sets if the IDs and ARNs of deployed resources
+	// should be included in the JSON output so it can be reconciled with a
+	// billing export. They're never sent to the dashboard.
+	IncludeCloudResourceIDs bool `yaml:"include_cloud_resource_ids,omitempty" ignored:"true"`
+
+	// PricingSnapshotPath is the path to a price snapshot file. If this is set
+	// prices are read from the snapshot instead of the Cloud Pricing API.
+	PricingSnapshotPath string `yaml:"pricing_snapshot,omitempty" ignored:"true"`
+
+	// CompareToPath is the path to a Terraform state JSON file or Infracost
+	// JSON file that the diff command compares the projects against.
+	CompareToPath string `ignored:"true"`
+
+	// PolicyFile is the path to a cost policy file set in the config file. If
+	// this is set the policies are evaluated against the output of the run.
+	PolicyFile string `yaml:"policy_file,omitempty" ignored:"true"`
+
+	// SavingsPlans are the AWS Savings Plans set in the config file. If these
+	// are set they are applied to the eligible costs of all the projects.
+	SavingsPlans []*SavingsPlan `yaml:"savings_plans,omitempty" ignored:"true"`
+
+	// PriceOverridesFile is the path to a price overrides file set in the
+	// config file or with the --price-overrides-file flag. If this is set the
+	// negotiated discounts and custom prices are applied to the prices.
+	PriceOverridesFile string `yaml:"price_overrides_file,omitempty" ignored:"true"`
+
 	// for testing
 	EventsDisabled       bool
 	LogWriter            io.Writer
@@ -93,6 +157,9 @@ func DefaultConfig() *Config {
 		DashboardAPIEndpoint:      "https://dashboard.api.infracost.io",
 		EnableDashboard:           false,
 
+		PricingCacheTTL:      24 * time.Hour,
+		PricingCacheDisabled: IsTest(),
+
 		Projects: []*Project{{}},
 
 		Format: "table",
@@ -109,6 +176,9 @@ func (c *Config) LoadFromConfigFile(path string) error {
 	}
 
 	c.Projects = cfgFile.Projects
+	c.PolicyFile = cfgFile.PolicyFile
+	c.SavingsPlans = cfgFile.SavingsPlans
+	c.PriceOverridesFile = cfgFile.PriceOverridesFile
 
 	// Reload the environment to overwrite any of the config file configs
 	err = c.LoadFromEnv()
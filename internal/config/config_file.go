@@ -111,6 +111,15 @@ func (y *YamlError) Error() string {
 type fileSpec struct {
 	Version  string     `yaml:"version"`
 	Projects []*Project `yaml:"projects" ignored:"true"`
+	// PolicyFile is an optional path to a cost policy file that is evaluated
+	// against the output of the run.
+	PolicyFile string `yaml:"policy_file,omitempty" ignored:"true"`
+	// SavingsPlans are the AWS Savings Plans that are applied to the eligible
+	// costs of all the projects.
+	SavingsPlans []*SavingsPlan `yaml:"savings_plans,omitempty" ignored:"true"`
+	// PriceOverridesFile is an optional path to a price overrides file with
+	// negotiated discounts and custom prices.
+	PriceOverridesFile string `yaml:"price_overrides_file,omitempty" ignored:"true"`
 }
 
 // UnmarshalYAML implements the yaml.v2.Unmarshaller interface. Marshalls the
@@ -119,8 +128,12 @@ type fileSpec struct {
 // type so that we don't run into error collisions with the base yaml.v2 errors.
 func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type roughFile struct {
-		Version  string                   `yaml:"version"`
-		Projects []map[string]interface{} `yaml:"projects"`
+		Version    string                   `yaml:"version"`
+		Projects   []map[string]interface{} `yaml:"projects"`
+		PolicyFile string                   `yaml:"policy_file"`
+
+		SavingsPlans       []*SavingsPlan `yaml:"savings_plans"`
+		PriceOverridesFile string         `yaml:"price_overrides_file"`
 	}
 
 	var r roughFile
@@ -180,6 +193,19 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 	}
 
+	for i, plan := range r.SavingsPlans {
+		if plan == nil {
+			continue
+		}
+
+		if errs := plan.Validate(); len(errs) > 0 {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("savings plan config at index %d is invalid", i),
+				errors: errs,
+			})
+		}
+	}
+
 	if validationError.isValid() {
 		return validationError
 	}
@@ -203,6 +229,9 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	f.Version = c.Version
 	f.Projects = c.Projects
+	f.PolicyFile = c.PolicyFile
+	f.SavingsPlans = c.SavingsPlans
+	f.PriceOverridesFile = c.PriceOverridesFile
 	return nil
 }
 
@@ -86,6 +86,35 @@ projects:
 				},
 			},
 		},
+		{
+			name: "should error invalid savings plan given",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+
+savings_plans:
+  - type: ec2_instance
+    hourly_commitment: 10
+    term: 2_year
+    payment_option: no_upfront
+    discount_percents:
+      rds: 10
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base: "savings plan config at index 0 is invalid",
+						errors: []error{
+							errors.New("term must be one of 1_year, 3_year"),
+							errors.New("region and instance_family are required for ec2_instance Savings Plans"),
+							errors.New("discount_percents key rds must be one of ec2, fargate, lambda"),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "should error invalid version given",
 			contents: []byte(`version: 81923.1
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	SavingsPlanTypeCompute     = "compute"
+	SavingsPlanTypeEC2Instance = "ec2_instance"
+)
+
+var (
+	validSavingsPlanTypes          = []string{SavingsPlanTypeCompute, SavingsPlanTypeEC2Instance}
+	validSavingsPlanTerms          = []string{"1_year", "3_year"}
+	validSavingsPlanPaymentOptions = []string{"no_upfront", "partial_upfront", "all_upfront"}
+	validSavingsPlanServices       = []string{"ec2", "fargate", "lambda"}
+)
+
+// SavingsPlan is an AWS Savings Plan set in the config file. Savings Plans
+// are applied to the eligible costs of all the projects in a run.
+type SavingsPlan struct {
+	// Name is shown in the outputs. It defaults to a name built from the type and term.
+	Name string `yaml:"name,omitempty"`
+	// Type is either compute, which covers EC2, Fargate and Lambda, or
+	// ec2_instance, which only covers EC2 instances of one family in one region.
+	Type string `yaml:"type"`
+	// HourlyCommitment is the amount spent each hour at the Savings Plan rates.
+	HourlyCommitment float64 `yaml:"hourly_commitment"`
+	// Term is either 1_year or 3_year.
+	Term string `yaml:"term"`
+	// PaymentOption is either no_upfront, partial_upfront or all_upfront.
+	PaymentOption string `yaml:"payment_option"`
+	// Region and InstanceFamily are required for EC2 Instance Savings Plans.
+	Region         string `yaml:"region,omitempty"`
+	InstanceFamily string `yaml:"instance_family,omitempty"`
+	// DiscountPercents override the default discounts from the on-demand
+	// prices, keyed by the service: ec2, fargate or lambda.
+	DiscountPercents map[string]float64 `yaml:"discount_percents,omitempty"`
+}
+
+// DisplayName returns the name of the Savings Plan shown in the outputs.
+func (s *SavingsPlan) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	typeLabel := "Compute"
+	if s.Type == SavingsPlanTypeEC2Instance {
+		typeLabel = fmt.Sprintf("EC2 Instance (%s, %s)", s.InstanceFamily, s.Region)
+	}
+
+	return fmt.Sprintf("%s Savings Plan, %s %s", typeLabel, strings.Replace(s.Term, "_", " ", 1), strings.Replace(s.PaymentOption, "_", " ", 1))
+}
+
+// Validate returns the problems with the Savings Plan's fields.
+func (s *SavingsPlan) Validate() []error {
+	var errs []error
+
+	if !contains(validSavingsPlanTypes, s.Type) {
+		errs = append(errs, fmt.Errorf("type must be one of %s", strings.Join(validSavingsPlanTypes, ", ")))
+	}
+
+	if s.HourlyCommitment <= 0 {
+		errs = append(errs, fmt.Errorf("hourly_commitment must be greater than 0"))
+	}
+
+	if !contains(validSavingsPlanTerms, s.Term) {
+		errs = append(errs, fmt.Errorf("term must be one of %s", strings.Join(validSavingsPlanTerms, ", ")))
+	}
+
+	if !contains(validSavingsPlanPaymentOptions, s.PaymentOption) {
+		errs = append(errs, fmt.Errorf("payment_option must be one of %s", strings.Join(validSavingsPlanPaymentOptions, ", ")))
+	}
+
+	if s.Type == SavingsPlanTypeEC2Instance && (s.Region == "" || s.InstanceFamily == "") {
+		errs = append(errs, fmt.Errorf("region and instance_family are required for ec2_instance Savings Plans"))
+	}
+
+	services := make([]string, 0, len(s.DiscountPercents))
+	for service := range s.DiscountPercents {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		if !contains(validSavingsPlanServices, service) {
+			errs = append(errs, fmt.Errorf("discount_percents key %s must be one of %s", service, strings.Join(validSavingsPlanServices, ", ")))
+			continue
+		}
+
+		if d := s.DiscountPercents[service]; d < 0 || d >= 100 {
+			errs = append(errs, fmt.Errorf("discount_percents value for %s must be between 0 and 100", service))
+		}
+	}
+
+	return errs
+}
+
+func contains(arr []string, e string) bool {
+	for _, a := range arr {
+		if a == e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,302 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Quantity sources for a cost component. Usage-based quantities use the
+// sources from schema.UsageSource.
+const (
+	quantitySourceAttributes = "resource attributes"
+	quantitySourceNone       = "no usage specified"
+)
+
+// Options for which resources to explain and how to format the costs.
+type Options struct {
+	// Addresses limits the explanation to these resources and their children,
+	// e.g. "module.app" includes all the resources in that module.
+	Addresses []string
+	Currency  string
+}
+
+// Component is the explanation of how a cost component was priced and how
+// its costs were calculated.
+type Component struct {
+	Address         string
+	Name            string
+	ProductFilter   *schema.ProductFilter
+	PriceFilter     *schema.PriceFilter
+	PriceHash       string
+	PriceWarnings   []schema.PriceWarning
+	Price           decimal.Decimal
+	Unit            string
+	UnitMultiplier  decimal.Decimal
+	HourlyQuantity  *decimal.Decimal
+	MonthlyQuantity *decimal.Decimal
+	QuantitySource  string
+	UsageKeys       []string
+	DiscountPerc    float64
+	HourlyCost      *decimal.Decimal
+	MonthlyCost     *decimal.Decimal
+}
+
+// Resource is the explanation of all the cost components of a resource.
+type Resource struct {
+	Address      string
+	UsageSources map[string]schema.UsageSource
+	Components   []Component
+}
+
+// Explain builds the explanations for the priced resources in the projects.
+// Resources that are skipped or don't match the addresses are ignored. An
+// error is returned if addresses are given and none of them match.
+func Explain(projects []*schema.Project, opts Options) ([]Resource, error) {
+	explained := make([]Resource, 0)
+
+	for _, p := range projects {
+		for _, r := range p.Resources {
+			if r.IsSkipped || !matchesAddresses(r.Name, opts.Addresses) {
+				continue
+			}
+
+			explained = append(explained, explainResource(r))
+		}
+	}
+
+	if len(explained) == 0 && len(opts.Addresses) > 0 {
+		return explained, fmt.Errorf("No supported resources found matching %s", strings.Join(opts.Addresses, ", "))
+	}
+
+	return explained, nil
+}
+
+func explainResource(r *schema.Resource) Resource {
+	usageSources := r.UsageSources()
+	usageKeys := r.CostComponentUsageKeys()
+
+	res := Resource{
+		Address:      r.Name,
+		UsageSources: usageSources,
+		Components:   make([]Component, 0, len(r.CostComponents)),
+	}
+
+	addComponents := func(address string, components []*schema.CostComponent) {
+		for _, c := range components {
+			res.Components = append(res.Components, Component{
+				Address:         address,
+				Name:            c.Name,
+				ProductFilter:   c.ProductFilter,
+				PriceFilter:     c.PriceFilter,
+				PriceHash:       c.PriceHash(),
+				PriceWarnings:   c.PriceWarnings(),
+				Price:           c.Price(),
+				Unit:            c.Unit,
+				UnitMultiplier:  c.UnitMultiplier,
+				HourlyQuantity:  c.HourlyQuantity,
+				MonthlyQuantity: c.MonthlyQuantity,
+				QuantitySource:  quantitySource(c, usageKeys[c], usageSources),
+				UsageKeys:       usageKeys[c],
+				DiscountPerc:    c.MonthlyDiscountPerc,
+				HourlyCost:      c.HourlyCost,
+				MonthlyCost:     c.MonthlyCost,
+			})
+		}
+	}
+
+	addComponents(r.Name, r.CostComponents)
+	for _, s := range r.FlattenedSubResources() {
+		addComponents(s.Name, s.CostComponents)
+	}
+
+	return res
+}
+
+// quantitySource returns where the quantities of the cost component came
+// from, which is the sources of the usage keys it uses, e.g.
+// "usage file (monthly_requests), default (request_duration_ms)". If all the
+// keys have the same source only the source is returned.
+func quantitySource(c *schema.CostComponent, usageKeys []string, usageSources map[string]schema.UsageSource) string {
+	if c.HourlyQuantity == nil && c.MonthlyQuantity == nil {
+		return quantitySourceNone
+	}
+
+	if len(usageKeys) == 0 {
+		return quantitySourceAttributes
+	}
+
+	keysBySource := make(map[schema.UsageSource][]string)
+	for _, k := range usageKeys {
+		source, ok := usageSources[k]
+		if !ok {
+			source = schema.UsageSourceDefault
+		}
+		keysBySource[source] = append(keysBySource[source], k)
+	}
+
+	order := []schema.UsageSource{schema.UsageSourceUsageFile, schema.UsageSourceEstimate, schema.UsageSourceDefault}
+
+	for _, source := range order {
+		if len(keysBySource[source]) == len(usageKeys) {
+			return string(source)
+		}
+	}
+
+	parts := make([]string, 0, len(keysBySource))
+	for _, source := range order {
+		if keys := keysBySource[source]; len(keys) > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%s)", source, strings.Join(keys, ", ")))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func matchesAddresses(name string, addresses []string) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+
+	for _, addr := range addresses {
+		if name == addr || strings.HasPrefix(name, addr+".") || strings.HasPrefix(name, addr+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToText formats the explanations for the terminal, showing the price query
+// and the arithmetic for the hourly and monthly costs of each cost component.
+func ToText(resources []Resource, opts Options) string {
+	var b strings.Builder
+
+	for i, r := range resources {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "%s\n", ui.BoldString(r.Address))
+
+		if len(r.UsageSources) > 0 {
+			keys := make([]string, 0, len(r.UsageSources))
+			for k := range r.UsageSources {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			b.WriteString("  Usage:\n")
+			for _, k := range keys {
+				fmt.Fprintf(&b, "    %s: %s\n", k, r.UsageSources[k])
+			}
+		}
+
+		if len(r.Components) == 0 {
+			fmt.Fprintf(&b, "  %s\n", ui.FaintString("No cost components"))
+			continue
+		}
+
+		for _, c := range r.Components {
+			b.WriteString("\n")
+			writeComponent(&b, r.Address, c, opts.Currency)
+		}
+	}
+
+	return b.String()
+}
+
+func writeComponent(b *strings.Builder, resourceAddress string, c Component, currency string) {
+	name := c.Name
+	if c.Address != resourceAddress {
+		name = fmt.Sprintf("%s › %s", strings.TrimPrefix(c.Address, resourceAddress+"."), c.Name)
+	}
+	fmt.Fprintf(b, "  %s\n", ui.PrimaryString(name))
+
+	writeField(b, "Product filter", filterJSON(c.ProductFilter))
+	writeField(b, "Price filter", filterJSON(c.PriceFilter))
+
+	if c.PriceHash == "" {
+		writeField(b, "Price hash", "-")
+	} else {
+		writeField(b, "Price hash", c.PriceHash)
+	}
+
+	for _, w := range c.PriceWarnings {
+		writeField(b, "Price warning", ui.WarningString(w.Message))
+	}
+
+	writeField(b, "Price", fmt.Sprintf("%s %s", c.Price.String(), currency))
+	writeField(b, "Unit", fmt.Sprintf("%s (unit multiplier %s)", c.Unit, c.UnitMultiplier.String()))
+	if !c.UnitMultiplier.Equal(decimal.NewFromInt(1)) {
+		writeField(b, "Price per unit", fmt.Sprintf("%s × %s = %s %s per %s",
+			c.Price.String(),
+			c.UnitMultiplier.String(),
+			c.Price.Mul(c.UnitMultiplier).String(),
+			currency,
+			c.Unit,
+		))
+	}
+
+	writeField(b, "Hourly quantity", formatQuantity(c.HourlyQuantity))
+	writeField(b, "Monthly quantity", formatQuantity(c.MonthlyQuantity))
+	writeField(b, "Quantity source", c.QuantitySource)
+	if len(c.UsageKeys) > 0 {
+		writeField(b, "Usage keys", strings.Join(c.UsageKeys, ", "))
+	}
+
+	if c.HourlyCost != nil && c.HourlyQuantity != nil {
+		writeField(b, "Hourly cost", fmt.Sprintf("%s × %s = %s",
+			c.Price.String(),
+			c.HourlyQuantity.String(),
+			formatCost(currency, c.HourlyCost),
+		))
+	} else {
+		writeField(b, "Hourly cost", "-")
+	}
+
+	if c.MonthlyCost != nil && c.MonthlyQuantity != nil {
+		calc := fmt.Sprintf("%s × %s", c.Price.String(), c.MonthlyQuantity.String())
+		if c.DiscountPerc != 0 {
+			calc += fmt.Sprintf(" × (1 - %s discount)", decimal.NewFromFloat(c.DiscountPerc).String())
+		}
+
+		writeField(b, "Monthly cost", fmt.Sprintf("%s = %s", calc, formatCost(currency, c.MonthlyCost)))
+	} else {
+		writeField(b, "Monthly cost", "-")
+	}
+}
+
+func writeField(b *strings.Builder, label string, value string) {
+	fmt.Fprintf(b, "    %-18s%s\n", label+":", value)
+}
+
+func formatQuantity(q *decimal.Decimal) string {
+	if q == nil {
+		return "-"
+	}
+
+	return q.String()
+}
+
+// formatCost doesn't round the cost so the result of the arithmetic can be
+// checked exactly.
+func formatCost(currency string, d *decimal.Decimal) string {
+	return fmt.Sprintf("%s %s", d.String(), currency)
+}
+
+// filterJSON returns the filter as it's sent in the price query variables.
+func filterJSON(filter interface{}) string {
+	b, err := json.Marshal(filter)
+	if err != nil || string(b) == "null" {
+		return "-"
+	}
+
+	return string(b)
+}
@@ -0,0 +1,199 @@
+package explain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+// testLambda builds a Lambda function whose duration uses a default that
+// isn't in the usage schema, like the real resource.
+func testLambda(u *schema.UsageData) *schema.Resource {
+	var requests, duration *decimal.Decimal
+	if u.Get("monthly_requests").Exists() {
+		requests = decimalPtr(decimal.NewFromInt(u.Get("monthly_requests").Int()))
+
+		ms := decimal.NewFromInt(1)
+		if u.Get("request_duration_ms").Exists() {
+			ms = decimal.NewFromInt(u.Get("request_duration_ms").Int())
+		}
+		duration = decimalPtr(requests.Mul(ms).Div(decimal.NewFromInt(1000)))
+	}
+
+	return &schema.Resource{
+		Name: "aws_lambda_function.hello",
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Requests",
+				Unit:            "1M requests",
+				UnitMultiplier:  decimal.NewFromInt(1000000),
+				MonthlyQuantity: requests,
+				ProductFilter: &schema.ProductFilter{
+					VendorName: strPtr("aws"),
+					Service:    strPtr("AWSLambda"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "group", Value: strPtr("AWS-Lambda-Requests")},
+					},
+				},
+			},
+			{
+				Name:            "Duration",
+				Unit:            "GB-seconds",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: duration,
+			},
+		},
+		UsageSchema: []*schema.UsageItem{
+			{Key: "monthly_requests", DefaultValue: 0, ValueType: schema.Int64},
+			{Key: "request_duration_ms", DefaultValue: 0, ValueType: schema.Int64},
+		},
+	}
+}
+
+func testProject() *schema.Project {
+	usage := schema.NewUsageData("aws_lambda_function.hello", map[string]gjson.Result{
+		"monthly_requests": gjson.Parse("2000000"),
+	})
+
+	lambda := testLambda(usage)
+	lambda.SetRebuildFunc(usage, testLambda)
+	lambda.EstimationSummary = map[string]bool{"monthly_requests": true}
+
+	requests := lambda.CostComponents[0]
+	requests.SetPrice(decimal.RequireFromString("0.0000002"))
+	requests.SetPriceHash("lambda-requests-hash")
+
+	instance := &schema.CostComponent{
+		Name:           "Instance usage",
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Service:    strPtr("AmazonEC2"),
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		},
+	}
+	instance.SetPrice(decimal.RequireFromString("0.1"))
+	instance.SetPriceHash("ec2-hash")
+
+	storage := &schema.CostComponent{
+		Name:           "Storage",
+		Unit:           "GB",
+		UnitMultiplier: decimal.NewFromInt(1),
+	}
+	storage.AddPriceWarning(schema.PriceWarningNoProducts, "No products found, using 0.00")
+
+	p := schema.NewProject("test", &schema.ProjectMetadata{})
+	p.Resources = []*schema.Resource{
+		lambda,
+		{
+			Name:           "module.app.aws_instance.web",
+			CostComponents: []*schema.CostComponent{instance},
+			SubResources: []*schema.Resource{
+				{Name: "root_block_device", CostComponents: []*schema.CostComponent{storage}},
+			},
+		},
+		{
+			Name:      "aws_unsupported.thing",
+			IsSkipped: true,
+		},
+	}
+
+	schema.CalculateCosts(p)
+
+	return p
+}
+
+func TestExplain(t *testing.T) {
+	resources, err := Explain([]*schema.Project{testProject()}, Options{})
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	lambda := resources[0]
+	assert.Equal(t, map[string]schema.UsageSource{
+		"monthly_requests":    schema.UsageSourceUsageFile,
+		"request_duration_ms": schema.UsageSourceDefault,
+	}, lambda.UsageSources)
+	require.Len(t, lambda.Components, 2)
+	assert.Equal(t, "lambda-requests-hash", lambda.Components[0].PriceHash)
+	assert.Equal(t, "usage file", lambda.Components[0].QuantitySource)
+	assert.Equal(t, []string{"monthly_requests"}, lambda.Components[0].UsageKeys)
+	assert.Equal(t, "0.4", lambda.Components[0].MonthlyCost.String())
+	assert.Equal(t, "usage file (monthly_requests), default (request_duration_ms)", lambda.Components[1].QuantitySource)
+	assert.Equal(t, []string{"monthly_requests", "request_duration_ms"}, lambda.Components[1].UsageKeys)
+
+	instance := resources[1]
+	require.Len(t, instance.Components, 2)
+	assert.Equal(t, "resource attributes", instance.Components[0].QuantitySource)
+	assert.Equal(t, "root_block_device", instance.Components[1].Address)
+	assert.Equal(t, "no usage specified", instance.Components[1].QuantitySource)
+	assert.Equal(t, []schema.PriceWarning{{Type: schema.PriceWarningNoProducts, Message: "No products found, using 0.00"}}, instance.Components[1].PriceWarnings)
+}
+
+func TestExplainEstimatedUsage(t *testing.T) {
+	p := testProject()
+	p.Resources[0].EstimationSummary = map[string]bool{"monthly_requests": true, "request_duration_ms": true}
+	p.Resources[0].EstimatedUsageKeys = map[string]bool{"request_duration_ms": true}
+
+	resources, err := Explain([]*schema.Project{p}, Options{})
+	require.NoError(t, err)
+
+	lambda := resources[0]
+	assert.Equal(t, map[string]schema.UsageSource{
+		"monthly_requests":    schema.UsageSourceUsageFile,
+		"request_duration_ms": schema.UsageSourceEstimate,
+	}, lambda.UsageSources)
+	assert.Equal(t, "usage file", lambda.Components[0].QuantitySource)
+	assert.Equal(t, "usage file (monthly_requests), estimate (request_duration_ms)", lambda.Components[1].QuantitySource)
+
+	p.Resources[0].EstimatedUsageKeys = map[string]bool{"monthly_requests": true, "request_duration_ms": true}
+
+	resources, err = Explain([]*schema.Project{p}, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "estimate", resources[0].Components[0].QuantitySource)
+	assert.Equal(t, "estimate", resources[0].Components[1].QuantitySource)
+}
+
+func TestExplainAddresses(t *testing.T) {
+	resources, err := Explain([]*schema.Project{testProject()}, Options{Addresses: []string{"module.app"}})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "module.app.aws_instance.web", resources[0].Address)
+
+	_, err = Explain([]*schema.Project{testProject()}, Options{Addresses: []string{"aws_instance.missing", "aws_unsupported.thing"}})
+	assert.EqualError(t, err, "No supported resources found matching aws_instance.missing, aws_unsupported.thing")
+}
+
+func TestToText(t *testing.T) {
+	opts := Options{Currency: "USD"}
+	resources, err := Explain([]*schema.Project{testProject()}, opts)
+	require.NoError(t, err)
+
+	text := ToText(resources, opts)
+
+	assert.Contains(t, text, `Product filter:   {"vendorName":"aws","service":"AWSLambda","attributeFilters":[{"key":"group","value":"AWS-Lambda-Requests"}]}`)
+	assert.Contains(t, text, "Price filter:     -")
+	assert.Contains(t, text, "Price per unit:   0.0000002 × 1000000 = 0.2 USD per 1M requests")
+	assert.Contains(t, text, "Monthly cost:     0.0000002 × 2000000 = 0.4 USD")
+	assert.Contains(t, text, `Price filter:     {"purchaseOption":"on_demand"}`)
+	assert.Contains(t, text, "Hourly cost:      0.1 × 1 = 0.1 USD")
+	assert.Contains(t, text, "Monthly cost:     0.1 × 730 = 73 USD")
+	assert.Contains(t, text, "Price hash:       -")
+	assert.Contains(t, text, "No products found, using 0.00")
+}
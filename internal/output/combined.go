@@ -27,9 +27,13 @@ func Combine(currency string, inputs []ReportInput, opts Options) Root {
 	var pastTotalMonthlyCost *decimal.Decimal
 	var diffTotalHourlyCost *decimal.Decimal
 	var diffTotalMonthlyCost *decimal.Decimal
+	var totalUpfrontCost *decimal.Decimal
+	hasListMonthlyCost := false
 
 	projects := make([]Project, 0)
 	summaries := make([]*Summary, 0, len(inputs))
+	warnings := make([]Warning, 0)
+	savingsPlans := make([]*SavingsPlans, 0)
 
 	for _, input := range inputs {
 
@@ -37,6 +41,16 @@ func Combine(currency string, inputs []ReportInput, opts Options) Root {
 
 		summaries = append(summaries, input.Root.Summary)
 
+		warnings = append(warnings, input.Root.Warnings...)
+
+		savingsPlans = append(savingsPlans, input.Root.SavingsPlans)
+
+		totalUpfrontCost = addDecimalPtrs(totalUpfrontCost, input.Root.TotalUpfrontCost)
+
+		if input.Root.TotalListMonthlyCost != nil {
+			hasListMonthlyCost = true
+		}
+
 		if input.Root.TotalHourlyCost != nil {
 			if totalHourlyCost == nil {
 				totalHourlyCost = decimalPtr(decimal.Zero)
@@ -93,6 +107,12 @@ func Combine(currency string, inputs []ReportInput, opts Options) Root {
 	combined.DiffTotalMonthlyCost = diffTotalMonthlyCost
 	combined.TimeGenerated = time.Now()
 	combined.Summary = MergeSummaries(summaries)
+	combined.Warnings = warnings
+	combined.SavingsPlans = mergeSavingsPlans(savingsPlans)
+	combined.TotalUpfrontCost = totalUpfrontCost
+	if hasListMonthlyCost {
+		combined.TotalListMonthlyCost = listMonthlyCost(projects)
+	}
 
 	return combined
 }
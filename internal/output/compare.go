@@ -0,0 +1,83 @@
+package output
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Compare diffs the projects in two Infracost JSON outputs and returns the
+// result in the same format as a diff run. Projects are matched by name, or
+// by metadata path if the names don't match. Resources are matched by their
+// address. Projects that are only in the old output are shown as removed.
+func Compare(oldOut Root, newOut Root) (Root, error) {
+	oldProjects := ToSchemaProjects(oldOut)
+	newProjects := ToSchemaProjects(newOut)
+
+	matched := make(map[*schema.Project]bool, len(oldProjects))
+	projects := make([]*schema.Project, 0, len(newProjects))
+
+	for _, p := range newProjects {
+		past := findMatchingProject(oldProjects, p, matched)
+		if past != nil {
+			matched[past] = true
+			p.PastResources = past.Resources
+		}
+
+		projects = append(projects, p)
+	}
+
+	for _, past := range oldProjects {
+		if matched[past] {
+			continue
+		}
+
+		p := schema.NewProject(past.Name, past.Metadata)
+		p.PastResources = past.Resources
+		projects = append(projects, p)
+	}
+
+	for _, p := range projects {
+		p.CalculateDiff()
+	}
+
+	out, err := ToOutputFormat(projects)
+	if err != nil {
+		return out, err
+	}
+
+	out.Currency = newOut.Currency
+
+	// The resources in the JSON outputs don't include the skipped resources or
+	// the price warnings so use the summaries and warnings from the new output
+	// instead of recalculating them.
+	for i := range newOut.Projects {
+		if newOut.Projects[i].Summary != nil {
+			out.Projects[i].Summary = newOut.Projects[i].Summary
+		}
+		out.Projects[i].SavingsPlans = newOut.Projects[i].SavingsPlans
+	}
+
+	if newOut.Summary != nil {
+		out.Summary = newOut.Summary
+	}
+
+	out.Warnings = newOut.Warnings
+	out.SavingsPlans = newOut.SavingsPlans
+
+	return out, nil
+}
+
+func findMatchingProject(projects []*schema.Project, p *schema.Project, matched map[*schema.Project]bool) *schema.Project {
+	for _, past := range projects {
+		if !matched[past] && past.Name == p.Name {
+			return past
+		}
+	}
+
+	for _, past := range projects {
+		if !matched[past] && past.Metadata.Path != "" && past.Metadata.Path == p.Metadata.Path {
+			return past
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func compareTestProject(name string, path string, costs map[string]int64) Project {
+	resources := make([]Resource, 0, len(costs))
+	for addr, cost := range costs {
+		monthlyCost := decimal.NewFromInt(cost)
+		resources = append(resources, Resource{
+			Name:        addr,
+			MonthlyCost: &monthlyCost,
+			CostComponents: []CostComponent{
+				{
+					Name:            "Instance usage",
+					Unit:            "months",
+					MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+					Price:           monthlyCost,
+					MonthlyCost:     &monthlyCost,
+				},
+			},
+		})
+	}
+
+	return Project{
+		Name:      name,
+		Metadata:  &schema.ProjectMetadata{Path: path},
+		Breakdown: &Breakdown{Resources: resources},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	oldOut := Root{
+		Currency: "USD",
+		Projects: []Project{
+			compareTestProject("app", "app", map[string]int64{"aws_instance.web": 100}),
+			compareTestProject("old-db-name", "db", map[string]int64{"aws_db_instance.main": 200}),
+			compareTestProject("legacy", "legacy", map[string]int64{"aws_instance.legacy": 30}),
+		},
+	}
+
+	newOut := Root{
+		Currency: "USD",
+		Projects: []Project{
+			compareTestProject("app", "app", map[string]int64{"aws_instance.web": 150}),
+			compareTestProject("db", "db", map[string]int64{"aws_db_instance.main": 200}),
+		},
+	}
+
+	out, err := Compare(oldOut, newOut)
+	require.NoError(t, err)
+
+	assert.Equal(t, "USD", out.Currency)
+	require.Len(t, out.Projects, 3)
+
+	app := out.Projects[0]
+	assert.Equal(t, "app", app.Name)
+	assert.Equal(t, "50", app.Diff.TotalMonthlyCost.String())
+
+	db := out.Projects[1]
+	assert.Equal(t, "db", db.Name)
+	assert.Equal(t, "200", db.PastBreakdown.TotalMonthlyCost.String())
+	assert.Equal(t, "0", db.Diff.TotalMonthlyCost.String())
+
+	legacy := out.Projects[2]
+	assert.Equal(t, "legacy", legacy.Name)
+	assert.Equal(t, "-30", legacy.Diff.TotalMonthlyCost.String())
+
+	assert.Equal(t, "350", out.TotalMonthlyCost.String())
+	assert.Equal(t, "330", out.PastTotalMonthlyCost.String())
+	assert.Equal(t, "20", out.DiffTotalMonthlyCost.String())
+}
@@ -28,7 +28,29 @@ type Root struct {
 	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
 	TimeGenerated        time.Time        `json:"timeGenerated"`
 	Summary              *Summary         `json:"summary"`
+	Warnings             []Warning        `json:"warnings,omitempty"`
 	FullSummary          *Summary         `json:"-"`
+
+	// SavingsPlans is set if any Savings Plans are set in the config file.
+	SavingsPlans *SavingsPlans `json:"savingsPlans,omitempty"`
+
+	// TotalUpfrontCost is the total of the one-off fees, e.g. the upfront
+	// fees of reservations, which aren't included in the monthly costs.
+	TotalUpfrontCost *decimal.Decimal `json:"totalUpfrontCost,omitempty"`
+
+	// TotalListMonthlyCost is the total monthly cost at the list prices. It
+	// is only set if a price overrides file changed any of the prices.
+	TotalListMonthlyCost *decimal.Decimal `json:"totalListMonthlyCost,omitempty"`
+}
+
+// Warning is a problem with the price lookup for a cost component that means
+// its cost might be wrong, e.g. no price was found so it was priced at 0.00.
+type Warning struct {
+	Type          string `json:"type"`
+	Message       string `json:"message"`
+	ProjectName   string `json:"projectName"`
+	ResourceName  string `json:"resourceName"`
+	CostComponent string `json:"costComponent"`
 }
 
 type Project struct {
@@ -39,6 +61,8 @@ type Project struct {
 	Diff          *Breakdown              `json:"diff"`
 	Summary       *Summary                `json:"summary"`
 	fullSummary   *Summary
+
+	SavingsPlans *ProjectSavingsPlans `json:"savingsPlans,omitempty"`
 }
 
 func (p *Project) Label(dashboardEnabled bool) string {
@@ -52,6 +76,9 @@ type Breakdown struct {
 	Resources        []Resource       `json:"resources"`
 	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
 	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+
+	TotalUpfrontCost     *decimal.Decimal `json:"totalUpfrontCost,omitempty"`
+	TotalListMonthlyCost *decimal.Decimal `json:"totalListMonthlyCost,omitempty"`
 }
 
 type CostComponent struct {
@@ -62,16 +89,26 @@ type CostComponent struct {
 	Price           decimal.Decimal  `json:"price"`
 	HourlyCost      *decimal.Decimal `json:"hourlyCost"`
 	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+
+	UpfrontQuantity *decimal.Decimal `json:"upfrontQuantity,omitempty"`
+	UpfrontCost     *decimal.Decimal `json:"upfrontCost,omitempty"`
+
+	ListPrice       *decimal.Decimal `json:"listPrice,omitempty"`
+	ListMonthlyCost *decimal.Decimal `json:"listMonthlyCost,omitempty"`
 }
 
 type Resource struct {
-	Name           string            `json:"name"`
-	Tags           map[string]string `json:"tags,omitempty"`
-	Metadata       map[string]string `json:"metadata"`
-	HourlyCost     *decimal.Decimal  `json:"hourlyCost"`
-	MonthlyCost    *decimal.Decimal  `json:"monthlyCost"`
-	CostComponents []CostComponent   `json:"costComponents,omitempty"`
-	SubResources   []Resource        `json:"subresources,omitempty"`
+	Name             string            `json:"name"`
+	Tags             map[string]string `json:"tags,omitempty"`
+	Metadata         map[string]string `json:"metadata"`
+	CloudResourceIDs []string          `json:"cloudResourceIds,omitempty"`
+	HourlyCost       *decimal.Decimal  `json:"hourlyCost"`
+	MonthlyCost      *decimal.Decimal  `json:"monthlyCost"`
+	CostComponents   []CostComponent   `json:"costComponents,omitempty"`
+	SubResources     []Resource        `json:"subresources,omitempty"`
+
+	UpfrontCost     *decimal.Decimal `json:"upfrontCost,omitempty"`
+	ListMonthlyCost *decimal.Decimal `json:"listMonthlyCost,omitempty"`
 }
 
 type Summary struct {
@@ -125,6 +162,9 @@ func outputBreakdown(resources []*schema.Resource) *Breakdown {
 		Resources:        arr,
 		TotalHourlyCost:  totalMonthlyCost,
 		TotalMonthlyCost: totalHourlyCost,
+		TotalUpfrontCost: calculateTotalUpfrontCost(arr),
+
+		TotalListMonthlyCost: calculateTotalListMonthlyCost(arr),
 	}
 }
 
@@ -140,6 +180,10 @@ func outputResource(r *schema.Resource) Resource {
 			Price:           c.UnitMultiplierPrice(),
 			HourlyCost:      c.HourlyCost,
 			MonthlyCost:     c.MonthlyCost,
+			UpfrontQuantity: c.UpfrontQuantity,
+			UpfrontCost:     c.UpfrontCost,
+			ListPrice:       c.UnitMultiplierListPrice(),
+			ListMonthlyCost: c.ListMonthlyCost,
 		})
 	}
 
@@ -149,24 +193,141 @@ func outputResource(r *schema.Resource) Resource {
 	}
 
 	return Resource{
-		Name:           r.Name,
-		Metadata:       map[string]string{},
-		Tags:           r.Tags,
-		HourlyCost:     r.HourlyCost,
-		MonthlyCost:    r.MonthlyCost,
-		CostComponents: comps,
-		SubResources:   subresources,
+		Name:             r.Name,
+		Metadata:         map[string]string{},
+		Tags:             r.Tags,
+		CloudResourceIDs: r.CloudResourceIDs,
+		HourlyCost:       r.HourlyCost,
+		MonthlyCost:      r.MonthlyCost,
+		CostComponents:   comps,
+		SubResources:     subresources,
+		UpfrontCost:      r.UpfrontCost,
+		ListMonthlyCost:  r.ListMonthlyCost,
+	}
+}
+
+// StripCloudResourceIDs returns a copy of the output without the IDs and ARNs
+// of the deployed resources, since they're only included when requested.
+func StripCloudResourceIDs(out Root) Root {
+	projects := make([]Project, 0, len(out.Projects))
+
+	for _, p := range out.Projects {
+		p.PastBreakdown = stripBreakdownCloudResourceIDs(p.PastBreakdown)
+		p.Breakdown = stripBreakdownCloudResourceIDs(p.Breakdown)
+		p.Diff = stripBreakdownCloudResourceIDs(p.Diff)
+		projects = append(projects, p)
+	}
+
+	out.Projects = projects
+
+	return out
+}
+
+func stripBreakdownCloudResourceIDs(b *Breakdown) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	stripped := *b
+	stripped.Resources = stripResourceCloudResourceIDs(b.Resources)
+
+	return &stripped
+}
+
+func stripResourceCloudResourceIDs(resources []Resource) []Resource {
+	if resources == nil {
+		return nil
+	}
+
+	stripped := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		r.CloudResourceIDs = nil
+		r.SubResources = stripResourceCloudResourceIDs(r.SubResources)
+		stripped = append(stripped, r)
+	}
+
+	return stripped
+}
+
+// ToSchemaProjects converts the projects in the output back into schema
+// projects so that a previous output can be used as the baseline of a diff.
+// The costs are copied as they are so the resources don't need to be priced
+// again.
+func ToSchemaProjects(out Root) []*schema.Project {
+	projects := make([]*schema.Project, 0, len(out.Projects))
+
+	for _, p := range out.Projects {
+		metadata := p.Metadata
+		if metadata == nil {
+			metadata = &schema.ProjectMetadata{}
+		}
+
+		project := schema.NewProject(p.Name, metadata)
+
+		if p.Breakdown != nil {
+			for _, r := range p.Breakdown.Resources {
+				project.Resources = append(project.Resources, schemaResource(r))
+			}
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects
+}
+
+func schemaResource(r Resource) *schema.Resource {
+	comps := make([]*schema.CostComponent, 0, len(r.CostComponents))
+	for _, c := range r.CostComponents {
+		comp := &schema.CostComponent{
+			Name:            c.Name,
+			Unit:            c.Unit,
+			UnitMultiplier:  decimal.NewFromInt(1),
+			HourlyQuantity:  c.HourlyQuantity,
+			MonthlyQuantity: c.MonthlyQuantity,
+			HourlyCost:      c.HourlyCost,
+			MonthlyCost:     c.MonthlyCost,
+			UpfrontQuantity: c.UpfrontQuantity,
+			UpfrontCost:     c.UpfrontCost,
+			ListMonthlyCost: c.ListMonthlyCost,
+		}
+		comp.SetPrice(c.Price)
+		if c.ListPrice != nil {
+			comp.SetListPrice(*c.ListPrice)
+		}
+
+		comps = append(comps, comp)
+	}
+
+	subresources := make([]*schema.Resource, 0, len(r.SubResources))
+	for _, s := range r.SubResources {
+		subresources = append(subresources, schemaResource(s))
+	}
+
+	return &schema.Resource{
+		Name:             r.Name,
+		Tags:             r.Tags,
+		CloudResourceIDs: r.CloudResourceIDs,
+		HourlyCost:       r.HourlyCost,
+		MonthlyCost:      r.MonthlyCost,
+		CostComponents:   comps,
+		SubResources:     subresources,
+		UpfrontCost:      r.UpfrontCost,
+		ListMonthlyCost:  r.ListMonthlyCost,
 	}
 }
 
 func ToOutputFormat(projects []*schema.Project) (Root, error) {
 	var totalMonthlyCost, totalHourlyCost,
 		pastTotalMonthlyCost, pastTotalHourlyCost,
-		diffTotalMonthlyCost, diffTotalHourlyCost *decimal.Decimal
+		diffTotalMonthlyCost, diffTotalHourlyCost,
+		totalUpfrontCost *decimal.Decimal
+	hasListMonthlyCost := false
 
 	outProjects := make([]Project, 0, len(projects))
 	summaries := make([]*Summary, 0, len(projects))
 	fullSummaries := make([]*Summary, 0, len(projects))
+	warnings := make([]Warning, 0)
 
 	for _, project := range projects {
 		var pastBreakdown, breakdown, diff *Breakdown
@@ -187,6 +348,17 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 				}
 				totalMonthlyCost = decimalPtr(totalMonthlyCost.Add(*breakdown.TotalMonthlyCost))
 			}
+
+			if breakdown.TotalUpfrontCost != nil {
+				if totalUpfrontCost == nil {
+					totalUpfrontCost = decimalPtr(decimal.Zero)
+				}
+				totalUpfrontCost = decimalPtr(totalUpfrontCost.Add(*breakdown.TotalUpfrontCost))
+			}
+
+			if breakdown.TotalListMonthlyCost != nil {
+				hasListMonthlyCost = true
+			}
 		}
 
 		if project.HasDiff {
@@ -226,6 +398,8 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 			}
 		}
 
+		warnings = append(warnings, priceWarnings(project)...)
+
 		summary, err := BuildSummary(project.Resources, SummaryOptions{
 			OnlyFields: []string{
 				"TotalDetectedResources",
@@ -270,12 +444,67 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 		DiffTotalMonthlyCost: diffTotalMonthlyCost,
 		TimeGenerated:        time.Now(),
 		Summary:              MergeSummaries(summaries),
+		Warnings:             warnings,
 		FullSummary:          MergeSummaries(fullSummaries),
+		TotalUpfrontCost:     totalUpfrontCost,
+	}
+
+	// The list total includes the monthly cost of the projects that had no
+	// prices changed so it can be compared with the overall total.
+	if hasListMonthlyCost {
+		out.TotalListMonthlyCost = listMonthlyCost(outProjects)
 	}
 
 	return out, nil
 }
 
+// priceWarnings returns a warning for each problem with the price lookups
+// for the cost components in the project, including the past resources of a
+// diff. Subresource names are prefixed with the name of their parent.
+func priceWarnings(project *schema.Project) []Warning {
+	warnings := make([]Warning, 0)
+	seen := make(map[Warning]bool)
+
+	var addResource func(name string, r *schema.Resource)
+	addResource = func(name string, r *schema.Resource) {
+		for _, c := range r.CostComponents {
+			for _, w := range c.PriceWarnings() {
+				warning := Warning{
+					Type:          string(w.Type),
+					Message:       w.Message,
+					ProjectName:   project.Name,
+					ResourceName:  name,
+					CostComponent: c.Name,
+				}
+
+				if !seen[warning] {
+					seen[warning] = true
+					warnings = append(warnings, warning)
+				}
+			}
+		}
+
+		for _, s := range r.SubResources {
+			addResource(name+"."+s.Name, s)
+		}
+	}
+
+	for _, r := range project.AllResources() {
+		if !r.IsSkipped {
+			addResource(r.Name, r)
+		}
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].ResourceName == warnings[j].ResourceName {
+			return warnings[i].CostComponent < warnings[j].CostComponent
+		}
+		return warnings[i].ResourceName < warnings[j].ResourceName
+	})
+
+	return warnings
+}
+
 func (r *Root) summaryMessage(showSkipped bool) string {
 	msg := ""
 
@@ -341,6 +570,20 @@ func (r *Root) summaryMessage(showSkipped bool) string {
 		}
 	}
 
+	if len(r.Warnings) > 0 {
+		if len(r.Warnings) == 1 {
+			msg += "\n∙ 1 cost component had a pricing warning, its cost might be wrong:"
+		} else {
+			msg += fmt.Sprintf("\n∙ %d cost components had pricing warnings, their costs might be wrong:", len(r.Warnings))
+		}
+
+		for _, w := range r.Warnings {
+			msg += fmt.Sprintf("\n  ∙ %s › %s: %s", w.ResourceName, w.CostComponent, w.Message)
+		}
+	}
+
+	msg += r.savingsPlansMessage()
+
 	if r.ShareURL != "" {
 		msg += fmt.Sprintf("\n\nShare the results: %s", ui.LinkString(r.ShareURL))
 	}
@@ -548,6 +791,67 @@ func calculateTotalCosts(resources []Resource) (*decimal.Decimal, *decimal.Decim
 	return totalHourlyCost, totalMonthlyCost
 }
 
+// calculateTotalUpfrontCost returns the total upfront cost of the resources,
+// or nil if none of them have an upfront cost.
+func calculateTotalUpfrontCost(resources []Resource) *decimal.Decimal {
+	var total *decimal.Decimal
+
+	for _, r := range resources {
+		if r.UpfrontCost != nil {
+			if total == nil {
+				total = decimalPtr(decimal.Zero)
+			}
+
+			total = decimalPtr(total.Add(*r.UpfrontCost))
+		}
+	}
+
+	return total
+}
+
+// calculateTotalListMonthlyCost returns the total monthly cost of the
+// resources at the list prices, or nil if none of their prices were changed.
+// Resources without a list cost are included at their monthly cost.
+func calculateTotalListMonthlyCost(resources []Resource) *decimal.Decimal {
+	total := decimal.Zero
+	hasListCost := false
+
+	for _, r := range resources {
+		if r.ListMonthlyCost != nil {
+			hasListCost = true
+			total = total.Add(*r.ListMonthlyCost)
+		} else if r.MonthlyCost != nil {
+			total = total.Add(*r.MonthlyCost)
+		}
+	}
+
+	if !hasListCost {
+		return nil
+	}
+
+	return &total
+}
+
+// listMonthlyCost returns the total monthly cost of the projects at the list
+// prices. Projects without a list cost are included at their monthly cost.
+func listMonthlyCost(projects []Project) *decimal.Decimal {
+	total := decimal.Zero
+
+	for _, p := range projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		if p.Breakdown.TotalListMonthlyCost != nil {
+			total = total.Add(*p.Breakdown.TotalListMonthlyCost)
+		} else if p.Breakdown.TotalMonthlyCost != nil {
+			total = total.Add(*p.Breakdown.TotalMonthlyCost)
+		}
+	}
+
+	return &total
+}
+
 func sortResources(resources []Resource, groupKey string) {
 	sort.Slice(resources, func(i, j int) bool {
 		// If an empty group key is passed just sort by name
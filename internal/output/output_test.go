@@ -5,6 +5,9 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
 )
 
 func TestCalculateTotalCosts(t *testing.T) {
@@ -32,3 +35,160 @@ func TestCalculateTotalCosts(t *testing.T) {
 	actual, _ = totalMonthlyCost.Float64()
 	assert.Equal(t, expected, actual)
 }
+
+func TestToSchemaProjects(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Name:     "infracost/infracost/examples/terraform",
+				Metadata: &schema.ProjectMetadata{Path: "examples/terraform"},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name:        "aws_instance.web",
+							Tags:        map[string]string{"team": "payments"},
+							HourlyCost:  decimalPtr(decimal.NewFromFloat(0.1)),
+							MonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+							CostComponents: []CostComponent{
+								{
+									Name:            "Instance usage (Linux/UNIX, on-demand, m5.large)",
+									Unit:            "hours",
+									HourlyQuantity:  decimalPtr(decimal.NewFromInt(1)),
+									MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+									Price:           decimal.NewFromFloat(0.1),
+									HourlyCost:      decimalPtr(decimal.NewFromFloat(0.1)),
+									MonthlyCost:     decimalPtr(decimal.NewFromInt(73)),
+								},
+							},
+							SubResources: []Resource{
+								{Name: "root_block_device"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	projects := ToSchemaProjects(out)
+	require.Len(t, projects, 1)
+
+	p := projects[0]
+	assert.Equal(t, "infracost/infracost/examples/terraform", p.Name)
+	assert.Equal(t, "examples/terraform", p.Metadata.Path)
+	require.Len(t, p.Resources, 1)
+
+	r := p.Resources[0]
+	assert.Equal(t, "aws_instance.web", r.Name)
+	assert.Equal(t, "payments", r.Tags["team"])
+	assert.True(t, r.MonthlyCost.Equal(decimal.NewFromInt(73)))
+	require.Len(t, r.SubResources, 1)
+	require.Len(t, r.CostComponents, 1)
+
+	c := r.CostComponents[0]
+	assert.True(t, c.Price().Equal(decimal.NewFromFloat(0.1)))
+	assert.True(t, c.UnitMultiplierMonthlyQuantity().Equal(decimal.NewFromInt(730)))
+	assert.True(t, c.MonthlyCost.Equal(decimal.NewFromInt(73)))
+}
+
+func TestStripCloudResourceIDs(t *testing.T) {
+	resources := []Resource{
+		{
+			Name:             "aws_instance.web",
+			CloudResourceIDs: []string{"i-1234567890", "arn:aws:ec2:us-east-1:123456789012:instance/i-1234567890"},
+			SubResources: []Resource{
+				{Name: "root_block_device", CloudResourceIDs: []string{"vol-1234567890"}},
+			},
+		},
+	}
+
+	out := Root{
+		Projects: []Project{
+			{Name: "test", PastBreakdown: &Breakdown{Resources: resources}, Breakdown: &Breakdown{Resources: resources}},
+		},
+	}
+
+	stripped := StripCloudResourceIDs(out)
+
+	for _, b := range []*Breakdown{stripped.Projects[0].PastBreakdown, stripped.Projects[0].Breakdown} {
+		assert.Equal(t, "aws_instance.web", b.Resources[0].Name)
+		assert.Nil(t, b.Resources[0].CloudResourceIDs)
+		assert.Nil(t, b.Resources[0].SubResources[0].CloudResourceIDs)
+	}
+	assert.Nil(t, stripped.Projects[0].Diff)
+
+	// The original output isn't changed
+	assert.Len(t, out.Projects[0].Breakdown.Resources[0].CloudResourceIDs, 2)
+	assert.Len(t, out.Projects[0].Breakdown.Resources[0].SubResources[0].CloudResourceIDs, 1)
+}
+
+func TestToOutputFormatWarnings(t *testing.T) {
+	instance := &schema.CostComponent{Name: "Instance usage"}
+	instance.AddPriceWarning(schema.PriceWarningMultipleProducts, "Multiple products found, using the first product")
+
+	storage := &schema.CostComponent{Name: "Storage"}
+	storage.AddPriceWarning(schema.PriceWarningNoProducts, "No products found, using 0.00")
+
+	resource := &schema.Resource{
+		Name:           "aws_instance.web",
+		CostComponents: []*schema.CostComponent{instance},
+		SubResources: []*schema.Resource{
+			{Name: "root_block_device", CostComponents: []*schema.CostComponent{storage}},
+		},
+	}
+
+	project := schema.NewProject("infracost/infracost/examples/terraform", &schema.ProjectMetadata{})
+	project.PastResources = []*schema.Resource{resource}
+	project.Resources = []*schema.Resource{resource}
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Warning{
+		{
+			Type:          "multiple_products",
+			Message:       "Multiple products found, using the first product",
+			ProjectName:   "infracost/infracost/examples/terraform",
+			ResourceName:  "aws_instance.web",
+			CostComponent: "Instance usage",
+		},
+		{
+			Type:          "no_products",
+			Message:       "No products found, using 0.00",
+			ProjectName:   "infracost/infracost/examples/terraform",
+			ResourceName:  "aws_instance.web.root_block_device",
+			CostComponent: "Storage",
+		},
+	}, out.Warnings)
+
+	msg := out.summaryMessage(false)
+	assert.Contains(t, msg, "∙ 2 cost components had pricing warnings, their costs might be wrong:")
+	assert.Contains(t, msg, "  ∙ aws_instance.web.root_block_device › Storage: No products found, using 0.00")
+}
+
+func TestSavingsPlansMessage(t *testing.T) {
+	detected := 1
+	plan := SavingsPlan{
+		Name:                        "Compute Savings Plan, 1 year no upfront",
+		UnusedCommitmentMonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+		UtilizationPercent:          decimalPtr(decimal.NewFromInt(90)),
+	}
+	savingsPlans := &SavingsPlans{
+		Plans:                     []SavingsPlan{plan},
+		EligibleMonthlyCost:       decimalPtr(decimal.NewFromInt(1000)),
+		CoveredMonthlyCost:        decimalPtr(decimal.NewFromInt(900)),
+		EffectiveTotalMonthlyCost: decimalPtr(decimal.NewFromInt(800)),
+	}
+
+	out := Root{
+		Currency:     "USD",
+		Summary:      &Summary{TotalDetectedResources: &detected},
+		SavingsPlans: mergeSavingsPlans([]*SavingsPlans{nil, savingsPlans}),
+	}
+
+	msg := out.summaryMessage(false)
+	assert.Contains(t, msg, "∙ Savings Plans covered $900.00 of the $1,000.00 eligible monthly cost, the effective monthly cost is $800.00:")
+	assert.Contains(t, msg, "  ∙ Compute Savings Plan, 1 year no upfront: 90.0% utilized, $73.00 unused commitment")
+
+	assert.Nil(t, mergeSavingsPlans([]*SavingsPlans{nil}))
+}
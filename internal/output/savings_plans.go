@@ -0,0 +1,117 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// SavingsPlans is the coverage of the eligible costs of a run by the AWS
+// Savings Plans set in the config file. The covered costs are the on-demand
+// costs that the Savings Plans pay for at their discounted rates.
+type SavingsPlans struct {
+	Plans                       []SavingsPlan    `json:"plans"`
+	EligibleMonthlyCost         *decimal.Decimal `json:"eligibleMonthlyCost"`
+	CoveredMonthlyCost          *decimal.Decimal `json:"coveredMonthlyCost"`
+	UncoveredMonthlyCost        *decimal.Decimal `json:"uncoveredMonthlyCost"`
+	CommitmentMonthlyCost       *decimal.Decimal `json:"commitmentMonthlyCost"`
+	UnusedCommitmentMonthlyCost *decimal.Decimal `json:"unusedCommitmentMonthlyCost"`
+	EffectiveTotalHourlyCost    *decimal.Decimal `json:"effectiveTotalHourlyCost"`
+	EffectiveTotalMonthlyCost   *decimal.Decimal `json:"effectiveTotalMonthlyCost"`
+}
+
+// SavingsPlan is the utilization of a single Savings Plan.
+type SavingsPlan struct {
+	Name                        string           `json:"name"`
+	Type                        string           `json:"type"`
+	Term                        string           `json:"term"`
+	PaymentOption               string           `json:"paymentOption"`
+	HourlyCommitment            *decimal.Decimal `json:"hourlyCommitment"`
+	CoveredMonthlyCost          *decimal.Decimal `json:"coveredMonthlyCost"`
+	UsedCommitmentMonthlyCost   *decimal.Decimal `json:"usedCommitmentMonthlyCost"`
+	UnusedCommitmentMonthlyCost *decimal.Decimal `json:"unusedCommitmentMonthlyCost"`
+	// UtilizationPercent is the percentage of the commitment that is used.
+	UtilizationPercent *decimal.Decimal `json:"utilizationPercent"`
+}
+
+// ProjectSavingsPlans is the coverage of a project's eligible costs. The
+// effective cost includes the commitment used by the project, but not any
+// unused commitment since that isn't spent on a single project.
+type ProjectSavingsPlans struct {
+	EligibleMonthlyCost       *decimal.Decimal `json:"eligibleMonthlyCost"`
+	CoveredMonthlyCost        *decimal.Decimal `json:"coveredMonthlyCost"`
+	UncoveredMonthlyCost      *decimal.Decimal `json:"uncoveredMonthlyCost"`
+	SavingsPlanMonthlyCost    *decimal.Decimal `json:"savingsPlanMonthlyCost"`
+	EffectiveTotalMonthlyCost *decimal.Decimal `json:"effectiveTotalMonthlyCost"`
+}
+
+// mergeSavingsPlans combines the Savings Plans of multiple runs. Each run's
+// coverage was calculated separately so the plans are listed for each run.
+func mergeSavingsPlans(all []*SavingsPlans) *SavingsPlans {
+	var merged *SavingsPlans
+
+	for _, s := range all {
+		if s == nil {
+			continue
+		}
+
+		if merged == nil {
+			merged = &SavingsPlans{Plans: make([]SavingsPlan, 0)}
+		}
+
+		merged.Plans = append(merged.Plans, s.Plans...)
+		merged.EligibleMonthlyCost = addDecimalPtrs(merged.EligibleMonthlyCost, s.EligibleMonthlyCost)
+		merged.CoveredMonthlyCost = addDecimalPtrs(merged.CoveredMonthlyCost, s.CoveredMonthlyCost)
+		merged.UncoveredMonthlyCost = addDecimalPtrs(merged.UncoveredMonthlyCost, s.UncoveredMonthlyCost)
+		merged.CommitmentMonthlyCost = addDecimalPtrs(merged.CommitmentMonthlyCost, s.CommitmentMonthlyCost)
+		merged.UnusedCommitmentMonthlyCost = addDecimalPtrs(merged.UnusedCommitmentMonthlyCost, s.UnusedCommitmentMonthlyCost)
+		merged.EffectiveTotalHourlyCost = addDecimalPtrs(merged.EffectiveTotalHourlyCost, s.EffectiveTotalHourlyCost)
+		merged.EffectiveTotalMonthlyCost = addDecimalPtrs(merged.EffectiveTotalMonthlyCost, s.EffectiveTotalMonthlyCost)
+	}
+
+	return merged
+}
+
+func (r *Root) savingsPlansMessage() string {
+	s := r.SavingsPlans
+	if s == nil {
+		return ""
+	}
+
+	msg := fmt.Sprintf("\n∙ Savings Plans covered %s of the %s eligible monthly cost, the effective monthly cost is %s:",
+		formatCost2DP(r.Currency, s.CoveredMonthlyCost),
+		formatCost2DP(r.Currency, s.EligibleMonthlyCost),
+		formatCost2DP(r.Currency, s.EffectiveTotalMonthlyCost),
+	)
+
+	for _, p := range s.Plans {
+		utilization := decimal.Zero
+		if p.UtilizationPercent != nil {
+			utilization = *p.UtilizationPercent
+		}
+
+		msg += fmt.Sprintf("\n  ∙ %s: %s%% utilized, %s unused commitment",
+			p.Name,
+			utilization.StringFixed(1),
+			formatCost2DP(r.Currency, p.UnusedCommitmentMonthlyCost),
+		)
+	}
+
+	return msg
+}
+
+func addDecimalPtrs(d1 *decimal.Decimal, d2 *decimal.Decimal) *decimal.Decimal {
+	if d1 == nil && d2 == nil {
+		return nil
+	}
+
+	if d1 == nil {
+		return decimalPtr(*d2)
+	}
+
+	if d2 == nil {
+		return decimalPtr(*d1)
+	}
+
+	return decimalPtr(d1.Add(*d2))
+}
@@ -62,6 +62,40 @@ func slackAllProjectsSummaryBlock(out Root, currency string) []*slack.TextBlockO
 	return slackSummaryBlock("All projects", currency, out.TotalMonthlyCost, out.PastTotalMonthlyCost, out.DiffTotalMonthlyCost)
 }
 
+func slackSavingsPlansBlock(out Root) *slack.SectionBlock {
+	s := out.SavingsPlans
+	if s == nil {
+		return nil
+	}
+
+	text := fmt.Sprintf("*Savings Plans* covered %s of the %s eligible monthly cost, the effective monthly cost is %s",
+		formatCost2DP(out.Currency, s.CoveredMonthlyCost),
+		formatCost2DP(out.Currency, s.EligibleMonthlyCost),
+		formatCost2DP(out.Currency, s.EffectiveTotalMonthlyCost),
+	)
+
+	for _, p := range s.Plans {
+		utilization := decimal.Zero
+		if p.UtilizationPercent != nil {
+			utilization = *p.UtilizationPercent
+		}
+
+		text += fmt.Sprintf("\n∙ %s: %s%% utilized, %s unused commitment",
+			p.Name,
+			utilization.StringFixed(1),
+			formatCost2DP(out.Currency, p.UnusedCommitmentMonthlyCost),
+		)
+	}
+
+	return slack.NewSectionBlock(
+		&slack.TextBlockObject{
+			Type: slack.MarkdownType,
+			Text: text,
+		},
+		[]*slack.TextBlockObject{}, nil,
+	)
+}
+
 func ToSlackMessage(out Root, opts Options) ([]byte, error) {
 	diff, err := ToDiff(out, opts)
 	if err != nil {
@@ -130,6 +164,10 @@ func ToSlackMessage(out Root, opts Options) ([]byte, error) {
 		))
 	}
 
+	if savingsPlansBlock := slackSavingsPlansBlock(out); savingsPlansBlock != nil {
+		blocks = append(blocks, savingsPlansBlock)
+	}
+
 	diffMsg := fmt.Sprintf("*Infracost output*\n```%s```", ui.StripColor(string(diff)))
 	diffMsg = truncateMiddle(diffMsg, 3000, "\n\n...(truncated due to Slack message length)...\n\n")
 
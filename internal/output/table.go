@@ -63,6 +63,33 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 		fmt.Sprintf("%*s ", tableLen-(len(overallTitle)+1), totalOut), // pad based on the last line length
 	)
 
+	if out.SavingsPlans != nil {
+		effectiveOut := formatCost2DP(out.Currency, out.SavingsPlans.EffectiveTotalMonthlyCost)
+		effectiveTitle := formatTitleWithCurrency(" EFFECTIVE TOTAL", out.Currency)
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(effectiveTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(effectiveTitle)+1), effectiveOut),
+		)
+	}
+
+	if out.TotalListMonthlyCost != nil {
+		listOut := formatCost2DP(out.Currency, out.TotalListMonthlyCost)
+		listTitle := formatTitleWithCurrency(" LIST PRICE TOTAL", out.Currency)
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(listTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(listTitle)+1), listOut),
+		)
+	}
+
+	if out.TotalUpfrontCost != nil {
+		upfrontOut := formatCost2DP(out.Currency, out.TotalUpfrontCost)
+		upfrontTitle := formatTitleWithCurrency(" UPFRONT TOTAL", out.Currency)
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(upfrontTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(upfrontTitle)+1), upfrontOut),
+		)
+	}
+
 	summaryMsg := out.summaryMessage(opts.ShowSkipped)
 
 	if summaryMsg != "" {
@@ -207,7 +234,17 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 
 		label := fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), c.Name)
 
-		if c.MonthlyCost == nil {
+		if c.MonthlyCost == nil && c.UpfrontCost != nil {
+			upfront := fmt.Sprintf("Upfront fee: %s", formatCost2DP(currency, c.UpfrontCost))
+
+			t.AppendRow(table.Row{
+				label,
+				upfront,
+				upfront,
+				upfront,
+			}, table.RowConfig{AutoMerge: true, AlignAutoMerge: text.AlignLeft})
+
+		} else if c.MonthlyCost == nil {
 			price := fmt.Sprintf("Monthly cost depends on usage: %s per %s",
 				formatPrice(currency, c.Price),
 				c.Unit,
@@ -198,6 +198,8 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
       {{if contains .Fields "monthlyCost"}}
         <td class="monthly-cost">{{.CostComponent.MonthlyCost | formatCost2DP}}</td>
       {{end}}
+    {{else if .CostComponent.UpfrontCost}}
+      <td colspan="{{len .Fields}}" class="usage-cost">Upfront fee: {{.CostComponent.UpfrontCost | formatCost2DP}}</td>
     {{else}}
       <td colspan="{{len .Fields}}" class="usage-cost">Cost depends on usage: {{.CostComponent.Price | formatPrice}} per {{.CostComponent.Unit}}</td>
     {{end}}
@@ -279,6 +281,24 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
           <td class="name" colspan="{{len .Options.Fields}}">{{ "Overall total" | formatTitleWithCurrency }}</td>
           <td class="monthly-cost">{{.Root.TotalMonthlyCost | formatCost2DP}}</td>
         </tr>
+        {{- if .Root.SavingsPlans}}
+        <tr class="total">
+          <td class="name" colspan="{{len .Options.Fields}}">{{ "Effective total" | formatTitleWithCurrency }}</td>
+          <td class="monthly-cost">{{.Root.SavingsPlans.EffectiveTotalMonthlyCost | formatCost2DP}}</td>
+        </tr>
+        {{- end}}
+        {{- if .Root.TotalListMonthlyCost}}
+        <tr class="total">
+          <td class="name" colspan="{{len .Options.Fields}}">{{ "List price total" | formatTitleWithCurrency }}</td>
+          <td class="monthly-cost">{{.Root.TotalListMonthlyCost | formatCost2DP}}</td>
+        </tr>
+        {{- end}}
+        {{- if .Root.TotalUpfrontCost}}
+        <tr class="total">
+          <td class="name" colspan="{{len .Options.Fields}}">{{ "Upfront total" | formatTitleWithCurrency }}</td>
+          <td class="monthly-cost">{{.Root.TotalUpfrontCost | formatCost2DP}}</td>
+        </tr>
+        {{- end}}
       </tbody>
     </table>
 
@@ -339,6 +359,9 @@ var CommentMarkdownTemplate = `
 {{- else }}
 Previous monthly cost: {{ formatCost .Root.PastTotalMonthlyCost }}
 New monthly cost: {{ formatCost .Root.TotalMonthlyCost }}
+{{- if .Root.SavingsPlans }}
+Effective monthly cost with Savings Plans: {{ formatCost .Root.SavingsPlans.EffectiveTotalMonthlyCost }}
+{{- end }}
 
 **Infracost output:**
 {{- end }}
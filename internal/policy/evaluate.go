@@ -0,0 +1,320 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+// Result is the outcome of evaluating a single policy.
+type Result struct {
+	Policy     *Policy
+	Violations []Violation
+}
+
+// Passed returns true if the policy has no violations.
+func (r Result) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// Violation is a single breach of a policy.
+type Violation struct {
+	// Project is the name of the project the violation is in, if any.
+	Project string
+	// Address is the resource address, or the tags for tag policies.
+	Address string
+	Message string
+}
+
+// Results are the results of evaluating all the policies in a file.
+type Results []Result
+
+// ViolationCount returns the total number of violations across all policies.
+func (r Results) ViolationCount() int {
+	count := 0
+	for _, result := range r {
+		count += len(result.Violations)
+	}
+
+	return count
+}
+
+// costs are the values that can be used as metrics in conditions.
+type costs map[string]decimal.Decimal
+
+// Evaluate checks the output against each policy in the file.
+func Evaluate(f *File, out output.Root) Results {
+	currency := out.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	results := make(Results, 0, len(f.Policies))
+
+	for _, p := range f.Policies {
+		var violations []Violation
+
+		switch p.Scope() {
+		case ScopeRun:
+			violations = evaluateRun(p, out, currency)
+		case ScopeProject:
+			violations = evaluateProjects(p, out, currency)
+		case ScopeTag:
+			violations = evaluateTags(p, out, currency)
+		case ScopeResource:
+			violations = evaluateResources(p, out, currency)
+		}
+
+		results = append(results, Result{
+			Policy:     p,
+			Violations: violations,
+		})
+	}
+
+	return results
+}
+
+func evaluateRun(p *Policy, out output.Root, currency string) []Violation {
+	c := costs{
+		"totalMonthlyCost":     value(out.TotalMonthlyCost),
+		"totalHourlyCost":      value(out.TotalHourlyCost),
+		"pastTotalMonthlyCost": value(out.PastTotalMonthlyCost),
+		"pastTotalHourlyCost":  value(out.PastTotalHourlyCost),
+		"diffTotalMonthlyCost": value(out.DiffTotalMonthlyCost),
+		"diffTotalHourlyCost":  value(out.DiffTotalHourlyCost),
+	}
+
+	if msg, ok := checkCondition(p, c, currency); !ok {
+		return []Violation{{Message: msg}}
+	}
+
+	return nil
+}
+
+func evaluateProjects(p *Policy, out output.Root, currency string) []Violation {
+	violations := make([]Violation, 0)
+
+	for _, project := range filterProjects(p, out.Projects) {
+		c := costs{}
+		addBreakdownCosts(c, "total", project.Breakdown)
+		addBreakdownCosts(c, "pastTotal", project.PastBreakdown)
+		addBreakdownCosts(c, "diffTotal", project.Diff)
+
+		if msg, ok := checkCondition(p, c, currency); !ok {
+			violations = append(violations, Violation{
+				Project: project.Name,
+				Message: msg,
+			})
+		}
+	}
+
+	return violations
+}
+
+func evaluateTags(p *Policy, out output.Root, currency string) []Violation {
+	var monthly, hourly, pastMonthly, pastHourly decimal.Decimal
+
+	for _, project := range filterProjects(p, out.Projects) {
+		if project.Breakdown != nil {
+			for _, r := range project.Breakdown.Resources {
+				if matchTags(p.Tags, r.Tags) {
+					monthly = monthly.Add(value(r.MonthlyCost))
+					hourly = hourly.Add(value(r.HourlyCost))
+				}
+			}
+		}
+
+		if project.PastBreakdown != nil {
+			for _, r := range project.PastBreakdown.Resources {
+				if matchTags(p.Tags, r.Tags) {
+					pastMonthly = pastMonthly.Add(value(r.MonthlyCost))
+					pastHourly = pastHourly.Add(value(r.HourlyCost))
+				}
+			}
+		}
+	}
+
+	c := costs{
+		"totalMonthlyCost":     monthly,
+		"totalHourlyCost":      hourly,
+		"pastTotalMonthlyCost": pastMonthly,
+		"pastTotalHourlyCost":  pastHourly,
+		"diffTotalMonthlyCost": monthly.Sub(pastMonthly),
+		"diffTotalHourlyCost":  hourly.Sub(pastHourly),
+	}
+
+	if msg, ok := checkCondition(p, c, currency); !ok {
+		return []Violation{{
+			Address: formatTags(p.Tags),
+			Message: msg,
+		}}
+	}
+
+	return nil
+}
+
+func evaluateResources(p *Policy, out output.Root, currency string) []Violation {
+	violations := make([]Violation, 0)
+
+	for _, project := range filterProjects(p, out.Projects) {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range project.Breakdown.Resources {
+			if !matchAnyGlob(p.Resources, r.Name) {
+				continue
+			}
+
+			for _, name := range deniedCostComponents(p.DenyCostComponents, r) {
+				violations = append(violations, Violation{
+					Project: project.Name,
+					Address: r.Name,
+					Message: fmt.Sprintf("%s is not allowed", name),
+				})
+			}
+
+			if p.condition == nil {
+				continue
+			}
+
+			c := costs{
+				"monthlyCost":     value(r.MonthlyCost),
+				"hourlyCost":      value(r.HourlyCost),
+				"diffMonthlyCost": decimal.Zero,
+				"diffHourlyCost":  decimal.Zero,
+			}
+
+			if diff := findResource(project.Diff, r.Name); diff != nil {
+				c["diffMonthlyCost"] = value(diff.MonthlyCost)
+				c["diffHourlyCost"] = value(diff.HourlyCost)
+			}
+
+			if msg, ok := checkCondition(p, c, currency); !ok {
+				violations = append(violations, Violation{
+					Project: project.Name,
+					Address: r.Name,
+					Message: msg,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// checkCondition returns false and a message describing the violation if the
+// policy's condition doesn't hold.
+func checkCondition(p *Policy, c costs, currency string) (string, bool) {
+	if p.condition == nil {
+		return "", true
+	}
+
+	actual := c[p.condition.metric]
+	if p.condition.holds(actual) {
+		return "", true
+	}
+
+	return fmt.Sprintf("%s is %s, must be %s %s",
+		p.condition.metric,
+		output.FormatCost2DP(currency, &actual),
+		p.condition.op,
+		output.FormatCost2DP(currency, &p.condition.value),
+	), false
+}
+
+func filterProjects(p *Policy, projects []output.Project) []output.Project {
+	if len(p.Projects) == 0 {
+		return projects
+	}
+
+	filtered := make([]output.Project, 0, len(projects))
+	for _, project := range projects {
+		if matchAnyGlob(p.Projects, project.Name) {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
+
+func addBreakdownCosts(c costs, prefix string, b *output.Breakdown) {
+	var monthly, hourly decimal.Decimal
+	if b != nil {
+		monthly = value(b.TotalMonthlyCost)
+		hourly = value(b.TotalHourlyCost)
+	}
+
+	c[prefix+"MonthlyCost"] = monthly
+	c[prefix+"HourlyCost"] = hourly
+}
+
+// deniedCostComponents returns the names of the cost components of the
+// resource and its sub-resources that match any of the patterns.
+func deniedCostComponents(patterns []string, r output.Resource) []string {
+	names := make([]string, 0)
+
+	if len(patterns) == 0 {
+		return names
+	}
+
+	for _, c := range r.CostComponents {
+		if matchAnyGlob(patterns, c.Name) {
+			names = append(names, c.Name)
+		}
+	}
+
+	for _, s := range r.SubResources {
+		for _, name := range deniedCostComponents(patterns, s) {
+			names = append(names, fmt.Sprintf("%s %s", s.Name, name))
+		}
+	}
+
+	return names
+}
+
+func findResource(b *output.Breakdown, name string) *output.Resource {
+	if b == nil {
+		return nil
+	}
+
+	for i := range b.Resources {
+		if b.Resources[i].Name == name {
+			return &b.Resources[i]
+		}
+	}
+
+	return nil
+}
+
+func matchTags(want map[string]string, tags map[string]string) bool {
+	for k, v := range want {
+		if tags[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ", ")
+}
+
+func value(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+
+	return *d
+}
@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// ToText formats the results for the terminal. Only failed policies are
+// listed with each of their violations.
+func ToText(results Results) string {
+	var b strings.Builder
+
+	count := results.ViolationCount()
+	if count == 0 {
+		fmt.Fprintf(&b, "%s %s\n", ui.SuccessString("✔"), fmt.Sprintf("All %d cost policies passed", len(results)))
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%s\n", ui.BoldString(fmt.Sprintf("%s found:", pluralize(count, "cost policy violation", "cost policy violations"))))
+
+	for _, result := range results {
+		if result.Passed() {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n%s %s\n", ui.ErrorString("✖"), ui.BoldString(result.Policy.Name))
+		if result.Policy.Description != "" {
+			fmt.Fprintf(&b, "  %s\n", ui.FaintString(result.Policy.Description))
+		}
+
+		for _, v := range result.Violations {
+			fmt.Fprintf(&b, "  ∙ %s\n", formatViolation(v, ui.PrimaryString))
+		}
+	}
+
+	return b.String()
+}
+
+// ToMarkdown formats the results as a markdown table that can be posted in
+// a pull request comment.
+func ToMarkdown(results Results) []byte {
+	var b strings.Builder
+
+	count := results.ViolationCount()
+
+	b.WriteString("## Infracost cost policies\n\n")
+
+	if count == 0 {
+		fmt.Fprintf(&b, "✅ All %d cost policies passed.\n", len(results))
+		return []byte(b.String())
+	}
+
+	fmt.Fprintf(&b, "❌ %s found.\n\n", pluralize(count, "cost policy violation", "cost policy violations"))
+
+	b.WriteString("| Policy | Result | Details |\n")
+	b.WriteString("| ------ | ------ | ------- |\n")
+
+	for _, result := range results {
+		if result.Passed() {
+			fmt.Fprintf(&b, "| %s | ✅ Passed | |\n", escapeMarkdownCell(result.Policy.Name))
+			continue
+		}
+
+		details := make([]string, 0, len(result.Violations))
+		for _, v := range result.Violations {
+			details = append(details, escapeMarkdownCell(formatViolation(v, func(s string) string {
+				return fmt.Sprintf("`%s`", s)
+			})))
+		}
+
+		fmt.Fprintf(&b, "| %s | ❌ Failed | %s |\n", escapeMarkdownCell(result.Policy.Name), strings.Join(details, "<br>"))
+	}
+
+	return []byte(b.String())
+}
+
+func formatViolation(v Violation, highlight func(string) string) string {
+	location := make([]string, 0, 2)
+	if v.Project != "" {
+		location = append(location, highlight(v.Project))
+	}
+	if v.Address != "" {
+		location = append(location, highlight(v.Address))
+	}
+
+	if len(location) == 0 {
+		return v.Message
+	}
+
+	return fmt.Sprintf("%s: %s", strings.Join(location, " "), v.Message)
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func pluralize(count int, singular string, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+
+	return fmt.Sprintf("%d %s", count, plural)
+}
@@ -0,0 +1,263 @@
+// Package policy evaluates cost policies, such as budgets and denied cost
+// components, against the Infracost output so CI pipelines can block changes
+// that break them.
+package policy
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v2"
+)
+
+// ExitCode is the exit code used when a run has policy violations. It is
+// different to the exit code for errors so pipelines can tell them apart.
+const ExitCode = 2
+
+const (
+	minPolicyFileVersion = "0.1"
+	maxPolicyFileVersion = "0.1"
+)
+
+// Scope is the level of the output a policy is evaluated at.
+type Scope string
+
+const (
+	ScopeRun      Scope = "run"
+	ScopeProject  Scope = "project"
+	ScopeTag      Scope = "tag"
+	ScopeResource Scope = "resource"
+)
+
+// metricsByScope lists the metrics that can be used in the conditions of
+// policies for each scope. The names match the fields in the JSON output.
+var metricsByScope = map[Scope][]string{
+	ScopeRun:      {"totalMonthlyCost", "totalHourlyCost", "pastTotalMonthlyCost", "pastTotalHourlyCost", "diffTotalMonthlyCost", "diffTotalHourlyCost"},
+	ScopeProject:  {"totalMonthlyCost", "totalHourlyCost", "pastTotalMonthlyCost", "pastTotalHourlyCost", "diffTotalMonthlyCost", "diffTotalHourlyCost"},
+	ScopeTag:      {"totalMonthlyCost", "totalHourlyCost", "pastTotalMonthlyCost", "pastTotalHourlyCost", "diffTotalMonthlyCost", "diffTotalHourlyCost"},
+	ScopeResource: {"monthlyCost", "hourlyCost", "diffMonthlyCost", "diffHourlyCost"},
+}
+
+var conditionRegex = regexp.MustCompile(`^\s*([A-Za-z]+)\s*(<=|>=|==|!=|<|>)\s*\$?\s*(-?[0-9][0-9,]*(?:\.[0-9]+)?)\s*$`)
+
+// File is a policy file. It is referenced from the config file using the
+// policy_file key.
+type File struct {
+	Version  string    `yaml:"version"`
+	Policies []*Policy `yaml:"policies"`
+}
+
+// Policy is a single rule that the output must satisfy. Which of Resources,
+// Tags and Projects are set decides the scope the policy is evaluated at:
+//
+//   - Resources: each matching resource is checked on its own.
+//   - Tags: the costs of all resources with the tags are summed.
+//   - Projects: each matching project is checked on its own.
+//   - none of them: the totals for the whole run are checked.
+//
+// Projects can be combined with Resources or Tags to only check resources in
+// those projects.
+type Policy struct {
+	// Name is shown with any violations of the policy.
+	Name string `yaml:"name"`
+	// Description is an optional longer explanation of the policy.
+	Description string `yaml:"description,omitempty"`
+	// Condition must be true for the policy to pass, e.g. "diffTotalMonthlyCost < 500".
+	Condition string `yaml:"condition,omitempty"`
+	// Projects are glob patterns for the project names the policy applies to.
+	Projects []string `yaml:"projects,omitempty"`
+	// Resources are glob patterns for the resource addresses the policy applies to.
+	Resources []string `yaml:"resources,omitempty"`
+	// Tags are resource tags that must all match for a resource to be included.
+	Tags map[string]string `yaml:"tags,omitempty"`
+	// DenyCostComponents are glob patterns for cost component names that are
+	// not allowed, e.g. "*gp2*". Only applicable to resource policies.
+	DenyCostComponents []string `yaml:"deny_cost_components,omitempty"`
+
+	condition *condition
+}
+
+type condition struct {
+	metric string
+	op     string
+	value  decimal.Decimal
+}
+
+// LoadFile reads and validates the policy file at path.
+func LoadFile(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading policy file")
+	}
+
+	var f File
+	err = yaml.Unmarshal([]byte(os.ExpandEnv(string(content))), &f)
+	if err != nil {
+		return nil, errors.New("Error parsing policy file YAML: " + strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+
+	if !checkVersion(f.Version) {
+		return nil, fmt.Errorf("Invalid policy file version '%s'. Supported versions are %s ≤ x ≤ %s", f.Version, minPolicyFileVersion, maxPolicyFileVersion)
+	}
+
+	err = f.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (f *File) validate() error {
+	if len(f.Policies) == 0 {
+		return errors.New("Policy file is invalid: no policies specified")
+	}
+
+	msgs := make([]string, 0)
+
+	for i, p := range f.Policies {
+		err := p.init()
+		if err != nil {
+			name := p.Name
+			if name == "" {
+				name = fmt.Sprintf("at index %d", i)
+			}
+			msgs = append(msgs, fmt.Sprintf("policy %s: %s", name, err))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("Policy file is invalid:\n\t%s", strings.Join(msgs, "\n\t"))
+	}
+
+	return nil
+}
+
+// init validates the policy and parses its condition.
+func (p *Policy) init() error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if p.Condition == "" && len(p.DenyCostComponents) == 0 {
+		return errors.New("condition or deny_cost_components is required")
+	}
+
+	if len(p.DenyCostComponents) > 0 && len(p.Resources) == 0 {
+		p.Resources = []string{"*"}
+	}
+
+	if len(p.Resources) > 0 && len(p.Tags) > 0 {
+		return errors.New("resources and tags cannot be used together")
+	}
+
+	if p.Condition == "" {
+		return nil
+	}
+
+	c, err := parseCondition(p.Condition)
+	if err != nil {
+		return err
+	}
+
+	if !contains(metricsByScope[p.Scope()], c.metric) {
+		return fmt.Errorf("%s is not a valid %s metric, valid metrics are: %s", c.metric, p.Scope(), strings.Join(metricsByScope[p.Scope()], ", "))
+	}
+
+	p.condition = c
+
+	return nil
+}
+
+// Scope returns the level of the output the policy is evaluated at.
+func (p *Policy) Scope() Scope {
+	switch {
+	case len(p.Resources) > 0:
+		return ScopeResource
+	case len(p.Tags) > 0:
+		return ScopeTag
+	case len(p.Projects) > 0:
+		return ScopeProject
+	default:
+		return ScopeRun
+	}
+}
+
+func parseCondition(s string) (*condition, error) {
+	m := conditionRegex.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid condition '%s', conditions must be in the form '<metric> <operator> <number>', e.g. 'totalMonthlyCost < 500'", s)
+	}
+
+	value, err := decimal.NewFromString(strings.ReplaceAll(m[3], ",", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid number in condition '%s'", s)
+	}
+
+	return &condition{
+		metric: m[1],
+		op:     m[2],
+		value:  value,
+	}, nil
+}
+
+// holds returns true if the actual value satisfies the condition.
+func (c *condition) holds(actual decimal.Decimal) bool {
+	switch c.op {
+	case "<":
+		return actual.LessThan(c.value)
+	case "<=":
+		return actual.LessThanOrEqual(c.value)
+	case ">":
+		return actual.GreaterThan(c.value)
+	case ">=":
+		return actual.GreaterThanOrEqual(c.value)
+	case "==":
+		return actual.Equal(c.value)
+	case "!=":
+		return !actual.Equal(c.value)
+	}
+
+	return false
+}
+
+// matchGlob matches s against a glob pattern where * matches any characters.
+// Other characters are matched literally since resource addresses often
+// contain brackets.
+func matchGlob(pattern string, s string) bool {
+	re := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	ok, _ := regexp.MatchString(re, s)
+	return ok
+}
+
+func matchAnyGlob(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if matchGlob(p, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkVersion(v string) bool {
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return semver.Compare(v, "v"+minPolicyFileVersion) >= 0 && semver.Compare(v, "v"+maxPolicyFileVersion) <= 0
+}
+
+func contains(arr []string, e string) bool {
+	for _, a := range arr {
+		if a == e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,242 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+func writePolicyFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policies.yml")
+	err := os.WriteFile(path, []byte(content), 0600)
+	require.NoError(t, err)
+
+	return path
+}
+
+func decimalPtr(f float64) *decimal.Decimal {
+	d := decimal.NewFromFloat(f)
+	return &d
+}
+
+func testOutput() output.Root {
+	return output.Root{
+		Currency:             "USD",
+		TotalMonthlyCost:     decimalPtr(1600),
+		PastTotalMonthlyCost: decimalPtr(1000),
+		DiffTotalMonthlyCost: decimalPtr(600),
+		Projects: []output.Project{
+			{
+				Name: "infracost/app",
+				Breakdown: &output.Breakdown{
+					TotalMonthlyCost: decimalPtr(1400),
+					Resources: []output.Resource{
+						{
+							Name:        "aws_instance.web[0]",
+							Tags:        map[string]string{"team": "payments"},
+							MonthlyCost: decimalPtr(1200),
+							SubResources: []output.Resource{
+								{
+									Name:        "root_block_device",
+									MonthlyCost: decimalPtr(5),
+									CostComponents: []output.CostComponent{
+										{Name: "Storage (general purpose SSD, gp2)"},
+									},
+								},
+							},
+						},
+						{
+							Name:        "aws_ebs_volume.data",
+							Tags:        map[string]string{"team": "search"},
+							MonthlyCost: decimalPtr(200),
+							CostComponents: []output.CostComponent{
+								{Name: "Storage (general purpose SSD, gp3)"},
+							},
+						},
+					},
+				},
+				PastBreakdown: &output.Breakdown{
+					TotalMonthlyCost: decimalPtr(1000),
+					Resources: []output.Resource{
+						{
+							Name:        "aws_instance.web[0]",
+							Tags:        map[string]string{"team": "payments"},
+							MonthlyCost: decimalPtr(1000),
+						},
+					},
+				},
+				Diff: &output.Breakdown{
+					TotalMonthlyCost: decimalPtr(400),
+					Resources: []output.Resource{
+						{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(200)},
+						{Name: "aws_ebs_volume.data", MonthlyCost: decimalPtr(200)},
+					},
+				},
+			},
+			{
+				Name: "infracost/db",
+				Breakdown: &output.Breakdown{
+					TotalMonthlyCost: decimalPtr(200),
+				},
+			},
+		},
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writePolicyFile(t, `
+version: 0.1
+policies:
+  - name: Cost increase
+    condition: diffTotalMonthlyCost < $1,000
+  - name: No gp2
+    deny_cost_components: ["*gp2*"]
+  - name: Payments budget
+    tags:
+      team: payments
+    condition: totalMonthlyCost <= 1000
+`)
+
+	f, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Len(t, f.Policies, 3)
+
+	assert.Equal(t, ScopeRun, f.Policies[0].Scope())
+	assert.Equal(t, "1000", f.Policies[0].condition.value.String())
+	assert.Equal(t, ScopeResource, f.Policies[1].Scope())
+	assert.Equal(t, []string{"*"}, f.Policies[1].Resources)
+	assert.Equal(t, ScopeTag, f.Policies[2].Scope())
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "invalid version",
+			content:  "version: 9.9\npolicies:\n  - name: a\n    condition: totalMonthlyCost < 1\n",
+			expected: "Invalid policy file version '9.9'",
+		},
+		{
+			name:     "no policies",
+			content:  "version: 0.1\n",
+			expected: "no policies specified",
+		},
+		{
+			name:     "missing name",
+			content:  "version: 0.1\npolicies:\n  - condition: totalMonthlyCost < 1\n",
+			expected: "policy at index 0: name is required",
+		},
+		{
+			name:     "invalid condition",
+			content:  "version: 0.1\npolicies:\n  - name: a\n    condition: totalMonthlyCost is small\n",
+			expected: "policy a: invalid condition 'totalMonthlyCost is small'",
+		},
+		{
+			name:     "invalid metric for scope",
+			content:  "version: 0.1\npolicies:\n  - name: a\n    resources: ['*']\n    condition: totalMonthlyCost < 1\n",
+			expected: "policy a: totalMonthlyCost is not a valid resource metric",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writePolicyFile(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	path := writePolicyFile(t, `
+version: 0.1
+policies:
+  - name: Cost increase
+    condition: diffTotalMonthlyCost < 500
+  - name: Project budget
+    projects: ["infracost/*"]
+    condition: totalMonthlyCost < 1000
+  - name: Resource limit
+    resources: ["*"]
+    condition: monthlyCost <= 1000
+  - name: Resource increase
+    resources: ["aws_instance.*"]
+    condition: diffMonthlyCost < 500
+  - name: No gp2
+    deny_cost_components: ["*gp2*"]
+  - name: Payments budget
+    tags:
+      team: payments
+    condition: diffTotalMonthlyCost < 100
+`)
+
+	f, err := LoadFile(path)
+	require.NoError(t, err)
+
+	results := Evaluate(f, testOutput())
+	require.Len(t, results, 6)
+
+	assert.Equal(t, []Violation{
+		{Message: "diffTotalMonthlyCost is $600.00, must be < $500.00"},
+	}, results[0].Violations)
+
+	assert.Equal(t, []Violation{
+		{Project: "infracost/app", Message: "totalMonthlyCost is $1,400.00, must be < $1,000.00"},
+	}, results[1].Violations)
+
+	assert.Equal(t, []Violation{
+		{Project: "infracost/app", Address: "aws_instance.web[0]", Message: "monthlyCost is $1,200.00, must be <= $1,000.00"},
+	}, results[2].Violations)
+
+	assert.True(t, results[3].Passed())
+
+	assert.Equal(t, []Violation{
+		{Project: "infracost/app", Address: "aws_instance.web[0]", Message: "root_block_device Storage (general purpose SSD, gp2) is not allowed"},
+	}, results[4].Violations)
+
+	assert.Equal(t, []Violation{
+		{Address: "team=payments", Message: "diffTotalMonthlyCost is $200.00, must be < $100.00"},
+	}, results[5].Violations)
+
+	assert.Equal(t, 5, results.ViolationCount())
+}
+
+func TestMatchGlob(t *testing.T) {
+	assert.True(t, matchGlob("*", "aws_instance.web[0]"))
+	assert.True(t, matchGlob("aws_instance.web[0]", "aws_instance.web[0]"))
+	assert.True(t, matchGlob("module.*.aws_instance.*", "module.app.aws_instance.web"))
+	assert.False(t, matchGlob("aws_instance.web[0]", "aws_instance.web0"))
+	assert.False(t, matchGlob("aws_instance.*", "aws_ebs_volume.data"))
+}
+
+func TestToMarkdown(t *testing.T) {
+	results := Results{
+		{Policy: &Policy{Name: "Cost increase"}},
+		{
+			Policy: &Policy{Name: "No gp2"},
+			Violations: []Violation{
+				{Project: "infracost/app", Address: "aws_instance.web", Message: "Storage (gp2) is not allowed"},
+			},
+		},
+	}
+
+	expected := "## Infracost cost policies\n\n" +
+		"❌ 1 cost policy violation found.\n\n" +
+		"| Policy | Result | Details |\n" +
+		"| ------ | ------ | ------- |\n" +
+		"| Cost increase | ✅ Passed | |\n" +
+		"| No gp2 | ❌ Failed | `infracost/app` `aws_instance.web`: Storage (gp2) is not allowed |\n"
+
+	assert.Equal(t, expected, string(ToMarkdown(results)))
+}
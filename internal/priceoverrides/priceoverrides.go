@@ -0,0 +1,298 @@
+// Package priceoverrides applies negotiated discounts and custom prices, such
+// as the prices from an enterprise agreement, to the prices from the Cloud
+// Pricing API. The original price is kept as the list price so outputs can
+// show both.
+package priceoverrides
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v2"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	minFileVersion = "0.1"
+	maxFileVersion = "0.1"
+)
+
+// File is a price overrides file. It is referenced from the config file using
+// the price_overrides_file key or with the --price-overrides-file flag.
+//
+// The price of a cost component is set by the first of these that matches:
+//
+//   - an override with the price hash of the price.
+//   - an override with the SKU of the product, and the unit of the price if
+//     the override has a unit.
+//   - the discount with the most matching product fields. Discounts with the
+//     same number of fields are matched in the order they are listed.
+//   - the global discount.
+type File struct {
+	Version string `yaml:"version"`
+	// DiscountPercent is the global discount applied to all prices that
+	// don't match an override or discount.
+	DiscountPercent float64     `yaml:"discount_percent,omitempty"`
+	Discounts       []*Discount `yaml:"discounts,omitempty"`
+	Overrides       []*Override `yaml:"overrides,omitempty"`
+}
+
+// Discount is a percentage discount for the prices of the products that match
+// all of the fields that are set, e.g. all AmazonEC2 prices in us-east-1.
+type Discount struct {
+	VendorName      string  `yaml:"vendor_name,omitempty"`
+	Service         string  `yaml:"service,omitempty"`
+	ProductFamily   string  `yaml:"product_family,omitempty"`
+	Region          string  `yaml:"region,omitempty"`
+	DiscountPercent float64 `yaml:"discount_percent"`
+}
+
+// Override is an absolute price for the price with the price hash, or for the
+// product with the SKU. The price is in the currency of the run.
+//
+// The prices of a product can have different units, e.g. reserved instances
+// have an hourly price and an upfront price with the same SKU, so a SKU
+// override only applies to the prices with its unit if it has one. SKU
+// overrides without a unit don't apply to upfront prices.
+type Override struct {
+	PriceHash string  `yaml:"price_hash,omitempty"`
+	SKU       string  `yaml:"sku,omitempty"`
+	Unit      string  `yaml:"unit,omitempty"`
+	Price     float64 `yaml:"price"`
+}
+
+// LoadFile reads and validates the price overrides file at path.
+func LoadFile(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading price overrides file")
+	}
+
+	var f File
+	err = yaml.Unmarshal([]byte(os.ExpandEnv(string(content))), &f)
+	if err != nil {
+		return nil, errors.New("Error parsing price overrides file YAML: " + strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+
+	if !checkVersion(f.Version) {
+		return nil, fmt.Errorf("Invalid price overrides file version '%s'. Supported versions are %s ≤ x ≤ %s", f.Version, minFileVersion, maxFileVersion)
+	}
+
+	err = f.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (f *File) validate() error {
+	msgs := make([]string, 0)
+
+	if !validPercent(f.DiscountPercent) {
+		msgs = append(msgs, "discount_percent must be between 0 and 100")
+	}
+
+	for i, d := range f.Discounts {
+		if d == nil {
+			continue
+		}
+
+		if d.VendorName == "" && d.Service == "" && d.ProductFamily == "" && d.Region == "" {
+			msgs = append(msgs, fmt.Sprintf("discount at index %d: at least one of vendor_name, service, product_family or region is required", i))
+		}
+
+		if !validPercent(d.DiscountPercent) {
+			msgs = append(msgs, fmt.Sprintf("discount at index %d: discount_percent must be between 0 and 100", i))
+		}
+	}
+
+	for i, o := range f.Overrides {
+		if o == nil {
+			continue
+		}
+
+		if (o.PriceHash == "") == (o.SKU == "") {
+			msgs = append(msgs, fmt.Sprintf("override at index %d: one of price_hash or sku is required", i))
+		}
+
+		if o.Unit != "" && o.SKU == "" {
+			msgs = append(msgs, fmt.Sprintf("override at index %d: unit can only be used with sku", i))
+		}
+
+		if o.Price < 0 {
+			msgs = append(msgs, fmt.Sprintf("override at index %d: price must not be negative", i))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("Price overrides file is invalid:\n\t%s", strings.Join(msgs, "\n\t"))
+	}
+
+	return nil
+}
+
+// Apply sets the price of the cost component from the first override or
+// discount that matches it, keeping the original price as the list price. The
+// sku is the SKU of the product the price is for. It returns true if the price
+// was changed. Apply does nothing if the file is nil so it can be called
+// whether or not a file is used for the run.
+func (f *File) Apply(c *schema.CostComponent, sku string) bool {
+	if f == nil {
+		return false
+	}
+
+	price, ok := f.overridePrice(c, sku)
+	if !ok {
+		price = discounted(listPrice(c), f.discountPercent(c.ProductFilter))
+	}
+
+	return setPrice(c, price)
+}
+
+// ApplyDiscount sets the price of the cost component from the discount that
+// matches it, ignoring the overrides. It's used for prices that are derived
+// from the price of another product, e.g. spot prices that are a fraction of
+// the on-demand price, since their price hash and SKU are for the other
+// product.
+func (f *File) ApplyDiscount(c *schema.CostComponent) bool {
+	if f == nil {
+		return false
+	}
+
+	return setPrice(c, discounted(listPrice(c), f.discountPercent(c.ProductFilter)))
+}
+
+func listPrice(c *schema.CostComponent) decimal.Decimal {
+	if c.ListPrice() != nil {
+		return *c.ListPrice()
+	}
+
+	return c.Price()
+}
+
+// setPrice sets the price of the cost component if it's different from the
+// list price, keeping the list price.
+func setPrice(c *schema.CostComponent, price decimal.Decimal) bool {
+	lp := listPrice(c)
+	if price.Equal(lp) {
+		return false
+	}
+
+	c.SetListPrice(lp)
+	c.SetPrice(price)
+
+	return true
+}
+
+func (f *File) overridePrice(c *schema.CostComponent, sku string) (decimal.Decimal, bool) {
+	if priceHash := c.PriceHash(); priceHash != "" {
+		for _, o := range f.Overrides {
+			if o != nil && o.PriceHash == priceHash {
+				return decimal.NewFromFloat(o.Price), true
+			}
+		}
+	}
+
+	if sku != "" {
+		for _, o := range f.Overrides {
+			if o != nil && o.SKU == sku && o.matchesUnit(c) {
+				return decimal.NewFromFloat(o.Price), true
+			}
+		}
+	}
+
+	return decimal.Zero, false
+}
+
+// matchesUnit returns true if the override's unit matches the unit of the
+// cost component's price. Overrides without a unit match all prices apart from
+// upfront prices.
+func (o *Override) matchesUnit(c *schema.CostComponent) bool {
+	if o.Unit == "" {
+		return c.UpfrontQuantity == nil
+	}
+
+	return c.PriceFilter != nil && c.PriceFilter.Unit != nil && *c.PriceFilter.Unit == o.Unit
+}
+
+// discountPercent returns the discount from the discount with the most
+// matching product fields, or the global discount if none of them match.
+func (f *File) discountPercent(product *schema.ProductFilter) float64 {
+	var match *Discount
+	matchFields := 0
+
+	for _, d := range f.Discounts {
+		if d == nil {
+			continue
+		}
+
+		fields, ok := d.matches(product)
+		if ok && fields > matchFields {
+			match = d
+			matchFields = fields
+		}
+	}
+
+	if match != nil {
+		return match.DiscountPercent
+	}
+
+	return f.DiscountPercent
+}
+
+// matches returns the number of fields the discount matched if all of its
+// fields match the product.
+func (d *Discount) matches(product *schema.ProductFilter) (int, bool) {
+	if product == nil {
+		return 0, false
+	}
+
+	fields := 0
+
+	for _, m := range []struct {
+		want string
+		got  *string
+	}{
+		{d.VendorName, product.VendorName},
+		{d.Service, product.Service},
+		{d.ProductFamily, product.ProductFamily},
+		{d.Region, product.Region},
+	} {
+		if m.want == "" {
+			continue
+		}
+
+		if m.got == nil || *m.got != m.want {
+			return 0, false
+		}
+
+		fields++
+	}
+
+	return fields, true
+}
+
+func discounted(price decimal.Decimal, discountPercent float64) decimal.Decimal {
+	if discountPercent == 0 {
+		return price
+	}
+
+	return price.Mul(decimal.NewFromInt(100).Sub(decimal.NewFromFloat(discountPercent))).Div(decimal.NewFromInt(100))
+}
+
+func validPercent(p float64) bool {
+	return p >= 0 && p <= 100
+}
+
+func checkVersion(v string) bool {
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return semver.Compare(v, "v"+minFileVersion) >= 0 && semver.Compare(v, "v"+maxFileVersion) <= 0
+}
@@ -0,0 +1,225 @@
+package priceoverrides
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "price-overrides.yml")
+	err := os.WriteFile(path, []byte(content), 0600)
+	require.NoError(t, err)
+
+	return path
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func testCostComponent(price string) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:            "Instance usage (Linux/UNIX, on-demand, m5.large)",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr("us-east-1"),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+		},
+	}
+	c.SetPrice(decimal.RequireFromString(price))
+	c.SetPriceHash("abc")
+
+	return c
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeFile(t, `
+version: 0.1
+discount_percent: 5
+discounts:
+  - service: AmazonEC2
+    region: us-east-1
+    discount_percent: 15
+overrides:
+  - price_hash: abc
+    price: 0.08
+  - sku: DQ578CGN99KG6ECF
+    price: 0.075
+`)
+
+	f, err := LoadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5.0, f.DiscountPercent)
+	require.Len(t, f.Discounts, 1)
+	assert.Equal(t, "AmazonEC2", f.Discounts[0].Service)
+	require.Len(t, f.Overrides, 2)
+	assert.Equal(t, 0.075, f.Overrides[1].Price)
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "invalid version",
+			content:  "version: 9.9\n",
+			expected: "Invalid price overrides file version '9.9'",
+		},
+		{
+			name:     "invalid global discount",
+			content:  "version: 0.1\ndiscount_percent: 120\n",
+			expected: "discount_percent must be between 0 and 100",
+		},
+		{
+			name:     "discount without fields",
+			content:  "version: 0.1\ndiscounts:\n  - discount_percent: 10\n",
+			expected: "discount at index 0: at least one of vendor_name, service, product_family or region is required",
+		},
+		{
+			name:     "override with price hash and sku",
+			content:  "version: 0.1\noverrides:\n  - price_hash: abc\n    sku: def\n    price: 1\n",
+			expected: "override at index 0: one of price_hash or sku is required",
+		},
+		{
+			name:     "unit without sku",
+			content:  "version: 0.1\noverrides:\n  - price_hash: abc\n    unit: Hrs\n    price: 1\n",
+			expected: "override at index 0: unit can only be used with sku",
+		},
+		{
+			name:     "negative override",
+			content:  "version: 0.1\noverrides:\n  - sku: def\n    price: -1\n",
+			expected: "override at index 0: price must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeFile(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	f := &File{
+		DiscountPercent: 5,
+		Discounts: []*Discount{
+			{Service: "AmazonEC2", Region: "us-east-1", DiscountPercent: 15},
+			{Service: "AmazonEC2", DiscountPercent: 10},
+			{Service: "AmazonRDS", DiscountPercent: 20},
+		},
+		Overrides: []*Override{
+			{SKU: "SKU1", Price: 0.07},
+			{PriceHash: "abc", Price: 0.08},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		file      *File
+		sku       string
+		region    string
+		priceHash string
+		expected  string
+		changed   bool
+	}{
+		{"no file", nil, "", "us-east-1", "abc", "0.1", false},
+		{"price hash override", f, "SKU1", "us-east-1", "abc", "0.08", true},
+		{"sku override", f, "SKU1", "us-east-1", "def", "0.07", true},
+		{"most specific discount", f, "", "us-east-1", "def", "0.085", true},
+		{"less specific discount", f, "", "eu-west-1", "def", "0.09", true},
+		{"global discount", &File{DiscountPercent: 5}, "", "us-east-1", "def", "0.095", true},
+		{"no discount", &File{}, "", "us-east-1", "def", "0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCostComponent("0.1")
+			c.ProductFilter.Region = strPtr(tt.region)
+			c.SetPriceHash(tt.priceHash)
+
+			changed := tt.file.Apply(c, tt.sku)
+
+			assert.Equal(t, tt.changed, changed)
+			assert.True(t, decimal.RequireFromString(tt.expected).Equal(c.Price()), c.Price().String())
+
+			if tt.changed {
+				require.NotNil(t, c.ListPrice())
+				assert.True(t, decimal.RequireFromString("0.1").Equal(*c.ListPrice()))
+			} else {
+				assert.Nil(t, c.ListPrice())
+			}
+		})
+	}
+}
+
+func TestApplySKUOverrideUnit(t *testing.T) {
+	// Reserved instances have an hourly and an upfront price with the same SKU
+	hourly := func() *schema.CostComponent {
+		c := testCostComponent("0.06")
+		c.PriceFilter = &schema.PriceFilter{Unit: strPtr("Hrs")}
+		c.SetPriceHash("hourly")
+		return c
+	}
+	upfront := func() *schema.CostComponent {
+		c := testCostComponent("500")
+		c.MonthlyQuantity = nil
+		c.UpfrontQuantity = decimalPtr(decimal.NewFromInt(1))
+		c.PriceFilter = &schema.PriceFilter{Unit: strPtr("Quantity")}
+		c.SetPriceHash("upfront")
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		file     *File
+		c        *schema.CostComponent
+		expected string
+	}{
+		{"sku override without unit on hourly price", &File{Overrides: []*Override{{SKU: "SKU1", Price: 0.05}}}, hourly(), "0.05"},
+		{"sku override without unit on upfront price", &File{Overrides: []*Override{{SKU: "SKU1", Price: 0.05}}}, upfront(), "500"},
+		{"sku override with hourly unit on hourly price", &File{Overrides: []*Override{{SKU: "SKU1", Unit: "Hrs", Price: 0.05}}}, hourly(), "0.05"},
+		{"sku override with hourly unit on upfront price", &File{Overrides: []*Override{{SKU: "SKU1", Unit: "Hrs", Price: 0.05}}}, upfront(), "500"},
+		{"sku override with upfront unit on upfront price", &File{Overrides: []*Override{{SKU: "SKU1", Unit: "Quantity", Price: 450}}}, upfront(), "450"},
+		{"sku overrides for each unit", &File{Overrides: []*Override{{SKU: "SKU1", Unit: "Hrs", Price: 0.05}, {SKU: "SKU1", Unit: "Quantity", Price: 450}}}, upfront(), "450"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.file.Apply(tt.c, "SKU1")
+			assert.True(t, decimal.RequireFromString(tt.expected).Equal(tt.c.Price()), tt.c.Price().String())
+		})
+	}
+}
+
+func TestApplyListMonthlyCost(t *testing.T) {
+	c := testCostComponent("0.1")
+	r := &schema.Resource{Name: "aws_instance.web", CostComponents: []*schema.CostComponent{c}}
+
+	(&File{DiscountPercent: 20}).Apply(c, "")
+	r.CalculateCosts()
+
+	assert.Equal(t, "58.4", c.MonthlyCost.String())
+	assert.Equal(t, "73", c.ListMonthlyCost.String())
+	assert.Equal(t, "73", r.ListMonthlyCost.String())
+}
@@ -1,10 +1,10 @@
 package prices
 
 import (
-	"runtime"
+	"fmt"
 
 	"github.com/infracost/infracost/internal/apiclient"
-	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/priceoverrides"
 	"github.com/infracost/infracost/internal/schema"
 
 	"github.com/shopspring/decimal"
@@ -12,77 +12,69 @@ import (
 	"github.com/tidwall/gjson"
 )
 
-func PopulatePrices(ctx *config.RunContext, project *schema.Project) error {
-	resources := project.AllResources()
+// PopulatePrices gets the prices for all the resources in the projects. The
+// resources from all the projects are priced together so identical price
+// queries are only run once. The overrides are applied to the prices if they
+// are not nil.
+func PopulatePrices(c *apiclient.PricingAPIClient, projects []*schema.Project, overrides *priceoverrides.File) error {
+	resources := make([]*schema.Resource, 0)
+	for _, project := range projects {
+		resources = append(resources, project.AllResources()...)
+	}
 
-	c := apiclient.NewPricingAPIClient(ctx)
+	return GetPrices(c, resources, overrides)
+}
 
-	err := GetPricesConcurrent(c, resources)
+func GetPrices(c *apiclient.PricingAPIClient, resources []*schema.Resource, overrides *priceoverrides.File) error {
+	results, err := c.RunQueries(resources)
 	if err != nil {
 		return err
 	}
-	return nil
-}
 
-// GetPricesConcurrent gets the prices of all resources concurrently.
-// Concurrency level is calculated using the following formula:
-// max(min(4, numCPU * 4), 16)
-func GetPricesConcurrent(c *apiclient.PricingAPIClient, resources []*schema.Resource) error {
-	// Set the number of workers
-	numWorkers := 4
-	numCPU := runtime.NumCPU()
-	if numCPU*4 > numWorkers {
-		numWorkers = numCPU * 4
-	}
-	if numWorkers > 16 {
-		numWorkers = 16
-	}
-	numJobs := len(resources)
-	jobs := make(chan *schema.Resource, numJobs)
-	resultErrors := make(chan error, numJobs)
-
-	// Fire up the workers
-	for i := 0; i < numWorkers; i++ {
-		go func(jobs <-chan *schema.Resource, resultErrors chan<- error) {
-			for r := range jobs {
-				err := GetPrices(c, r)
-				resultErrors <- err
-			}
-		}(jobs, resultErrors)
-	}
-
-	// Feed the workers the jobs of getting prices
-	for _, r := range resources {
-		jobs <- r
-	}
+	fallbacks := make([]apiclient.PriceQueryKey, 0)
 
-	// Get the result of the jobs
-	for i := 0; i < numJobs; i++ {
-		err := <-resultErrors
-		if err != nil {
-			return err
+	for _, r := range results {
+		if r.CostComponent.FallbackPriceFilter != nil && !hasPrice(r.Result) {
+			fallbacks = append(fallbacks, r.PriceQueryKey)
+			continue
 		}
-	}
-	return nil
-}
 
-func GetPrices(c *apiclient.PricingAPIClient, r *schema.Resource) error {
-	if r.IsSkipped {
-		return nil
+		setCostComponentPrice(c.Currency, r.Resource, r.CostComponent, r.Result)
+		overrides.Apply(r.CostComponent, productSKU(r.Result))
 	}
 
-	results, err := c.RunQueries(r)
+	fallbackResults, err := c.RunFallbackQueries(fallbacks)
 	if err != nil {
 		return err
 	}
 
-	for _, r := range results {
-		setCostComponentPrice(c.Currency, r.Resource, r.CostComponent, r.Result)
+	for _, r := range fallbackResults {
+		log.Debugf("No prices found for %s %s, using the fallback price", r.Resource.Name, r.CostComponent.Name)
+		setFallbackCostComponentPrice(c.Currency, r.Resource, r.CostComponent, r.Result, overrides)
 	}
 
 	return nil
 }
 
+func hasPrice(res gjson.Result) bool {
+	return len(res.Get("data.products.0.prices").Array()) > 0
+}
+
+func productSKU(res gjson.Result) string {
+	return res.Get("data.products.0.sku").String()
+}
+
+// setFallbackCostComponentPrice sets the price of the cost component from the
+// result of its fallback price query, multiplied by the fallback multiplier.
+// The price hash and SKU are for the fallback product, e.g. the on-demand
+// price of a spot instance, so only the discounts of the overrides are
+// applied.
+func setFallbackCostComponentPrice(currency string, r *schema.Resource, c *schema.CostComponent, res gjson.Result, overrides *priceoverrides.File) {
+	setCostComponentPrice(currency, r, c, res)
+	c.SetPrice(c.Price().Mul(c.FallbackPriceMultiplier))
+	overrides.ApplyDiscount(c)
+}
+
 func setCostComponentPrice(currency string, r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
 	var p decimal.Decimal
 
@@ -95,11 +87,13 @@ func setCostComponentPrice(currency string, r *schema.Resource, c *schema.CostCo
 		}
 
 		log.Warnf("No products found for %s %s, using 0.00", r.Name, c.Name)
+		c.AddPriceWarning(schema.PriceWarningNoProducts, "No products found, using 0.00")
 		c.SetPrice(decimal.Zero)
 		return
 	}
 	if len(products) > 1 {
 		log.Warnf("Multiple products found for %s %s, using the first product", r.Name, c.Name)
+		c.AddPriceWarning(schema.PriceWarningMultipleProducts, "Multiple products found, using the first product")
 	}
 
 	prices := products[0].Get("prices").Array()
@@ -111,17 +105,20 @@ func setCostComponentPrice(currency string, r *schema.Resource, c *schema.CostCo
 		}
 
 		log.Warnf("No prices found for %s %s, using 0.00", r.Name, c.Name)
+		c.AddPriceWarning(schema.PriceWarningNoPrices, "No prices found, using 0.00")
 		c.SetPrice(decimal.Zero)
 		return
 	}
 	if len(prices) > 1 {
 		log.Warnf("Multiple prices found for %s %s, using the first price", r.Name, c.Name)
+		c.AddPriceWarning(schema.PriceWarningMultiplePrices, "Multiple prices found, using the first price")
 	}
 
 	var err error
 	p, err = decimal.NewFromString(prices[0].Get(currency).String())
 	if err != nil {
 		log.Warnf("Error converting price to '%v' (using 0.00)  '%v': %s", currency, prices[0].Get(currency).String(), err.Error())
+		c.AddPriceWarning(schema.PriceWarningInvalidPrice, fmt.Sprintf("Invalid %s price '%s', using 0.00", currency, prices[0].Get(currency).String()))
 		c.SetPrice(decimal.Zero)
 		return
 	}
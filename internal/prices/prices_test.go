@@ -0,0 +1,131 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/priceoverrides"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestSetCostComponentPriceWarnings(t *testing.T) {
+	tests := []struct {
+		name          string
+		result        string
+		ignoreMissing bool
+		expectedPrice string
+		expectedTypes []schema.PriceWarningType
+		removed       bool
+	}{
+		{
+			name:          "single price",
+			result:        `{"data": {"products": [{"prices": [{"priceHash": "abc", "USD": "0.1"}]}]}}`,
+			expectedPrice: "0.1",
+		},
+		{
+			name:          "no products",
+			result:        `{"data": {"products": []}}`,
+			expectedPrice: "0",
+			expectedTypes: []schema.PriceWarningType{schema.PriceWarningNoProducts},
+		},
+		{
+			name:          "no products ignored",
+			result:        `{"data": {"products": []}}`,
+			ignoreMissing: true,
+			expectedPrice: "0",
+			removed:       true,
+		},
+		{
+			name:          "multiple products and prices",
+			result:        `{"data": {"products": [{"prices": [{"priceHash": "abc", "USD": "0.1"}, {"priceHash": "def", "USD": "0.2"}]}, {"prices": []}]}}`,
+			expectedPrice: "0.1",
+			expectedTypes: []schema.PriceWarningType{schema.PriceWarningMultipleProducts, schema.PriceWarningMultiplePrices},
+		},
+		{
+			name:          "no prices",
+			result:        `{"data": {"products": [{"prices": []}]}}`,
+			expectedPrice: "0",
+			expectedTypes: []schema.PriceWarningType{schema.PriceWarningNoPrices},
+		},
+		{
+			name:          "invalid price",
+			result:        `{"data": {"products": [{"prices": [{"priceHash": "abc", "USD": "n/a"}]}]}}`,
+			expectedPrice: "0",
+			expectedTypes: []schema.PriceWarningType{schema.PriceWarningInvalidPrice},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &schema.CostComponent{Name: "Instance usage", IgnoreIfMissingPrice: tt.ignoreMissing}
+			r := &schema.Resource{Name: "aws_instance.web", CostComponents: []*schema.CostComponent{c}}
+
+			setCostComponentPrice("USD", r, c, gjson.Parse(tt.result))
+
+			assert.True(t, decimal.RequireFromString(tt.expectedPrice).Equal(c.Price()))
+			assert.Equal(t, tt.removed, len(r.CostComponents) == 0)
+
+			types := make([]schema.PriceWarningType, 0, len(c.PriceWarnings()))
+			for _, w := range c.PriceWarnings() {
+				types = append(types, w.Type)
+			}
+			assert.ElementsMatch(t, tt.expectedTypes, types)
+		})
+	}
+}
+
+func TestSetFallbackCostComponentPrice(t *testing.T) {
+	c := &schema.CostComponent{
+		Name:                    "Instance usage (Linux/UNIX, spot, m5.large)",
+		FallbackPriceFilter:     &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+		FallbackPriceMultiplier: decimal.RequireFromString("0.3"),
+	}
+	r := &schema.Resource{Name: "aws_spot_instance_request.web", CostComponents: []*schema.CostComponent{c}}
+
+	assert.False(t, hasPrice(gjson.Parse(`{"data": {"products": [{"prices": []}]}}`)))
+	assert.True(t, hasPrice(gjson.Parse(`{"data": {"products": [{"prices": [{"priceHash": "abc", "USD": "0.096"}]}]}}`)))
+
+	setFallbackCostComponentPrice("USD", r, c, gjson.Parse(`{"data": {"products": [{"prices": [{"priceHash": "abc", "USD": "0.096"}]}]}}`), nil)
+
+	assert.True(t, decimal.RequireFromString("0.0288").Equal(c.Price()))
+	assert.Equal(t, "abc", c.PriceHash())
+	assert.Empty(t, c.PriceWarnings())
+}
+
+func TestSetFallbackCostComponentPriceOverrides(t *testing.T) {
+	overrides := &priceoverrides.File{
+		DiscountPercent: 10,
+		Overrides: []*priceoverrides.Override{
+			{PriceHash: "abc", Price: 0.08},
+			{SKU: "DQ578CGN99KG6ECF", Price: 0.075},
+		},
+	}
+
+	c := &schema.CostComponent{
+		Name:                    "Instance usage (Linux/UNIX, spot, m5.large)",
+		FallbackPriceFilter:     &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+		FallbackPriceMultiplier: decimal.RequireFromString("0.3"),
+	}
+	r := &schema.Resource{Name: "aws_spot_instance_request.web", CostComponents: []*schema.CostComponent{c}}
+
+	setFallbackCostComponentPrice("USD", r, c, gjson.Parse(`{"data": {"products": [{"sku": "DQ578CGN99KG6ECF", "prices": [{"priceHash": "abc", "USD": "0.096"}]}]}}`), overrides)
+
+	// The overrides for the on-demand price hash and SKU don't replace the
+	// spot price, only the global discount is applied
+	assert.True(t, decimal.RequireFromString("0.02592").Equal(c.Price()), c.Price().String())
+	require.NotNil(t, c.ListPrice())
+	assert.True(t, decimal.RequireFromString("0.0288").Equal(*c.ListPrice()), c.ListPrice().String())
+}
+
+func TestProductSKU(t *testing.T) {
+	assert.Equal(t, "DQ578CGN99KG6ECF", productSKU(gjson.Parse(`{"data": {"products": [{"sku": "DQ578CGN99KG6ECF", "prices": []}]}}`)))
+	assert.Equal(t, "", productSKU(gjson.Parse(`{"data": {"products": []}}`)))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetAPIGatewayRestAPIRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ApiGateway::RestApi",
+		RFunc: NewAPIGatewayRestAPI,
+	}
+}
+
+func NewAPIGatewayRestAPI(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.APIGatewayRestAPI{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
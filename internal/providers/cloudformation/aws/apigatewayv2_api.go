@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/apigatewayv2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetAPIGatewayv2ApiRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ApiGatewayV2::Api",
+		RFunc: NewAPIGatewayv2API,
+	}
+}
+
+func NewAPIGatewayv2API(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*apigatewayv2.Api)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	r := &aws.APIgatewayv2API{Address: strPtr(d.Address), ProtocolType: strPtr(cfr.ProtocolType), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetCloudwatchLogGroupItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::Logs::LogGroup",
+		RFunc: NewCloudwatchLogGroup,
+	}
+}
+
+func NewCloudwatchLogGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.CloudwatchLogGroup{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"strconv"
+
+	"github.com/awslabs/goformation/v4/cloudformation/rds"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetDBInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::RDS::DBInstance",
+		RFunc: NewDBInstance,
+	}
+}
+
+func NewDBInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*rds.DBInstance)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	region := d.Get("region").String()
+
+	// Instances in an Aurora cluster use the same resource type as standalone
+	// instances but are priced like Terraform's aws_rds_cluster_instance
+	if cfr.DBClusterIdentifier != "" {
+		r := &aws.RdsClusterInstance{Address: strPtr(d.Address), Region: strPtr(region), InstanceClass: strPtr(cfr.DBInstanceClass), Engine: strPtr(cfr.Engine)}
+		r.PopulateUsage(u)
+		return r.BuildResource()
+	}
+
+	r := &aws.DbInstance{Address: strPtr(d.Address), Region: strPtr(region), InstanceClass: strPtr(cfr.DBInstanceClass), Engine: strPtr(cfr.Engine), MultiAz: boolPtr(cfr.MultiAZ), LicenseModel: strPtr(cfr.LicenseModel)}
+	if cfr.BackupRetentionPeriod > 0 {
+		r.BackupRetentionPeriod = strPtr(strconv.FormatInt(int64(cfr.BackupRetentionPeriod), 10))
+	}
+	if cfr.StorageType != "" {
+		r.StorageType = strPtr(cfr.StorageType)
+	}
+	if cfr.Iops > 0 {
+		r.Iops = floatPtr(float64(cfr.Iops))
+	}
+	if allocatedStorage, err := strconv.ParseFloat(cfr.AllocatedStorage, 64); err == nil {
+		r.AllocatedStorage = floatPtr(allocatedStorage)
+	}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/ec2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetEBSVolumeRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::EC2::Volume",
+		RFunc: NewEBSVolume,
+	}
+}
+
+func NewEBSVolume(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*ec2.Volume)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	a := &aws.EBSVolume{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+		Type:    cfr.VolumeType,
+		IOPS:    int64(cfr.Iops),
+	}
+
+	if cfr.Size > 0 {
+		a.Size = intPtr(int64(cfr.Size))
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
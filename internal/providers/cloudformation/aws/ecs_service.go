@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/ecs"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetECSServiceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "AWS::ECS::Service",
+		RFunc:               NewECSService,
+		ReferenceAttributes: []string{"TaskDefinition"},
+	}
+}
+
+func NewECSService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*ecs.Service)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	// CloudFormation runs one task by default
+	desiredCount := int64(1)
+	if cfr.DesiredCount > 0 {
+		desiredCount = int64(cfr.DesiredCount)
+	}
+
+	a := &aws.ECSService{
+		Address:      d.Address,
+		Region:       d.Get("region").String(),
+		LaunchType:   cfr.LaunchType,
+		DesiredCount: desiredCount,
+	}
+
+	refs := d.References("TaskDefinition")
+	if len(refs) > 0 {
+		if taskDefinition, ok := refs[0].CFResource.(*ecs.TaskDefinition); ok {
+			a.Memory = taskDefinition.Memory
+			a.CPU = taskDefinition.Cpu
+			if len(taskDefinition.InferenceAccelerators) > 0 {
+				a.InferenceAcceleratorDeviceType = taskDefinition.InferenceAccelerators[0].DeviceType
+			}
+		}
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
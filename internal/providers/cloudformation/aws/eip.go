@@ -0,0 +1,31 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/ec2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetEIPRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::EC2::EIP",
+		RFunc: NewEip,
+	}
+}
+
+func NewEip(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*ec2.EIP)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	r := &aws.Eip{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String())}
+	if cfr.InstanceId != "" {
+		r.Instance = strPtr(cfr.InstanceId)
+	}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
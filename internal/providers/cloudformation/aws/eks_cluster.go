@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetNewEKSClusterItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::EKS::Cluster",
+		RFunc: NewEKSCluster,
+	}
+}
+
+func NewEKSCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.EksCluster{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/awslabs/goformation/v4/cloudformation/eks"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetNewEKSNodeGroupItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::EKS::Nodegroup",
+		Notes: []string{"Launch templates are not yet supported, the instance type of the node group is used."},
+		RFunc: NewEKSNodeGroup,
+	}
+}
+
+func NewEKSNodeGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*eks.Nodegroup)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	var instanceCount int64
+	if cfr.ScalingConfig != nil {
+		instanceCount = int64(cfr.ScalingConfig.DesiredSize)
+	}
+
+	diskSize := int64(20)
+	if cfr.DiskSize > 0 {
+		diskSize = int64(cfr.DiskSize)
+	}
+
+	instanceType := "t3.medium"
+	if len(cfr.InstanceTypes) > 0 {
+		instanceType = strings.ToLower(cfr.InstanceTypes[0])
+	}
+
+	a := &aws.EKSNodeGroup{
+		Address:        d.Address,
+		Region:         d.Get("region").String(),
+		Name:           cfr.NodegroupName,
+		ClusterName:    cfr.ClusterName,
+		InstanceType:   instanceType,
+		PurchaseOption: strings.ToLower(cfr.CapacityType),
+		InstanceCount:  intPtr(instanceCount),
+		DiskSize:       diskSize,
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
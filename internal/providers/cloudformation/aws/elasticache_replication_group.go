@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/elasticache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetElastiCacheReplicationGroupItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::ElastiCache::ReplicationGroup",
+		RFunc: NewElastiCacheReplicationGroup,
+	}
+}
+
+func NewElastiCacheReplicationGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*elasticache.ReplicationGroup)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	cacheEngine := "redis"
+	if cfr.Engine != "" {
+		cacheEngine = cfr.Engine
+	}
+
+	// Cluster mode is enabled when the number of node groups (shards) is set
+	var cacheNodes int64
+	if cfr.NumNodeGroups > 0 {
+		nodeGroups := int64(cfr.NumNodeGroups)
+		cacheNodes = nodeGroups*int64(cfr.ReplicasPerNodeGroup) + nodeGroups
+	} else {
+		cacheNodes = int64(cfr.NumCacheClusters)
+	}
+
+	a := &aws.ElastiCache{
+		Address:                d.Address,
+		Region:                 d.Get("region").String(),
+		NodeType:               cfr.CacheNodeType,
+		Engine:                 cacheEngine,
+		CacheNodes:             cacheNodes,
+		SnapshotRetentionLimit: int64(cfr.SnapshotRetentionLimit),
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/awslabs/goformation/v4/cloudformation/ec2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// rootDeviceNames are the root device names used by the Amazon Linux, Ubuntu
+// and Windows AMIs. The root device can't be known without looking up the AMI.
+var rootDeviceNames = []string{"/dev/xvda", "/dev/sda1"}
+
+func GetInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "AWS::EC2::Instance",
+		Notes: []string{
+			"Costs associated with marketplace AMIs are not supported.",
+			"For non-standard Linux AMIs such as Windows and RHEL, the operating system should be specified in usage file.",
+			"EC2 detailed monitoring assumes the standard 7 metrics and the lowest tier of prices for CloudWatch.",
+			"If a root volume is not specified then an 8Gi gp2 volume is assumed.",
+		},
+		RFunc: NewInstance,
+	}
+}
+
+func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*ec2.Instance)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	region := d.Get("region").String()
+
+	a := &aws.Instance{
+		Address:          d.Address,
+		Region:           region,
+		Tenancy:          cfr.Tenancy,
+		PurchaseOption:   "on_demand",
+		AMI:              cfr.ImageId,
+		InstanceType:     cfr.InstanceType,
+		EBSOptimized:     cfr.EbsOptimized,
+		EnableMonitoring: cfr.Monitoring,
+	}
+
+	if cfr.CreditSpecification != nil {
+		a.CPUCredits = cfr.CreditSpecification.CPUCredits
+	}
+
+	if len(cfr.ElasticInferenceAccelerators) > 0 {
+		a.ElasticInferenceAcceleratorType = strPtr(cfr.ElasticInferenceAccelerators[0].Type)
+	}
+
+	a.RootBlockDevice = &aws.EBSVolume{
+		Address: "root_block_device",
+		Region:  region,
+	}
+
+	for i, mapping := range cfr.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+
+		ebsVolume := newInstanceEBSVolume(fmt.Sprintf("ebs_block_device[%d]", i), region, mapping.Ebs)
+
+		if isRootDevice(mapping.DeviceName) {
+			ebsVolume.Address = "root_block_device"
+			a.RootBlockDevice = ebsVolume
+			continue
+		}
+
+		a.EBSBlockDevices = append(a.EBSBlockDevices, ebsVolume)
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
+
+func newInstanceEBSVolume(address string, region string, ebs *ec2.Instance_Ebs) *aws.EBSVolume {
+	v := &aws.EBSVolume{
+		Address: address,
+		Region:  region,
+		Type:    ebs.VolumeType,
+		IOPS:    int64(ebs.Iops),
+	}
+
+	if ebs.VolumeSize > 0 {
+		v.Size = intPtr(int64(ebs.VolumeSize))
+	}
+
+	return v
+}
+
+func isRootDevice(deviceName string) bool {
+	for _, n := range rootDeviceNames {
+		if deviceName == n {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/kms"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetNewKMSKeyRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::KMS::Key",
+		RFunc: NewKMSKey,
+	}
+}
+
+func NewKMSKey(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*kms.Key)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	r := &aws.KmsKey{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String()), CustomerMasterKeySpec: strPtr(cfr.KeySpec)}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
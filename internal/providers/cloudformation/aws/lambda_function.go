@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/lambda"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetLambdaFunctionRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::Lambda::Function",
+		Notes: []string{"Provisioned concurrency is not yet supported."},
+		RFunc: NewLambdaFunction,
+	}
+}
+
+func NewLambdaFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*lambda.Function)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	memorySize := int64(128)
+	if cfr.MemorySize > 0 {
+		memorySize = int64(cfr.MemorySize)
+	}
+
+	a := &aws.LambdaFunction{
+		Address:    d.Address,
+		Region:     d.Get("region").String(),
+		Name:       cfr.FunctionName,
+		MemorySize: memorySize,
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/rds"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetRDSClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::RDS::DBCluster",
+		RFunc: NewRDSCluster,
+	}
+}
+
+func NewRDSCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*rds.DBCluster)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	// CloudFormation keeps backups for 1 day by default
+	backupRetentionPeriod := int64(1)
+	if cfr.BackupRetentionPeriod > 0 {
+		backupRetentionPeriod = int64(cfr.BackupRetentionPeriod)
+	}
+
+	r := &aws.RdsCluster{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String()), Engine: strPtr(cfr.Engine), BackupRetentionPeriod: intPtr(backupRetentionPeriod)}
+	if cfr.EngineMode != "" {
+		r.EngineMode = strPtr(cfr.EngineMode)
+	}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
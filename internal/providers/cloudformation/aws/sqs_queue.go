@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/sqs"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetSQSQueueRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::SQS::Queue",
+		RFunc: NewSQSQueue,
+	}
+}
+
+func NewSQSQueue(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*sqs.Queue)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	r := &aws.SqsQueue{Address: strPtr(d.Address), FifoQueue: boolPtr(cfr.FifoQueue), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,263 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+const (
+	cdkManifestFile      = "manifest.json"
+	cdkAppConfigFile     = "cdk.json"
+	cdkDefaultOutputDir  = "cdk.out"
+	cdkStackArtifact     = "aws:cloudformation:stack"
+	cdkAssemblyArtifact  = "cdk:cloud-assembly"
+	cdkUnknownRegion     = "unknown-region"
+	cdkUnknownAccount    = "unknown-account"
+	cdkEnvironmentPrefix = "aws://"
+	cdkAssetPathMetadata = "aws:asset:path"
+)
+
+// cloudAssemblyManifest is the manifest.json file of a CDK cloud assembly,
+// i.e. the cdk.out directory created by `cdk synth`.
+type cloudAssemblyManifest struct {
+	Version   string                           `json:"version"`
+	Artifacts map[string]cloudAssemblyArtifact `json:"artifacts"`
+}
+
+type cloudAssemblyArtifact struct {
+	Type        string `json:"type"`
+	Environment string `json:"environment"`
+	DisplayName string `json:"displayName"`
+	Properties  struct {
+		TemplateFile  string            `json:"templateFile"`
+		StackName     string            `json:"stackName"`
+		Parameters    map[string]string `json:"parameters"`
+		DirectoryName string            `json:"directoryName"`
+		DisplayName   string            `json:"displayName"`
+	} `json:"properties"`
+}
+
+// cdkStack is a stack in a CDK cloud assembly.
+type cdkStack struct {
+	// Name is the display name of the stack, which includes the stages and
+	// parent stacks it is nested in, e.g. Prod/MyStack.
+	Name string
+	// StackName is the name the stack is deployed with.
+	StackName    string
+	TemplatePath string
+	Region       string
+	Account      string
+	Parameters   map[string]string
+}
+
+// IsCDKProject returns true if the path is a CDK cloud assembly directory, or
+// a CDK app directory which has a cdk.json file.
+func IsCDKProject(path string) bool {
+	if isCloudAssembly(path) {
+		return true
+	}
+
+	return config.FileExists(filepath.Join(path, cdkAppConfigFile))
+}
+
+func isCloudAssembly(path string) bool {
+	m, err := readCloudAssemblyManifest(path)
+	return err == nil && m.Version != "" && m.Artifacts != nil
+}
+
+// cloudAssemblyDir returns the cloud assembly directory for the path, which is
+// either the cloud assembly itself or a CDK app directory that has been
+// synthesized to the output directory set in its cdk.json file.
+func cloudAssemblyDir(path string) (string, error) {
+	if isCloudAssembly(path) {
+		return path, nil
+	}
+
+	outputDir := cdkDefaultOutputDir
+
+	data, err := os.ReadFile(filepath.Join(path, cdkAppConfigFile))
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading cdk.json file")
+	}
+
+	var appConfig struct {
+		Output string `json:"output"`
+	}
+	if err := json.Unmarshal(data, &appConfig); err != nil {
+		return "", errors.Wrap(err, "Error parsing cdk.json file")
+	}
+
+	if appConfig.Output != "" {
+		outputDir = appConfig.Output
+	}
+
+	dir := filepath.Join(path, outputDir)
+	if !isCloudAssembly(dir) {
+		return "", fmt.Errorf("Could not find the CDK cloud assembly at %s, run `cdk synth` in %s first", dir, path)
+	}
+
+	return dir, nil
+}
+
+func readCloudAssemblyManifest(dir string) (*cloudAssemblyManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, cdkManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var m cloudAssemblyManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// loadCloudAssembly returns the stacks in the cloud assembly, including the
+// stacks of any stages, which are nested cloud assemblies. The stacks are
+// sorted by artifact ID so the projects are always in the same order. The
+// prefix is the path of the stages the cloud assembly is nested in.
+func loadCloudAssembly(dir string, prefix string) ([]*cdkStack, error) {
+	m, err := readCloudAssemblyManifest(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading CDK cloud assembly manifest in %s", dir)
+	}
+
+	ids := make([]string, 0, len(m.Artifacts))
+	for id := range m.Artifacts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var stacks []*cdkStack
+
+	for _, id := range ids {
+		a := m.Artifacts[id]
+
+		switch a.Type {
+		case cdkStackArtifact:
+			// The display name is the construct path of the stack, which
+			// already includes any stages it is in
+			name := a.DisplayName
+			if name == "" {
+				name = prefix + id
+			}
+
+			stackName := a.Properties.StackName
+			if stackName == "" {
+				stackName = id
+			}
+
+			account, region := parseCDKEnvironment(a.Environment)
+
+			stacks = append(stacks, &cdkStack{
+				Name:         name,
+				StackName:    stackName,
+				TemplatePath: filepath.Join(dir, a.Properties.TemplateFile),
+				Region:       region,
+				Account:      account,
+				Parameters:   a.Properties.Parameters,
+			})
+		case cdkAssemblyArtifact:
+			name := a.Properties.DisplayName
+			if name == "" {
+				name = id
+			}
+
+			nested, err := loadCloudAssembly(filepath.Join(dir, a.Properties.DirectoryName), prefix+name+"/")
+			if err != nil {
+				return nil, err
+			}
+
+			stacks = append(stacks, nested...)
+		}
+	}
+
+	return stacks, nil
+}
+
+// parseCDKEnvironment returns the account and region from a stack environment,
+// e.g. aws://123456789012/us-east-1. Environment-agnostic stacks use
+// unknown-account and unknown-region, for which empty values are returned.
+func parseCDKEnvironment(env string) (string, string) {
+	parts := strings.SplitN(strings.TrimPrefix(env, cdkEnvironmentPrefix), "/", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+
+	account, region := parts[0], parts[1]
+	if account == cdkUnknownAccount {
+		account = ""
+	}
+	if region == cdkUnknownRegion {
+		region = ""
+	}
+
+	return account, region
+}
+
+// nestedStacks returns the nested stacks of the stack. CDK nested stacks are
+// AWS::CloudFormation::Stack resources and their templates are assets in the
+// cloud assembly, the path of which is set in the resource metadata.
+func (s *cdkStack) nestedStacks(t *cloudformation.Template) []*cdkStack {
+	names := make([]string, 0, len(t.Resources))
+	for name, r := range t.Resources {
+		if r.AWSCloudFormationType() == "AWS::CloudFormation::Stack" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var stacks []*cdkStack
+
+	for _, name := range names {
+		b, err := json.Marshal(t.Resources[name])
+		if err != nil {
+			log.Debugf("Error marshalling Cloudformation resource: %s", err)
+			continue
+		}
+
+		var r struct {
+			Metadata   map[string]interface{} `json:"Metadata"`
+			Properties struct {
+				Parameters map[string]interface{} `json:"Parameters"`
+			} `json:"Properties"`
+		}
+		if err := json.Unmarshal(b, &r); err != nil {
+			log.Debugf("Error unmarshalling Cloudformation resource: %s", err)
+			continue
+		}
+
+		assetPath, ok := r.Metadata[cdkAssetPathMetadata].(string)
+		if !ok {
+			log.Debugf("Skipping nested stack %s in %s as its template is not a CDK asset", name, s.Name)
+			continue
+		}
+
+		params := make(map[string]string, len(r.Properties.Parameters))
+		for k, v := range r.Properties.Parameters {
+			params[k] = fmt.Sprintf("%v", v)
+		}
+
+		stacks = append(stacks, &cdkStack{
+			Name:         s.Name + "/" + name,
+			StackName:    s.StackName + "-" + name,
+			TemplatePath: filepath.Join(filepath.Dir(s.TemplatePath), assetPath),
+			Region:       s.Region,
+			Account:      s.Account,
+			Parameters:   params,
+		})
+	}
+
+	return stacks
+}
@@ -0,0 +1,187 @@
+package cloudformation
+
+import (
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+)
+
+type CDKProvider struct {
+	ctx  *config.ProjectContext
+	Path string
+}
+
+func NewCDKProvider(ctx *config.ProjectContext) schema.Provider {
+	return &CDKProvider{
+		ctx:  ctx,
+		Path: ctx.ProjectConfig.Path,
+	}
+}
+
+func (p *CDKProvider) Type() string {
+	return "cdk_cloud_assembly"
+}
+
+func (p *CDKProvider) DisplayType() string {
+	return "CDK cloud assembly"
+}
+
+func (p *CDKProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+// cdkStackResources are the resources of a stack in a CDK cloud assembly.
+type cdkStackResources struct {
+	stack     *cdkStack
+	resources []*schema.Resource
+}
+
+// LoadResources returns a project for each stack in the cloud assembly, so
+// each stack has its own breakdown and diff. The stacks are diffed against
+// the stacks with the same name in the previous cloud assembly, e.g. the
+// cdk.out directory of the deployed app, if it's set as the previous
+// template.
+func (p *CDKProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	current, err := p.loadStacks(p.Path, usage)
+	if err != nil {
+		return []*schema.Project{}, err
+	}
+
+	var previous []*cdkStackResources
+
+	previousPath := p.ctx.ProjectConfig.PreviousTemplatePath
+	if previousPath != "" {
+		previous, err = p.loadStacks(previousPath, usage)
+		if err != nil {
+			return []*schema.Project{}, errors.Wrap(err, "Error loading previous CDK cloud assembly")
+		}
+	}
+
+	previousResources := make(map[string][]*schema.Resource, len(previous))
+	for _, s := range previous {
+		previousResources[s.stack.Name] = s.resources
+	}
+
+	projects := make([]*schema.Project, 0, len(current))
+
+	for _, s := range current {
+		project := p.newProject(s.stack)
+		project.Resources = s.resources
+
+		if previousPath == "" {
+			// Without the previous cloud assembly there's nothing to diff
+			project.HasDiff = false
+		} else {
+			project.PastResources = previousResources[s.stack.Name]
+			delete(previousResources, s.stack.Name)
+		}
+
+		projects = append(projects, project)
+	}
+
+	// Stacks that have been removed from the app
+	for _, s := range previous {
+		if resources, ok := previousResources[s.stack.Name]; ok {
+			project := p.newProject(s.stack)
+			project.PastResources = resources
+			projects = append(projects, project)
+		}
+	}
+
+	return projects, nil
+}
+
+// loadStacks returns the resources of each stack in the cloud assembly at the
+// path, including the nested stacks.
+func (p *CDKProvider) loadStacks(path string, usage map[string]*schema.UsageData) ([]*cdkStackResources, error) {
+	dir, err := cloudAssemblyDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks, err := loadCloudAssembly(dir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make([]*cdkStackResources, 0, len(stacks))
+
+	for len(stacks) > 0 {
+		stack := stacks[0]
+		stacks = stacks[1:]
+
+		resources, nested, err := p.loadStack(stack, usage)
+		if err != nil {
+			return loaded, err
+		}
+
+		loaded = append(loaded, &cdkStackResources{stack: stack, resources: resources})
+		stacks = append(nested, stacks...)
+	}
+
+	return loaded, nil
+}
+
+func (p *CDKProvider) newProject(stack *cdkStack) *schema.Project {
+	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	metadata.CDKStack = stack.Name
+	p.AddMetadata(metadata)
+	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	return schema.NewProject(name, metadata)
+}
+
+// loadStack returns the resources of the stack, along with its nested stacks.
+func (p *CDKProvider) loadStack(stack *cdkStack, usage map[string]*schema.UsageData) ([]*schema.Resource, []*cdkStack, error) {
+	raw, _, err := readTemplate(stack.TemplatePath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Error reading template for CDK stack %s", stack.Name)
+	}
+
+	params, err := p.stackParameters(stack)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	region := templateRegion(p.ctx.ProjectConfig, params, stack.Region)
+
+	template, err := resolveTemplate(raw, params, region, stack.StackName)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Error loading template for CDK stack %s", stack.Name)
+	}
+
+	parser := NewParser(p.ctx)
+	_, resources, err := parser.parseTemplate(template, region, usage)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Error parsing template for CDK stack %s", stack.Name)
+	}
+
+	return resources, stack.nestedStacks(template), nil
+}
+
+// stackParameters returns the parameter values of the stack from the cloud
+// assembly, which are overridden by the parameters file and the parameter
+// overrides.
+func (p *CDKProvider) stackParameters(stack *cdkStack) (map[string]string, error) {
+	params := make(map[string]string)
+
+	if stack.Account != "" {
+		params["AWS::AccountId"] = stack.Account
+	}
+
+	for k, v := range stack.Parameters {
+		params[k] = v
+	}
+
+	overrides, err := templateParameters(p.ctx.ProjectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range overrides {
+		params[k] = v
+	}
+
+	return params, nil
+}
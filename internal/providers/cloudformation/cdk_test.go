@@ -0,0 +1,202 @@
+package cloudformation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func writeTestFile(t *testing.T, path string, data string) {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	require.NoError(t, err)
+
+	err = os.WriteFile(path, []byte(data), 0600)
+	require.NoError(t, err)
+}
+
+func TestLoadCloudAssembly(t *testing.T) {
+	appDir := t.TempDir()
+	writeTestFile(t, filepath.Join(appDir, "cdk.json"), `{"app": "npx ts-node bin/app.ts", "output": "build/cdk.out"}`)
+
+	assert.True(t, IsCDKProject(appDir))
+	_, err := cloudAssemblyDir(appDir)
+	assert.Error(t, err)
+
+	dir := filepath.Join(appDir, "build", "cdk.out")
+	writeTestFile(t, filepath.Join(dir, "manifest.json"), `{
+  "version": "21.0.0",
+  "artifacts": {
+    "Tree": {"type": "cdk:tree", "properties": {"file": "tree.json"}},
+    "ApiStack": {
+      "type": "aws:cloudformation:stack",
+      "environment": "aws://123456789012/eu-west-1",
+      "properties": {"templateFile": "ApiStack.template.json", "parameters": {"Env": "prod"}},
+      "displayName": "ApiStack"
+    },
+    "assembly-Prod": {
+      "type": "cdk:cloud-assembly",
+      "properties": {"directoryName": "assembly-Prod", "displayName": "Prod"}
+    }
+  }
+}`)
+	writeTestFile(t, filepath.Join(dir, "assembly-Prod", "manifest.json"), `{
+  "version": "21.0.0",
+  "artifacts": {
+    "ProdDataStack": {
+      "type": "aws:cloudformation:stack",
+      "environment": "aws://unknown-account/unknown-region",
+      "properties": {"templateFile": "ProdDataStack.template.json", "stackName": "Prod-DataStack"},
+      "displayName": "Prod/DataStack"
+    }
+  }
+}`)
+
+	assert.True(t, IsCDKProject(dir))
+	assemblyDir, err := cloudAssemblyDir(appDir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, assemblyDir)
+
+	stacks, err := loadCloudAssembly(assemblyDir, "")
+	require.NoError(t, err)
+	require.Len(t, stacks, 2)
+
+	assert.Equal(t, &cdkStack{
+		Name:         "ApiStack",
+		StackName:    "ApiStack",
+		TemplatePath: filepath.Join(dir, "ApiStack.template.json"),
+		Region:       "eu-west-1",
+		Account:      "123456789012",
+		Parameters:   map[string]string{"Env": "prod"},
+	}, stacks[0])
+
+	assert.Equal(t, &cdkStack{
+		Name:         "Prod/DataStack",
+		StackName:    "Prod-DataStack",
+		TemplatePath: filepath.Join(dir, "assembly-Prod", "ProdDataStack.template.json"),
+	}, stacks[1])
+}
+
+func TestCDKNestedStacks(t *testing.T) {
+	raw, err := decodeTemplate([]byte(`{
+  "Resources": {
+    "NetworkNestedStackResource": {
+      "Type": "AWS::CloudFormation::Stack",
+      "Properties": {
+        "TemplateURL": "https://s3.amazonaws.com/bucket/nested.json",
+        "Parameters": {"Env": {"Ref": "Env"}}
+      },
+      "Metadata": {
+        "aws:cdk:path": "ApiStack/Network.NestedStack/Network.NestedStackResource",
+        "aws:asset:path": "ApiStackNetwork.nested.template.json",
+        "aws:asset:property": "TemplateURL"
+      }
+    },
+    "ExternalStack": {
+      "Type": "AWS::CloudFormation::Stack",
+      "Properties": {"TemplateURL": "https://s3.amazonaws.com/bucket/external.json"}
+    }
+  },
+  "Parameters": {"Env": {"Type": "String", "Default": "dev"}}
+}`))
+	require.NoError(t, err)
+
+	template, err := resolveTemplate(raw, map[string]string{"Env": "prod"}, "eu-west-1", "ApiStack")
+	require.NoError(t, err)
+
+	parent := &cdkStack{
+		Name:         "Prod/ApiStack",
+		StackName:    "Prod-ApiStack",
+		TemplatePath: filepath.Join("cdk.out", "ApiStack.template.json"),
+		Region:       "eu-west-1",
+		Account:      "123456789012",
+	}
+
+	assert.Equal(t, []*cdkStack{
+		{
+			Name:         "Prod/ApiStack/NetworkNestedStackResource",
+			StackName:    "Prod-ApiStack-NetworkNestedStackResource",
+			TemplatePath: filepath.Join("cdk.out", "ApiStackNetwork.nested.template.json"),
+			Region:       "eu-west-1",
+			Account:      "123456789012",
+			Parameters:   map[string]string{"Env": "prod"},
+		},
+	}, parent.nestedStacks(template))
+}
+
+func TestParseCDKEnvironment(t *testing.T) {
+	account, region := parseCDKEnvironment("aws://123456789012/us-west-2")
+	assert.Equal(t, "123456789012", account)
+	assert.Equal(t, "us-west-2", region)
+
+	account, region = parseCDKEnvironment("aws://unknown-account/unknown-region")
+	assert.Empty(t, account)
+	assert.Empty(t, region)
+}
+
+func writeTestCloudAssembly(t *testing.T, dir string, stacks map[string]string) {
+	artifacts := make([]string, 0, len(stacks))
+	for name, template := range stacks {
+		artifacts = append(artifacts, fmt.Sprintf(`"%s": {"type": "aws:cloudformation:stack", "environment": "aws://123456789012/us-east-1", "properties": {"templateFile": "%s.template.json"}, "displayName": "%s"}`, name, name, name))
+		writeTestFile(t, filepath.Join(dir, name+".template.json"), template)
+	}
+	sort.Strings(artifacts)
+
+	writeTestFile(t, filepath.Join(dir, "manifest.json"), fmt.Sprintf(`{"version": "21.0.0", "artifacts": {%s}}`, strings.Join(artifacts, ", ")))
+}
+
+func TestCDKProviderPreviousCloudAssembly(t *testing.T) {
+	logGroup := `{"Type": "AWS::Logs::LogGroup", "Properties": {"RetentionInDays": 7}}`
+
+	dir := filepath.Join(t.TempDir(), "cdk.out")
+	writeTestCloudAssembly(t, dir, map[string]string{
+		"ApiStack":  `{"Resources": {"Logs": ` + logGroup + `, "NewLogs": ` + logGroup + `}}`,
+		"DataStack": `{"Resources": {"Logs": ` + logGroup + `}}`,
+	})
+
+	previousDir := filepath.Join(t.TempDir(), "cdk.out")
+	writeTestCloudAssembly(t, previousDir, map[string]string{
+		"ApiStack": `{"Resources": {"Logs": ` + logGroup + `}}`,
+		"OldStack": `{"Resources": {"Logs": ` + logGroup + `}}`,
+	})
+
+	projectCfg := &config.Project{Path: dir}
+	ctx := config.NewProjectContext(config.EmptyRunContext(), projectCfg)
+
+	projects, err := NewCDKProvider(ctx).LoadResources(map[string]*schema.UsageData{})
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	for _, p := range projects {
+		assert.False(t, p.HasDiff)
+		assert.Nil(t, p.PastResources)
+	}
+
+	projectCfg.PreviousTemplatePath = previousDir
+
+	projects, err = NewCDKProvider(ctx).LoadResources(map[string]*schema.UsageData{})
+	require.NoError(t, err)
+	require.Len(t, projects, 3)
+
+	assert.Equal(t, "ApiStack", projects[0].Metadata.CDKStack)
+	assert.True(t, projects[0].HasDiff)
+	assert.Len(t, projects[0].Resources, 2)
+	assert.Len(t, projects[0].PastResources, 1)
+
+	// New stacks have no past resources
+	assert.Equal(t, "DataStack", projects[1].Metadata.CDKStack)
+	assert.Len(t, projects[1].Resources, 1)
+	assert.Empty(t, projects[1].PastResources)
+
+	// Removed stacks have no current resources
+	assert.Equal(t, "OldStack", projects[2].Metadata.CDKStack)
+	assert.Empty(t, projects[2].Resources)
+	assert.Len(t, projects[2].PastResources, 1)
+}
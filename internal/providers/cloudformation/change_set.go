@@ -0,0 +1,99 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// changeSet is the output of `aws cloudformation describe-change-set`.
+type changeSet struct {
+	ChangeSetName string `json:"ChangeSetName"`
+	Changes       []struct {
+		Type           string `json:"Type"`
+		ResourceChange struct {
+			Action            string `json:"Action"`
+			LogicalResourceID string `json:"LogicalResourceId"`
+			ResourceType      string `json:"ResourceType"`
+		} `json:"ResourceChange"`
+	} `json:"Changes"`
+}
+
+// parseChangeSet returns the change set if the data is a change set JSON
+// description, or nil if it's something else, e.g. a template.
+func parseChangeSet(data []byte) *changeSet {
+	var cs changeSet
+	if err := json.Unmarshal(data, &cs); err != nil {
+		return nil
+	}
+
+	if cs.ChangeSetName == "" || cs.Changes == nil {
+		return nil
+	}
+
+	return &cs
+}
+
+// pastResources returns the resources before the change set is executed,
+// given the resources after it. A change set only describes which resources
+// are changed, not their previous properties, so modified resources are
+// assumed to have the same cost as before and removed resources can't be
+// priced. Use a previous template to diff these, see warning.
+func (cs *changeSet) pastResources(resources []*schema.Resource) []*schema.Resource {
+	added := make(map[string]bool)
+
+	for _, c := range cs.Changes {
+		if c.Type == "Resource" && c.ResourceChange.Action == "Add" {
+			added[c.ResourceChange.LogicalResourceID] = true
+		}
+	}
+
+	past := make([]*schema.Resource, 0, len(resources))
+	for _, r := range resources {
+		if !added[r.Name] {
+			past = append(past, r)
+		}
+	}
+
+	return past
+}
+
+// warning returns a message listing the resources whose cost changes can't be
+// shown because the change set removes or modifies them, or an empty string
+// if there are none.
+func (cs *changeSet) warning() string {
+	var removed, modified string
+
+	for _, c := range cs.Changes {
+		if c.Type != "Resource" {
+			continue
+		}
+
+		rc := c.ResourceChange
+		line := fmt.Sprintf("\n  ∙ %s (%s)", rc.LogicalResourceID, rc.ResourceType)
+
+		switch rc.Action {
+		case "Remove":
+			removed += line
+		case "Modify":
+			modified += line
+		}
+	}
+
+	msg := ""
+	if removed != "" {
+		msg += "The change set removes the following resources, they are not included in the diff:" + removed
+	}
+	if modified != "" {
+		if msg != "" {
+			msg += "\n"
+		}
+		msg += "The change set modifies the following resources, they are assumed to cost the same as before:" + modified
+	}
+	if msg != "" {
+		msg += "\nUse the deployed template as the previous template to diff these resources."
+	}
+
+	return msg
+}
@@ -0,0 +1,44 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParseChangeSet(t *testing.T) {
+	assert.Nil(t, parseChangeSet([]byte(`{"Resources": {}}`)))
+	assert.Nil(t, parseChangeSet([]byte(`Resources: {}`)))
+
+	cs := parseChangeSet([]byte(`{
+  "ChangeSetName": "my-change-set",
+  "Changes": [
+    {"Type": "Resource", "ResourceChange": {"Action": "Add", "LogicalResourceId": "NewTable", "ResourceType": "AWS::DynamoDB::Table"}},
+    {"Type": "Resource", "ResourceChange": {"Action": "Modify", "LogicalResourceId": "Table", "ResourceType": "AWS::DynamoDB::Table"}},
+    {"Type": "Resource", "ResourceChange": {"Action": "Remove", "LogicalResourceId": "OldTable", "ResourceType": "AWS::DynamoDB::Table"}}
+  ]
+}`))
+	require.NotNil(t, cs)
+
+	resources := []*schema.Resource{
+		{Name: "NewTable"},
+		{Name: "Table"},
+		{Name: "Queue"},
+	}
+
+	past := cs.pastResources(resources)
+	require.Len(t, past, 2)
+	assert.Equal(t, "Table", past[0].Name)
+	assert.Equal(t, "Queue", past[1].Name)
+
+	assert.Equal(t, `The change set removes the following resources, they are not included in the diff:
+  ∙ OldTable (AWS::DynamoDB::Table)
+The change set modifies the following resources, they are assumed to cost the same as before:
+  ∙ Table (AWS::DynamoDB::Table)
+Use the deployed template as the previous template to diff these resources.`, cs.warning())
+
+	assert.Equal(t, "", (&changeSet{}).warning())
+}
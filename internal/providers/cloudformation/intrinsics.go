@@ -0,0 +1,647 @@
+package cloudformation
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultAccountID = "123456789012"
+
+// noValue is the result of a Ref to AWS::NoValue, which removes the property
+// it's used for.
+type noValue struct{}
+
+var subVariableRegexp = regexp.MustCompile(`\$\{([^!}][^}]*)\}`)
+
+// templateResolver evaluates the parameters, mappings, conditions and the
+// intrinsic functions in a template that can be resolved before the stack is
+// deployed. Intrinsic functions that need a deployed stack, e.g. Fn::GetAtt,
+// are removed so the properties they're used for are priced with their
+// defaults.
+type templateResolver struct {
+	template   map[string]interface{}
+	parameters map[string]interface{}
+	mappings   map[string]interface{}
+	conditions map[string]interface{}
+
+	evaluated  map[string]bool
+	evaluating map[string]bool
+}
+
+func newTemplateResolver(template map[string]interface{}, params map[string]string, region string, stackName string) *templateResolver {
+	r := &templateResolver{
+		template:   template,
+		parameters: pseudoParameters(region, stackName),
+		mappings:   mapValue(template["Mappings"]),
+		conditions: mapValue(template["Conditions"]),
+		evaluated:  make(map[string]bool),
+		evaluating: make(map[string]bool),
+	}
+
+	// The string pseudo parameters can be set like other parameters, e.g.
+	// AWS::AccountId for stacks with an explicit environment
+	for name, value := range params {
+		if _, ok := r.parameters[name].(string); ok {
+			r.parameters[name] = value
+		}
+	}
+
+	for name, def := range mapValue(template["Parameters"]) {
+		defMap := mapValue(def)
+
+		value, ok := params[name]
+		if !ok {
+			d, hasDefault := defMap["Default"]
+			if !hasDefault {
+				log.Warnf("No value for Cloudformation parameter %s, set it with --parameter-overrides or --parameters-file", name)
+				continue
+			}
+			value = fmt.Sprintf("%v", d)
+		}
+
+		r.parameters[name] = parameterValue(fmt.Sprintf("%v", defMap["Type"]), value)
+	}
+
+	return r
+}
+
+func pseudoParameters(region string, stackName string) map[string]interface{} {
+	partition := "aws"
+	urlSuffix := "amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		partition = "aws-cn"
+		urlSuffix = "amazonaws.com.cn"
+	} else if strings.HasPrefix(region, "us-gov-") {
+		partition = "aws-us-gov"
+	}
+
+	return map[string]interface{}{
+		"AWS::AccountId":        defaultAccountID,
+		"AWS::NotificationARNs": []interface{}{},
+		"AWS::NoValue":          noValue{},
+		"AWS::Partition":        partition,
+		"AWS::Region":           region,
+		"AWS::StackId":          fmt.Sprintf("arn:%s:cloudformation:%s:%s:stack/%s/id", partition, region, defaultAccountID, stackName),
+		"AWS::StackName":        stackName,
+		"AWS::URLSuffix":        urlSuffix,
+	}
+}
+
+// parameterValue converts the parameter value to the type of the parameter,
+// so that number parameters can be used for number properties.
+func parameterValue(paramType string, value string) interface{} {
+	switch {
+	case paramType == "Number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case paramType == "CommaDelimitedList" || strings.HasPrefix(paramType, "List<"):
+		l := make([]interface{}, 0)
+		for _, v := range strings.Split(value, ",") {
+			l = append(l, strings.TrimSpace(v))
+		}
+		return l
+	}
+
+	return value
+}
+
+// resolve returns a copy of the template with the resources that have false
+// conditions removed and the intrinsic functions in the resources resolved.
+func (r *templateResolver) resolve() (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(r.template))
+	for k, v := range r.template {
+		resolved[k] = v
+	}
+
+	resources := make(map[string]interface{})
+
+	// Sort the names so any errors are deterministic
+	names := make([]string, 0)
+	for name := range mapValue(r.template["Resources"]) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		res := mapValue(mapValue(r.template["Resources"])[name])
+
+		if cond, ok := res["Condition"].(string); ok {
+			include, err := r.condition(cond)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error evaluating condition for resource %s", name)
+			}
+			if !include {
+				continue
+			}
+		}
+
+		v, err := r.value(res)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error resolving resource %s", name)
+		}
+
+		resolvedRes := mapValue(v)
+		delete(resolvedRes, "Condition")
+		resources[name] = resolvedRes
+	}
+
+	resolved["Resources"] = resources
+	delete(resolved, "Conditions")
+
+	return resolved, nil
+}
+
+// value resolves the intrinsic functions in a value. Properties and list items
+// that resolve to AWS::NoValue or that can't be resolved are removed.
+func (r *templateResolver) value(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if fn, args, ok := intrinsic(t); ok {
+			return r.intrinsic(fn, args)
+		}
+
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			resolved, err := r.value(val)
+			if err != nil {
+				return nil, err
+			}
+			if isRemoved(resolved) {
+				continue
+			}
+			m[k] = resolved
+		}
+		return m, nil
+	case []interface{}:
+		l := make([]interface{}, 0, len(t))
+		for _, val := range t {
+			resolved, err := r.value(val)
+			if err != nil {
+				return nil, err
+			}
+			if isRemoved(resolved) {
+				continue
+			}
+			l = append(l, resolved)
+		}
+		return l, nil
+	}
+
+	return v, nil
+}
+
+func (r *templateResolver) intrinsic(fn string, args interface{}) (interface{}, error) {
+	switch fn {
+	case "Ref":
+		return r.ref(args)
+	case "Fn::If":
+		return r.fnIf(args)
+	case "Fn::FindInMap":
+		return r.fnFindInMap(args)
+	case "Fn::Join":
+		return r.fnJoin(args)
+	case "Fn::Select":
+		return r.fnSelect(args)
+	case "Fn::Split":
+		return r.fnSplit(args)
+	case "Fn::Sub":
+		return r.fnSub(args)
+	case "Fn::GetAZs":
+		return r.fnGetAZs(args)
+	case "Fn::Base64":
+		s, err := r.stringArg(args)
+		if err != nil || s == nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString([]byte(*s)), nil
+	case "Fn::Equals", "Fn::And", "Fn::Or", "Fn::Not":
+		return r.conditionFunction(fn, args)
+	}
+
+	// Fn::GetAtt, Fn::ImportValue, Fn::Cidr and Fn::Transform need the
+	// deployed stack or other stacks so they can't be resolved
+	return nil, nil
+}
+
+func (r *templateResolver) ref(args interface{}) (interface{}, error) {
+	name, ok := args.(string)
+	if !ok {
+		return nil, errors.New("Ref must be a string")
+	}
+
+	if v, ok := r.parameters[name]; ok {
+		return v, nil
+	}
+
+	// The physical IDs of resources aren't known until the stack is deployed,
+	// so use the logical ID. This lets the parser find the referenced resource.
+	if _, ok := mapValue(r.template["Resources"])[name]; ok {
+		return name, nil
+	}
+
+	return nil, nil
+}
+
+func (r *templateResolver) fnIf(args interface{}) (interface{}, error) {
+	l, ok := args.([]interface{})
+	if !ok || len(l) != 3 {
+		return nil, errors.New("Fn::If must be a list of a condition name and two values")
+	}
+
+	name, ok := l[0].(string)
+	if !ok {
+		return nil, errors.New("Fn::If condition must be a condition name")
+	}
+
+	cond, err := r.condition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if cond {
+		return r.value(l[1])
+	}
+
+	return r.value(l[2])
+}
+
+func (r *templateResolver) fnFindInMap(args interface{}) (interface{}, error) {
+	l, err := r.listArgs("Fn::FindInMap", args, 3)
+	if err != nil || l == nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, 3)
+	for _, v := range l {
+		s, ok := v.(string)
+		if !ok {
+			return nil, nil
+		}
+		keys = append(keys, s)
+	}
+
+	topLevel, ok := r.mappings[keys[0]]
+	if !ok {
+		return nil, fmt.Errorf("Fn::FindInMap mapping %s not found", keys[0])
+	}
+
+	secondLevel, ok := mapValue(topLevel)[keys[1]]
+	if !ok {
+		return nil, fmt.Errorf("Fn::FindInMap key %s not found in mapping %s", keys[1], keys[0])
+	}
+
+	v, ok := mapValue(secondLevel)[keys[2]]
+	if !ok {
+		return nil, fmt.Errorf("Fn::FindInMap key %s not found in mapping %s.%s", keys[2], keys[0], keys[1])
+	}
+
+	return v, nil
+}
+
+func (r *templateResolver) fnJoin(args interface{}) (interface{}, error) {
+	l, err := r.listArgs("Fn::Join", args, 2)
+	if err != nil || l == nil {
+		return nil, err
+	}
+
+	delim, ok := l[0].(string)
+	if !ok {
+		return nil, errors.New("Fn::Join delimiter must be a string")
+	}
+
+	values, ok := l[1].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == nil {
+			return nil, nil
+		}
+		parts = append(parts, fmt.Sprintf("%v", v))
+	}
+
+	return strings.Join(parts, delim), nil
+}
+
+func (r *templateResolver) fnSelect(args interface{}) (interface{}, error) {
+	l, err := r.listArgs("Fn::Select", args, 2)
+	if err != nil || l == nil {
+		return nil, err
+	}
+
+	index, err := strconv.Atoi(fmt.Sprintf("%v", l[0]))
+	if err != nil {
+		return nil, errors.New("Fn::Select index must be a number")
+	}
+
+	values, ok := l[1].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	if index < 0 || index >= len(values) {
+		return nil, fmt.Errorf("Fn::Select index %d is out of range", index)
+	}
+
+	return values[index], nil
+}
+
+func (r *templateResolver) fnSplit(args interface{}) (interface{}, error) {
+	l, err := r.listArgs("Fn::Split", args, 2)
+	if err != nil || l == nil {
+		return nil, err
+	}
+
+	delim, ok := l[0].(string)
+	if !ok {
+		return nil, errors.New("Fn::Split delimiter must be a string")
+	}
+
+	s, ok := l[1].(string)
+	if !ok {
+		return nil, nil
+	}
+
+	parts := make([]interface{}, 0)
+	for _, p := range strings.Split(s, delim) {
+		parts = append(parts, p)
+	}
+
+	return parts, nil
+}
+
+// fnSub substitutes the variables in the string with the given variables,
+// parameters and pseudo parameters. Variables for resources and attributes
+// are left as they are since they can't be resolved.
+func (r *templateResolver) fnSub(args interface{}) (interface{}, error) {
+	var s string
+	vars := make(map[string]interface{})
+
+	switch t := args.(type) {
+	case string:
+		s = t
+	case []interface{}:
+		if len(t) != 2 {
+			return nil, errors.New("Fn::Sub must be a string or a list of a string and a map of variables")
+		}
+
+		str, ok := t[0].(string)
+		if !ok {
+			return nil, errors.New("Fn::Sub must be a string or a list of a string and a map of variables")
+		}
+		s = str
+
+		for k, v := range mapValue(t[1]) {
+			resolved, err := r.value(v)
+			if err != nil {
+				return nil, err
+			}
+			vars[k] = resolved
+		}
+	default:
+		return nil, errors.New("Fn::Sub must be a string or a list of a string and a map of variables")
+	}
+
+	s = subVariableRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		name := strings.TrimSpace(m[2 : len(m)-1])
+
+		v, ok := vars[name]
+		if !ok {
+			v, ok = r.parameters[name]
+		}
+		if !ok || v == nil {
+			return m
+		}
+
+		if l, ok := v.([]interface{}); ok {
+			parts := make([]string, 0, len(l))
+			for _, p := range l {
+				parts = append(parts, fmt.Sprintf("%v", p))
+			}
+			return strings.Join(parts, ",")
+		}
+
+		return fmt.Sprintf("%v", v)
+	})
+
+	// ${!Literal} is written as ${Literal}
+	return strings.ReplaceAll(s, "${!", "${"), nil
+}
+
+func (r *templateResolver) fnGetAZs(args interface{}) (interface{}, error) {
+	region := fmt.Sprintf("%v", r.parameters["AWS::Region"])
+
+	v, err := r.value(args)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := v.(string); ok && s != "" {
+		region = s
+	}
+
+	azs := make([]interface{}, 0, 3)
+	for _, suffix := range []string{"a", "b", "c"} {
+		azs = append(azs, region+suffix)
+	}
+
+	return azs, nil
+}
+
+// condition evaluates a named condition from the Conditions section.
+func (r *templateResolver) condition(name string) (bool, error) {
+	if v, ok := r.evaluated[name]; ok {
+		return v, nil
+	}
+
+	def, ok := r.conditions[name]
+	if !ok {
+		return false, fmt.Errorf("Condition %s not found", name)
+	}
+
+	if r.evaluating[name] {
+		return false, fmt.Errorf("Condition %s refers to itself", name)
+	}
+	r.evaluating[name] = true
+	defer delete(r.evaluating, name)
+
+	v, err := r.conditionValue(def)
+	if err != nil {
+		return false, errors.Wrapf(err, "Error evaluating condition %s", name)
+	}
+
+	r.evaluated[name] = v
+
+	return v, nil
+}
+
+// conditionValue evaluates a condition function, or a reference to another
+// condition.
+func (r *templateResolver) conditionValue(v interface{}) (bool, error) {
+	switch t := v.(type) {
+	case bool:
+		return t, nil
+	case string:
+		return strconv.ParseBool(t)
+	case map[string]interface{}:
+		if name, ok := t["Condition"]; ok && len(t) == 1 {
+			s, ok := name.(string)
+			if !ok {
+				return false, errors.New("Condition must be a condition name")
+			}
+			return r.condition(s)
+		}
+
+		fn, args, ok := intrinsic(t)
+		if !ok {
+			break
+		}
+
+		res, err := r.conditionFunction(fn, args)
+		if err != nil {
+			return false, err
+		}
+
+		b, ok := res.(bool)
+		if !ok {
+			return false, fmt.Errorf("%s is not a condition function", fn)
+		}
+
+		return b, nil
+	}
+
+	return false, fmt.Errorf("Invalid condition %v", v)
+}
+
+func (r *templateResolver) conditionFunction(fn string, args interface{}) (interface{}, error) {
+	l, ok := args.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", fn)
+	}
+
+	switch fn {
+	case "Fn::Equals":
+		if len(l) != 2 {
+			return nil, errors.New("Fn::Equals must be a list of two values")
+		}
+
+		a, err := r.value(l[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.value(l[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b), nil
+	case "Fn::Not":
+		if len(l) != 1 {
+			return nil, errors.New("Fn::Not must be a list of one condition")
+		}
+
+		v, err := r.conditionValue(l[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return !v, nil
+	}
+
+	// Fn::And and Fn::Or
+	result := fn == "Fn::And"
+	for _, c := range l {
+		v, err := r.conditionValue(c)
+		if err != nil {
+			return nil, err
+		}
+
+		if fn == "Fn::And" {
+			result = result && v
+		} else {
+			result = result || v
+		}
+	}
+
+	return result, nil
+}
+
+// listArgs resolves the arguments of an intrinsic function that takes a list
+// of values. If any of the values can't be resolved then nil is returned.
+func (r *templateResolver) listArgs(fn string, args interface{}, n int) ([]interface{}, error) {
+	l, ok := args.([]interface{})
+	if !ok || len(l) != n {
+		return nil, fmt.Errorf("%s must be a list of %d values", fn, n)
+	}
+
+	resolved := make([]interface{}, 0, n)
+	for _, v := range l {
+		val, err := r.value(v)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		resolved = append(resolved, val)
+	}
+
+	return resolved, nil
+}
+
+func (r *templateResolver) stringArg(args interface{}) (*string, error) {
+	v, err := r.value(args)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, nil
+	}
+
+	return &s, nil
+}
+
+// intrinsic returns the function name and arguments if the map is an
+// intrinsic function, i.e. a map with a single Ref or Fn:: key.
+func intrinsic(m map[string]interface{}) (string, interface{}, bool) {
+	if len(m) != 1 {
+		return "", nil, false
+	}
+
+	for k, v := range m {
+		if k == "Ref" || strings.HasPrefix(k, "Fn::") {
+			return k, v, true
+		}
+	}
+
+	return "", nil, false
+}
+
+func isRemoved(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	_, ok := v.(noValue)
+	return ok
+}
+
+func mapValue(v interface{}) map[string]interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+
+	return m
+}
@@ -0,0 +1,169 @@
+package cloudformation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+const testTemplate = `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  Env:
+    Type: String
+    Default: dev
+  ReadCapacity:
+    Type: Number
+    Default: 5
+Mappings:
+  RegionMap:
+    us-east-1:
+      BillingMode: PAY_PER_REQUEST
+    eu-west-1:
+      BillingMode: PROVISIONED
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsDev: !Not [!Condition IsProd]
+Resources:
+  Table:
+    Type: AWS::DynamoDB::Table
+    Properties:
+      TableName: !Sub "${AWS::StackName}-${Env}-table"
+      BillingMode: !FindInMap [RegionMap, !Ref "AWS::Region", BillingMode]
+      ProvisionedThroughput: !If
+        - IsProd
+        - ReadCapacityUnits: !Ref ReadCapacity
+          WriteCapacityUnits: !Ref ReadCapacity
+        - !Ref AWS::NoValue
+      StreamSpecification:
+        StreamViewType: !GetAtt Other.StreamViewType
+      SSESpecification:
+        KMSMasterKeyId: !Ref Key
+  Key:
+    Type: AWS::KMS::Key
+  DevTable:
+    Type: AWS::DynamoDB::Table
+    Condition: IsDev
+    Properties:
+      TableName: !Join ["-", [dev, !Select [1, !Split [",", "a,b,c"]]]]
+`
+
+func resolveTestTemplate(t *testing.T, params map[string]string, region string) map[string]interface{} {
+	raw, err := decodeTemplate([]byte(testTemplate))
+	require.NoError(t, err)
+
+	resolved, err := newTemplateResolver(raw, params, region, "my-stack").resolve()
+	require.NoError(t, err)
+
+	return resolved
+}
+
+func templateProperties(t *testing.T, template map[string]interface{}, name string) map[string]interface{} {
+	res, ok := mapValue(template["Resources"])[name]
+	require.True(t, ok, "resource %s not found", name)
+
+	return mapValue(mapValue(res)["Properties"])
+}
+
+func TestResolveDefaults(t *testing.T) {
+	resolved := resolveTestTemplate(t, map[string]string{}, "us-east-1")
+
+	props := templateProperties(t, resolved, "Table")
+	assert.Equal(t, "my-stack-dev-table", props["TableName"])
+	assert.Equal(t, "PAY_PER_REQUEST", props["BillingMode"])
+	assert.NotContains(t, props, "ProvisionedThroughput")
+	assert.Equal(t, map[string]interface{}{}, props["StreamSpecification"])
+	assert.Equal(t, map[string]interface{}{"KMSMasterKeyId": "Key"}, props["SSESpecification"])
+
+	devProps := templateProperties(t, resolved, "DevTable")
+	assert.Equal(t, "dev-b", devProps["TableName"])
+	assert.NotContains(t, mapValue(resolved["Resources"])["DevTable"], "Condition")
+}
+
+func TestResolveParameterOverrides(t *testing.T) {
+	resolved := resolveTestTemplate(t, map[string]string{"Env": "prod", "ReadCapacity": "20"}, "eu-west-1")
+
+	props := templateProperties(t, resolved, "Table")
+	assert.Equal(t, "my-stack-prod-table", props["TableName"])
+	assert.Equal(t, "PROVISIONED", props["BillingMode"])
+	assert.Equal(t, map[string]interface{}{
+		"ReadCapacityUnits":  float64(20),
+		"WriteCapacityUnits": float64(20),
+	}, props["ProvisionedThroughput"])
+
+	assert.NotContains(t, mapValue(resolved["Resources"]), "DevTable")
+}
+
+func TestResolveConditionCycle(t *testing.T) {
+	raw := map[string]interface{}{
+		"Conditions": map[string]interface{}{
+			"A": map[string]interface{}{"Condition": "B"},
+			"B": map[string]interface{}{"Condition": "A"},
+		},
+		"Resources": map[string]interface{}{
+			"Table": map[string]interface{}{
+				"Type":      "AWS::DynamoDB::Table",
+				"Condition": "A",
+			},
+		},
+	}
+
+	_, err := newTemplateResolver(raw, nil, "us-east-1", "my-stack").resolve()
+	assert.Error(t, err)
+}
+
+func TestDecodeTemplateShortForm(t *testing.T) {
+	raw, err := decodeTemplate([]byte(`
+Resources:
+  Table:
+    Properties:
+      Arn: !GetAtt Other.Arn
+      Name: !Ref Name
+`))
+	require.NoError(t, err)
+
+	props := templateProperties(t, raw, "Table")
+	assert.Equal(t, map[string]interface{}{"Fn::GetAtt": []interface{}{"Other", "Arn"}}, props["Arn"])
+	assert.Equal(t, map[string]interface{}{"Ref": "Name"}, props["Name"])
+}
+
+func TestTemplateParameters(t *testing.T) {
+	dir := t.TempDir()
+
+	listFile := filepath.Join(dir, "list.json")
+	err := os.WriteFile(listFile, []byte(`[{"ParameterKey": "Env", "ParameterValue": "staging"}, {"ParameterKey": "ReadCapacity", "ParameterValue": "10"}]`), 0600)
+	require.NoError(t, err)
+
+	params, err := templateParameters(&config.Project{
+		ParametersFile:     listFile,
+		ParameterOverrides: `Env=prod "Name=my table"`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Env": "prod", "ReadCapacity": "10", "Name": "my table"}, params)
+
+	objFile := filepath.Join(dir, "obj.json")
+	err = os.WriteFile(objFile, []byte(`{"Parameters": {"Env": "staging"}}`), 0600)
+	require.NoError(t, err)
+
+	params, err = templateParameters(&config.Project{ParametersFile: objFile})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Env": "staging"}, params)
+
+	_, err = templateParameters(&config.Project{ParameterOverrides: "Env"})
+	assert.Error(t, err)
+}
+
+func TestTemplateRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	assert.Equal(t, "us-east-1", templateRegion(&config.Project{}, map[string]string{}, ""))
+	assert.Equal(t, "eu-west-2", templateRegion(&config.Project{Env: map[string]string{"AWS_DEFAULT_REGION": "eu-west-2"}}, map[string]string{}, ""))
+	assert.Equal(t, "eu-central-1", templateRegion(&config.Project{Env: map[string]string{"AWS_DEFAULT_REGION": "eu-west-2"}}, map[string]string{}, "eu-central-1"))
+	assert.Equal(t, "ap-south-1", templateRegion(&config.Project{Env: map[string]string{"AWS_REGION": "eu-west-2"}}, map[string]string{"AWS::Region": "ap-south-1"}, "eu-central-1"))
+}
@@ -1,10 +1,12 @@
 package cloudformation
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/awslabs/goformation/v4/cloudformation"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -41,10 +43,13 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		res := registryItem.RFunc(d, u)
 		if res != nil {
 			res.ResourceType = d.Type
-			// TODO: Figure out how to set tags.  For now, have the RFunc set them.
-			// res.Tags = d.Tags
+			res.Tags = d.Tags
+			res.SetRebuildFunc(u, func(u *schema.UsageData) *schema.Resource {
+				return registryItem.RFunc(d, u)
+			})
 			if u != nil {
 				res.EstimationSummary = u.CalcEstimationSummary()
+				res.EstimatedUsageKeys = u.EstimatedKeys
 			}
 			return res
 		}
@@ -59,14 +64,15 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 	}
 }
 
-func (p *Parser) parseTemplate(t *cloudformation.Template, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, error) {
+func (p *Parser) parseTemplate(t *cloudformation.Template, region string, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, error) {
 	baseResources := p.loadUsageFileResources(usage)
 
 	var resources []*schema.Resource
 	resources = append(resources, baseResources...)
 
-	for name, d := range t.Resources {
-		tags := map[string]string{} // TODO: Where do I get tags?
+	resourceData := p.parseResourceData(t, region)
+
+	for name, d := range resourceData {
 		var usageData *schema.UsageData
 
 		if ud := usage[name]; ud != nil {
@@ -78,9 +84,8 @@ func (p *Parser) parseTemplate(t *cloudformation.Template, usage map[string]*sch
 				usageData = arrayUsageData
 			}
 		}
-		resourceData := schema.NewCFResourceData(d.AWSCloudFormationType(), "aws", name, tags, d)
 
-		if r := p.createResource(resourceData, usageData); r != nil {
+		if r := p.createResource(d, usageData); r != nil {
 			resources = append(resources, r)
 		}
 	}
@@ -88,6 +93,73 @@ func (p *Parser) parseTemplate(t *cloudformation.Template, usage map[string]*sch
 	return resources, resources, nil
 }
 
+func (p *Parser) parseResourceData(t *cloudformation.Template, region string) map[string]*schema.ResourceData {
+	resourceData := make(map[string]*schema.ResourceData, len(t.Resources))
+	properties := make(map[string]gjson.Result, len(t.Resources))
+
+	for name, r := range t.Resources {
+		props := resourceProperties(r)
+		properties[name] = props
+
+		d := schema.NewCFResourceData(r.AWSCloudFormationType(), "aws", name, parseTags(props), r)
+		d.Set("region", region)
+		resourceData[name] = d
+	}
+
+	registryMap := GetResourceRegistryMap()
+
+	// Refs to other resources in the template are resolved to the logical ID
+	// of the resource, so these can be used to add the references
+	for name, d := range resourceData {
+		registryItem, ok := (*registryMap)[d.Type]
+		if !ok {
+			continue
+		}
+
+		for _, attr := range registryItem.ReferenceAttributes {
+			for _, ref := range properties[name].Get(attr).Array() {
+				if refData, ok := resourceData[ref.String()]; ok {
+					d.AddReference(attr, refData)
+				}
+			}
+		}
+	}
+
+	return resourceData
+}
+
+// resourceProperties returns the properties of the resource as JSON so they
+// can be read without knowing the resource type.
+func resourceProperties(r cloudformation.Resource) gjson.Result {
+	b, err := json.Marshal(r)
+	if err != nil {
+		log.Debugf("Error marshalling Cloudformation resource: %s", err)
+		return gjson.Result{}
+	}
+
+	return gjson.GetBytes(b, "Properties")
+}
+
+// parseTags returns the tags of the resource. Most resources use a list of
+// Key and Value objects but some, e.g. AWS::EKS::Nodegroup and
+// AWS::SSM::Parameter, use a map.
+func parseTags(props gjson.Result) map[string]string {
+	tags := make(map[string]string)
+
+	v := props.Get("Tags")
+	if v.IsArray() {
+		for _, tag := range v.Array() {
+			tags[tag.Get("Key").String()] = tag.Get("Value").String()
+		}
+	} else {
+		for k, val := range v.Map() {
+			tags[k] = val.String()
+		}
+	}
+
+	return tags
+}
+
 func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schema.Resource {
 	resources := make([]*schema.Resource, 0)
 
@@ -106,5 +178,5 @@ func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schem
 }
 
 func isAwsChina(d *schema.ResourceData) bool {
-	return strings.HasPrefix(d.Type, "aws_") && strings.HasPrefix(d.Get("region").String(), "cn-")
+	return (strings.HasPrefix(d.Type, "aws_") || strings.HasPrefix(d.Type, "AWS::")) && strings.HasPrefix(d.Get("region").String(), "cn-")
 }
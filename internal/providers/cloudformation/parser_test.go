@@ -0,0 +1,95 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/awslabs/goformation/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const testServiceTemplate = `
+Resources:
+  TaskDefinition:
+    Type: AWS::ECS::TaskDefinition
+    Properties:
+      Cpu: "1024"
+      Memory: 2 GB
+  Service:
+    Type: AWS::ECS::Service
+    Properties:
+      LaunchType: FARGATE
+      DesiredCount: 2
+      TaskDefinition: !Ref TaskDefinition
+      Tags:
+        - Key: Team
+          Value: payments
+  NodeGroup:
+    Type: AWS::EKS::Nodegroup
+    Properties:
+      ClusterName: cluster
+      Subnets: [subnet-a]
+      NodeRole: role
+      Tags:
+        Team: platform
+`
+
+func parseTestServiceTemplate(t *testing.T) []*schema.Resource {
+	raw, err := decodeTemplate([]byte(testServiceTemplate))
+	require.NoError(t, err)
+
+	resolved, err := newTemplateResolver(raw, nil, "eu-west-1", "my-stack").resolve()
+	require.NoError(t, err)
+
+	b, err := json.Marshal(resolved)
+	require.NoError(t, err)
+
+	template, err := goformation.ParseJSON(b)
+	require.NoError(t, err)
+
+	resources, _, err := (&Parser{}).parseTemplate(template, "eu-west-1", map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	return resources
+}
+
+func findResource(resources []*schema.Resource, name string) *schema.Resource {
+	for _, r := range resources {
+		if r.Name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func TestParseTemplateTags(t *testing.T) {
+	resources := parseTestServiceTemplate(t)
+
+	service := findResource(resources, "Service")
+	require.NotNil(t, service)
+	assert.Equal(t, map[string]string{"Team": "payments"}, service.Tags)
+
+	nodeGroup := findResource(resources, "NodeGroup")
+	require.NotNil(t, nodeGroup)
+	assert.Equal(t, map[string]string{"Team": "platform"}, nodeGroup.Tags)
+}
+
+func TestParseTemplateReferences(t *testing.T) {
+	resources := parseTestServiceTemplate(t)
+
+	service := findResource(resources, "Service")
+	require.NotNil(t, service)
+	require.Len(t, service.CostComponents, 2)
+
+	// 2 tasks with 2 GB and 1 vCPU each from the referenced task definition
+	assert.Equal(t, "4", service.CostComponents[0].HourlyQuantity.String())
+	assert.Equal(t, "2", service.CostComponents[1].HourlyQuantity.String())
+
+	taskDefinition := findResource(resources, "TaskDefinition")
+	require.NotNil(t, taskDefinition)
+	assert.True(t, taskDefinition.NoPrice)
+}
@@ -0,0 +1,149 @@
+package cloudformation
+
+import (
+	"sort"
+)
+
+// samFunctionProperties are the AWS::Serverless::Function properties that are
+// also AWS::Lambda::Function properties. The other properties, e.g. CodeUri
+// and Events, are used by the SAM transform to create other resources.
+var samFunctionProperties = []string{"FunctionName", "Handler", "MemorySize", "Runtime", "Timeout"}
+
+// expandSAMResources replaces the AWS SAM resources in the template with the
+// CloudFormation resources the SAM transform creates for them, so they can be
+// priced like the other resources. The APIs that SAM creates implicitly for
+// function events are added with the logical IDs SAM uses for them.
+func expandSAMResources(template map[string]interface{}) {
+	resources := mapValue(template["Resources"])
+	globals := mapValue(template["Globals"])
+
+	expanded := make(map[string]interface{}, len(resources))
+	implicit := make(map[string]interface{})
+
+	for name, v := range resources {
+		res := mapValue(v)
+		props := mapValue(res["Properties"])
+
+		switch res["Type"] {
+		case "AWS::Serverless::Function":
+			props = withGlobals(mapValue(globals["Function"]), props)
+
+			lambdaProps := make(map[string]interface{})
+			for _, k := range samFunctionProperties {
+				if val, ok := props[k]; ok {
+					lambdaProps[k] = val
+				}
+			}
+			if tags, ok := props["Tags"]; ok {
+				lambdaProps["Tags"] = tagList(tags)
+			}
+
+			expanded[name] = samResource(res, "AWS::Lambda::Function", lambdaProps)
+
+			for _, event := range mapValue(props["Events"]) {
+				eventMap := mapValue(event)
+				eventProps := mapValue(eventMap["Properties"])
+
+				switch eventMap["Type"] {
+				case "Api":
+					if _, ok := eventProps["RestApiId"]; !ok {
+						implicit["ServerlessRestApi"] = map[string]interface{}{
+							"Type": "AWS::ApiGateway::RestApi",
+						}
+					}
+				case "HttpApi":
+					if _, ok := eventProps["ApiId"]; !ok {
+						implicit["ServerlessHttpApi"] = map[string]interface{}{
+							"Type":       "AWS::ApiGatewayV2::Api",
+							"Properties": map[string]interface{}{"ProtocolType": "HTTP"},
+						}
+					}
+				}
+			}
+		case "AWS::Serverless::Api":
+			expanded[name] = samResource(res, "AWS::ApiGateway::RestApi", map[string]interface{}{})
+		case "AWS::Serverless::HttpApi":
+			expanded[name] = samResource(res, "AWS::ApiGatewayV2::Api", map[string]interface{}{"ProtocolType": "HTTP"})
+		case "AWS::Serverless::SimpleTable":
+			props = withGlobals(mapValue(globals["SimpleTable"]), props)
+
+			tableProps := map[string]interface{}{
+				"BillingMode": "PAY_PER_REQUEST",
+			}
+			if tableName, ok := props["TableName"]; ok {
+				tableProps["TableName"] = tableName
+			}
+			if throughput, ok := props["ProvisionedThroughput"]; ok {
+				tableProps["BillingMode"] = "PROVISIONED"
+				tableProps["ProvisionedThroughput"] = throughput
+			}
+			if tags, ok := props["Tags"]; ok {
+				tableProps["Tags"] = tagList(tags)
+			}
+
+			expanded[name] = samResource(res, "AWS::DynamoDB::Table", tableProps)
+		case "AWS::Serverless::LayerVersion":
+			expanded[name] = samResource(res, "AWS::Lambda::LayerVersion", map[string]interface{}{})
+		default:
+			expanded[name] = v
+		}
+	}
+
+	for name, v := range implicit {
+		if _, ok := expanded[name]; !ok {
+			expanded[name] = v
+		}
+	}
+
+	template["Resources"] = expanded
+}
+
+// samResource returns the CloudFormation resource for a SAM resource, keeping
+// the resource attributes like Condition and DependsOn.
+func samResource(res map[string]interface{}, resourceType string, props map[string]interface{}) map[string]interface{} {
+	r := make(map[string]interface{}, len(res))
+	for k, v := range res {
+		r[k] = v
+	}
+
+	r["Type"] = resourceType
+	r["Properties"] = props
+
+	return r
+}
+
+// withGlobals returns the properties with the values from the Globals section
+// of the template added for any properties that aren't set.
+func withGlobals(globals map[string]interface{}, props map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(globals)+len(props))
+	for k, v := range globals {
+		merged[k] = v
+	}
+	for k, v := range props {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// tagList converts the map of tags used by SAM and the Serverless Framework to
+// the list of Key and Value objects used by most CloudFormation resources.
+func tagList(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tags := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		tags = append(tags, map[string]interface{}{"Key": k, "Value": m[k]})
+	}
+
+	return tags
+}
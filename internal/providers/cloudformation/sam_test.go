@@ -0,0 +1,70 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandSAMResources(t *testing.T) {
+	raw, err := decodeTemplate([]byte(`
+Transform: AWS::Serverless-2016-10-31
+Globals:
+  Function:
+    MemorySize: 512
+    Runtime: python3.9
+Resources:
+  Hello:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+      CodeUri: src/
+      Tags:
+        team: api
+        env: prod
+      Events:
+        Get:
+          Type: Api
+          Properties:
+            Path: /hello
+            Method: get
+  Big:
+    Type: AWS::Serverless::Function
+    Properties:
+      MemorySize: 2048
+  Table:
+    Type: AWS::Serverless::SimpleTable
+    Properties:
+      TableName: my-table
+  Queue:
+    Type: AWS::SQS::Queue
+`))
+	require.NoError(t, err)
+
+	expandSAMResources(raw)
+	resources := mapValue(raw["Resources"])
+
+	assert.Equal(t, "AWS::Lambda::Function", mapValue(resources["Hello"])["Type"])
+	assert.Equal(t, map[string]interface{}{
+		"Handler":    "app.handler",
+		"MemorySize": 512,
+		"Runtime":    "python3.9",
+		"Tags": []interface{}{
+			map[string]interface{}{"Key": "env", "Value": "prod"},
+			map[string]interface{}{"Key": "team", "Value": "api"},
+		},
+	}, templateProperties(t, raw, "Hello"))
+	assert.Equal(t, 2048, templateProperties(t, raw, "Big")["MemorySize"])
+
+	assert.Equal(t, "AWS::ApiGateway::RestApi", mapValue(resources["ServerlessRestApi"])["Type"])
+	assert.NotContains(t, resources, "ServerlessHttpApi")
+
+	assert.Equal(t, "AWS::DynamoDB::Table", mapValue(resources["Table"])["Type"])
+	assert.Equal(t, map[string]interface{}{
+		"BillingMode": "PAY_PER_REQUEST",
+		"TableName":   "my-table",
+	}, templateProperties(t, raw, "Table"))
+
+	assert.Equal(t, "AWS::SQS::Queue", mapValue(resources["Queue"])["Type"])
+}
@@ -0,0 +1,327 @@
+package cloudformation
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// The Serverless Framework defaults, see https://www.serverless.com/framework/docs/providers/aws/guide/serverless.yml
+const (
+	serverlessDefaultMemorySize = 1024
+	serverlessDefaultStage      = "dev"
+)
+
+var serverlessVariableRegexp = regexp.MustCompile(`\$\{([^${}]+)\}`)
+
+// IsServerlessFramework returns true if the path is a Serverless Framework
+// serverless.yml file for AWS.
+func IsServerlessFramework(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	raw, err := decodeTemplate(data)
+	if err != nil {
+		return false
+	}
+
+	return isServerlessFramework(raw)
+}
+
+// isServerlessFramework returns true if the decoded file has the service and
+// provider sections of a serverless.yml file instead of the Resources section
+// of a CloudFormation template. The provider can be set as a map with the
+// name, or as just the name, e.g. provider: aws.
+func isServerlessFramework(raw map[string]interface{}) bool {
+	if _, ok := raw["service"]; !ok {
+		return false
+	}
+
+	if name, ok := raw["provider"].(string); ok {
+		return name == "aws"
+	}
+
+	return mapValue(raw["provider"])["name"] == "aws"
+}
+
+// serverlessTemplate converts a serverless.yml file to the CloudFormation
+// template the Serverless Framework deploys, using the same logical IDs. Only
+// the resources that affect the cost are created, i.e. the functions, the APIs
+// for their events and the resources from the resources section. The region
+// from the provider section is also returned.
+func serverlessTemplate(raw map[string]interface{}) (map[string]interface{}, string) {
+	raw = mapValue(newServerlessVariableResolver(raw).value(raw, 0))
+
+	provider := mapValue(raw["provider"])
+
+	stage := serverlessDefaultStage
+	if s, ok := provider["stage"].(string); ok && !hasServerlessVariable(s) {
+		stage = s
+	}
+
+	region := ""
+	if r, ok := provider["region"].(string); ok && !hasServerlessVariable(r) {
+		region = r
+	}
+
+	defaultMemorySize := numberValue(provider["memorySize"], serverlessDefaultMemorySize)
+
+	resources := make(map[string]interface{})
+	hasRestAPIEvents := false
+	hasHTTPAPIEvents := false
+	hasWebsocketEvents := false
+
+	for name, v := range mapValue(raw["functions"]) {
+		fn := mapValue(v)
+
+		functionName := fmt.Sprintf("%v-%s-%s", raw["service"], stage, name)
+		if n, ok := fn["name"].(string); ok {
+			functionName = n
+		}
+
+		props := map[string]interface{}{
+			"FunctionName": functionName,
+			"MemorySize":   numberValue(fn["memorySize"], defaultMemorySize),
+		}
+		if tags, ok := fn["tags"]; ok {
+			props["Tags"] = tagList(tags)
+		}
+
+		resources[serverlessLogicalID(name)+"LambdaFunction"] = map[string]interface{}{
+			"Type":       "AWS::Lambda::Function",
+			"Properties": props,
+		}
+
+		for _, event := range sliceValue(fn["events"]) {
+			e := mapValue(event)
+			if _, ok := e["http"]; ok {
+				hasRestAPIEvents = true
+			}
+			if _, ok := e["httpApi"]; ok {
+				hasHTTPAPIEvents = true
+			}
+			if _, ok := e["websocket"]; ok {
+				hasWebsocketEvents = true
+			}
+		}
+	}
+
+	// APIs are only created if the functions don't use existing ones
+	if hasRestAPIEvents && mapValue(provider["apiGateway"])["restApiId"] == nil {
+		resources["ApiGatewayRestApi"] = map[string]interface{}{
+			"Type": "AWS::ApiGateway::RestApi",
+		}
+	}
+
+	if hasHTTPAPIEvents && mapValue(provider["httpApi"])["id"] == nil {
+		resources["HttpApi"] = map[string]interface{}{
+			"Type":       "AWS::ApiGatewayV2::Api",
+			"Properties": map[string]interface{}{"ProtocolType": "HTTP"},
+		}
+	}
+
+	if hasWebsocketEvents {
+		resources["WebsocketsApi"] = map[string]interface{}{
+			"Type":       "AWS::ApiGatewayV2::Api",
+			"Properties": map[string]interface{}{"ProtocolType": "WEBSOCKET"},
+		}
+	}
+
+	template := map[string]interface{}{
+		"AWSTemplateFormatVersion": "2010-09-09",
+	}
+
+	// The resources section can be a CloudFormation template fragment, or a
+	// list of them
+	fragments := sliceValue(raw["resources"])
+	if m, ok := raw["resources"].(map[string]interface{}); ok {
+		fragments = []interface{}{m}
+	}
+
+	for _, f := range fragments {
+		fragment, ok := f.(map[string]interface{})
+		if !ok {
+			log.Debugf("Skipping Serverless Framework resources %v as they couldn't be resolved", f)
+			continue
+		}
+
+		for _, section := range []string{"Resources", "Conditions", "Mappings"} {
+			for k, v := range mapValue(fragment[section]) {
+				if section == "Resources" {
+					resources[k] = v
+					continue
+				}
+
+				m := mapValue(template[section])
+				m[k] = v
+				template[section] = m
+			}
+		}
+	}
+
+	template["Resources"] = resources
+
+	return template, region
+}
+
+// serverlessLogicalID returns the normalized function name the Serverless
+// Framework uses in logical IDs, e.g. my-function becomes MyDashfunction.
+func serverlessLogicalID(name string) string {
+	name = strings.ReplaceAll(name, "-", "Dash")
+	name = strings.ReplaceAll(name, "_", "Underscore")
+
+	r := []rune(name)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+
+	return string(r)
+}
+
+// serverlessVariableResolver resolves the Serverless Framework variables that
+// don't need the deployed stack or the CLI options, i.e. self references,
+// environment variables and the default values.
+type serverlessVariableResolver struct {
+	raw map[string]interface{}
+}
+
+func newServerlessVariableResolver(raw map[string]interface{}) *serverlessVariableResolver {
+	return &serverlessVariableResolver{raw: raw}
+}
+
+// maxServerlessVariableDepth stops self references that refer to themselves
+// from being resolved forever.
+const maxServerlessVariableDepth = 10
+
+func (r *serverlessVariableResolver) value(v interface{}, depth int) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[k] = r.value(val, depth)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, 0, len(t))
+		for _, val := range t {
+			l = append(l, r.value(val, depth))
+		}
+		return l
+	case string:
+		return r.string(t, depth)
+	}
+
+	return v
+}
+
+func (r *serverlessVariableResolver) string(s string, depth int) interface{} {
+	if depth >= maxServerlessVariableDepth {
+		return s
+	}
+
+	// Resolve the innermost variables first since variables can be nested,
+	// e.g. ${self:custom.tableName.${opt:stage, 'dev'}}
+	for i := 0; i < maxServerlessVariableDepth; i++ {
+		match := serverlessVariableRegexp.FindStringSubmatchIndex(s)
+		if match == nil {
+			return s
+		}
+
+		v, ok := r.variable(s[match[2]:match[3]], depth)
+		if !ok {
+			return s
+		}
+
+		// A variable that is the whole value keeps the type of the value it
+		// refers to, e.g. a number or a map
+		if match[0] == 0 && match[1] == len(s) {
+			return v
+		}
+
+		s = s[:match[0]] + fmt.Sprintf("%v", v) + s[match[1]:]
+	}
+
+	return s
+}
+
+// variable resolves a single variable, trying each of the comma separated
+// sources in turn, e.g. ${opt:stage, self:provider.stage, 'dev'}.
+func (r *serverlessVariableResolver) variable(expr string, depth int) (interface{}, bool) {
+	for _, source := range strings.Split(expr, ",") {
+		source = strings.TrimSpace(source)
+
+		switch {
+		case len(source) >= 2 && (source[0] == '\'' || source[0] == '"') && source[len(source)-1] == source[0]:
+			return source[1 : len(source)-1], true
+		case strings.HasPrefix(source, "self:"):
+			if v, ok := lookupPath(r.raw, strings.TrimPrefix(source, "self:")); ok {
+				return r.value(v, depth+1), true
+			}
+		case strings.HasPrefix(source, "env:"):
+			if v, ok := os.LookupEnv(strings.TrimPrefix(source, "env:")); ok {
+				return v, true
+			}
+		case source == "sls:stage":
+			if s, ok := mapValue(r.raw["provider"])["stage"]; ok {
+				return r.value(s, depth+1), true
+			}
+			return serverlessDefaultStage, true
+		default:
+			if f, err := strconv.ParseFloat(source, 64); err == nil {
+				return f, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func lookupPath(v interface{}, path string) (interface{}, bool) {
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		v, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+func hasServerlessVariable(s string) bool {
+	return strings.Contains(s, "${")
+}
+
+func numberValue(v interface{}, defaultValue float64) float64 {
+	switch t := v.(type) {
+	case int:
+		return float64(t)
+	case float64:
+		return t
+	case string:
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return f
+		}
+	}
+
+	return defaultValue
+}
+
+func sliceValue(v interface{}) []interface{} {
+	if l, ok := v.([]interface{}); ok {
+		return l
+	}
+
+	return []interface{}{}
+}
@@ -0,0 +1,130 @@
+package cloudformation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testServerlessFile = `
+service: my-service
+custom:
+  tableName: ${self:service}-${self:provider.stage}-table
+provider:
+  name: aws
+  runtime: nodejs14.x
+  stage: ${opt:stage, 'prod'}
+  region: eu-west-1
+functions:
+  hello:
+    handler: handler.hello
+    tags:
+      team: api
+    events:
+      - http:
+          path: hello
+          method: get
+  process-items:
+    handler: handler.process
+    memorySize: 256
+resources:
+  Resources:
+    ItemsTable:
+      Type: AWS::DynamoDB::Table
+      Properties:
+        TableName: ${self:custom.tableName}
+        BillingMode: PAY_PER_REQUEST
+`
+
+const testServerlessScalarProviderFile = `
+service: my-service
+provider: aws
+functions:
+  hello:
+    handler: handler.hello
+`
+
+func TestServerlessTemplate(t *testing.T) {
+	raw, err := decodeTemplate([]byte(testServerlessFile))
+	require.NoError(t, err)
+	require.True(t, isServerlessFramework(raw))
+
+	template, region := serverlessTemplate(raw)
+	assert.Equal(t, "eu-west-1", region)
+
+	resources := mapValue(template["Resources"])
+	assert.Len(t, resources, 4)
+
+	assert.Equal(t, "AWS::Lambda::Function", mapValue(resources["HelloLambdaFunction"])["Type"])
+	assert.Equal(t, map[string]interface{}{
+		"FunctionName": "my-service-prod-hello",
+		"MemorySize":   float64(1024),
+		"Tags": []interface{}{
+			map[string]interface{}{"Key": "team", "Value": "api"},
+		},
+	}, templateProperties(t, template, "HelloLambdaFunction"))
+
+	assert.Equal(t, float64(256), templateProperties(t, template, "ProcessDashitemsLambdaFunction")["MemorySize"])
+	assert.Equal(t, "AWS::ApiGateway::RestApi", mapValue(resources["ApiGatewayRestApi"])["Type"])
+	assert.Equal(t, "my-service-prod-table", templateProperties(t, template, "ItemsTable")["TableName"])
+}
+
+func TestServerlessTemplateScalarProvider(t *testing.T) {
+	raw, err := decodeTemplate([]byte(testServerlessScalarProviderFile))
+	require.NoError(t, err)
+	require.True(t, isServerlessFramework(raw))
+
+	template, region := serverlessTemplate(raw)
+	assert.Equal(t, "", region)
+	assert.Equal(t, map[string]interface{}{
+		"FunctionName": "my-service-dev-hello",
+		"MemorySize":   float64(1024),
+	}, templateProperties(t, template, "HelloLambdaFunction"))
+}
+
+func TestServerlessVariables(t *testing.T) {
+	t.Setenv("TABLE_SUFFIX", "v2")
+
+	raw := map[string]interface{}{
+		"service": "svc",
+		"provider": map[string]interface{}{
+			"name": "aws",
+		},
+		"custom": map[string]interface{}{
+			"memory": map[string]interface{}{"dev": 512},
+			"loop":   "${self:custom.loop}",
+		},
+	}
+
+	r := newServerlessVariableResolver(raw)
+	assert.Equal(t, 512, r.value("${self:custom.memory.${sls:stage}}", 0))
+	assert.Equal(t, "svc-v2", r.value("${self:service}-${env:TABLE_SUFFIX}", 0))
+	assert.Equal(t, "default", r.value("${env:MISSING_VAR, 'default'}", 0))
+	assert.Equal(t, float64(10), r.value("${opt:count, 10}", 0))
+	assert.Equal(t, "${opt:stage}", r.value("${opt:stage}", 0))
+	assert.NotPanics(t, func() { r.value("${self:custom.loop}", 0) })
+}
+
+func TestIsServerlessFramework(t *testing.T) {
+	dir := t.TempDir()
+
+	serverlessFile := filepath.Join(dir, "serverless.yml")
+	err := os.WriteFile(serverlessFile, []byte(testServerlessFile), 0600)
+	require.NoError(t, err)
+	assert.True(t, IsServerlessFramework(serverlessFile))
+
+	templateFile := filepath.Join(dir, "template.yml")
+	err = os.WriteFile(templateFile, []byte(testTemplate), 0600)
+	require.NoError(t, err)
+	assert.False(t, IsServerlessFramework(templateFile))
+
+	scalarProviderFile := filepath.Join(dir, "scalar-provider.yml")
+	err = os.WriteFile(scalarProviderFile, []byte(testServerlessScalarProviderFile), 0600)
+	require.NoError(t, err)
+	assert.True(t, IsServerlessFramework(scalarProviderFile))
+
+	assert.False(t, IsServerlessFramework(dir))
+}
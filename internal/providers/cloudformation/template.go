@@ -0,0 +1,283 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/awslabs/goformation/v4"
+	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/kballard/go-shellquote"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+const defaultRegion = "us-east-1"
+
+// loadTemplate reads a CloudFormation template, evaluates the parameters,
+// mappings, conditions and intrinsic functions that can be resolved without
+// deploying it, and parses the resolved template. AWS SAM templates and
+// Serverless Framework files are converted to the CloudFormation resources
+// they deploy first. The region the template is deployed to is also returned.
+func loadTemplate(ctx *config.ProjectContext, path string) (*cloudformation.Template, string, error) {
+	raw, fileRegion, err := readTemplate(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	params, err := templateParameters(ctx.ProjectConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	region := templateRegion(ctx.ProjectConfig, params, fileRegion)
+
+	t, err := resolveTemplate(raw, params, region, stackName(path))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return t, region, nil
+}
+
+// readTemplate reads and decodes a template, converting AWS SAM resources and
+// Serverless Framework files to CloudFormation resources. The region set in
+// the file is also returned for Serverless Framework files.
+func readTemplate(path string) (map[string]interface{}, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Error reading Cloudformation template file")
+	}
+
+	raw, err := decodeTemplate(data)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Error decoding Cloudformation template file")
+	}
+
+	var fileRegion string
+	if isServerlessFramework(raw) {
+		raw, fileRegion = serverlessTemplate(raw)
+	}
+
+	expandSAMResources(raw)
+
+	return raw, fileRegion, nil
+}
+
+// resolveTemplate evaluates the intrinsic functions in the decoded template
+// and parses it.
+func resolveTemplate(raw map[string]interface{}, params map[string]string, region string, stackName string) (*cloudformation.Template, error) {
+	resolved, err := newTemplateResolver(raw, params, region, stackName).resolve()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error resolving Cloudformation template")
+	}
+
+	b, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error encoding resolved Cloudformation template")
+	}
+
+	t, err := goformation.ParseJSON(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing Cloudformation template file")
+	}
+
+	return t, nil
+}
+
+// decodeTemplate decodes a JSON or YAML template into generic values. The
+// short form YAML tags for intrinsic functions, e.g. !Ref, are converted to
+// the long form used in JSON templates.
+func decodeTemplate(data []byte) (map[string]interface{}, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		var raw map[string]interface{}
+		err := json.Unmarshal(data, &raw)
+		return raw, err
+	}
+
+	var node yaml.Node
+	err := yaml.Unmarshal(data, &node)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := yamlNodeValue(&node)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("Template is not a YAML mapping")
+	}
+
+	return raw, nil
+}
+
+func yamlNodeValue(n *yaml.Node) (interface{}, error) {
+	var v interface{}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return yamlNodeValue(n.Content[0])
+	case yaml.AliasNode:
+		return yamlNodeValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			val, err := yamlNodeValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = val
+		}
+		v = m
+	case yaml.SequenceNode:
+		s := make([]interface{}, 0, len(n.Content))
+		for _, c := range n.Content {
+			val, err := yamlNodeValue(c)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, val)
+		}
+		v = s
+	default:
+		if isIntrinsicTag(n.Tag) {
+			v = n.Value
+		} else if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+	}
+
+	if !isIntrinsicTag(n.Tag) {
+		return v, nil
+	}
+
+	name := strings.TrimPrefix(n.Tag, "!")
+	switch name {
+	case "Ref", "Condition":
+		return map[string]interface{}{name: v}, nil
+	case "GetAtt":
+		// The short form of GetAtt is a single string, e.g. !GetAtt Table.Arn
+		if s, ok := v.(string); ok {
+			parts := strings.SplitN(s, ".", 2)
+			l := make([]interface{}, 0, len(parts))
+			for _, p := range parts {
+				l = append(l, p)
+			}
+			v = l
+		}
+	}
+
+	return map[string]interface{}{"Fn::" + name: v}, nil
+}
+
+// isIntrinsicTag returns true for the custom YAML tags used for intrinsic
+// functions, as opposed to the standard tags like !!str.
+func isIntrinsicTag(tag string) bool {
+	return strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "!!")
+}
+
+// templateParameters returns the parameter values from the parameters file and
+// the parameter overrides, which take precedence.
+func templateParameters(projectCfg *config.Project) (map[string]string, error) {
+	params := make(map[string]string)
+
+	if projectCfg.ParametersFile != "" {
+		fileParams, err := loadParametersFile(projectCfg.ParametersFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range fileParams {
+			params[k] = v
+		}
+	}
+
+	if projectCfg.ParameterOverrides != "" {
+		overrides, err := shellquote.Split(projectCfg.ParameterOverrides)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing parameter overrides")
+		}
+
+		for _, o := range overrides {
+			parts := strings.SplitN(o, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("Invalid parameter override %s, expected Key=Value", o)
+			}
+			params[parts[0]] = parts[1]
+		}
+	}
+
+	return params, nil
+}
+
+// loadParametersFile loads parameter values from a JSON file in the format
+// used by the AWS CLI, i.e. a list of ParameterKey and ParameterValue objects,
+// or the format used by CodePipeline, i.e. an object with a Parameters object.
+func loadParametersFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading parameters file")
+	}
+
+	var list []struct {
+		ParameterKey   string `json:"ParameterKey"`
+		ParameterValue string `json:"ParameterValue"`
+	}
+	if err := json.Unmarshal(data, &list); err == nil {
+		params := make(map[string]string, len(list))
+		for _, p := range list {
+			params[p.ParameterKey] = p.ParameterValue
+		}
+		return params, nil
+	}
+
+	var obj struct {
+		Parameters map[string]string `json:"Parameters"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, errors.Wrapf(err, "Error parsing parameters file %s", path)
+	}
+
+	return obj.Parameters, nil
+}
+
+// templateRegion returns the region the template is deployed to. This can be
+// set with an AWS::Region parameter override, the region in the file for
+// Serverless Framework files, or the usual AWS environment variables.
+func templateRegion(projectCfg *config.Project, params map[string]string, fileRegion string) string {
+	if r := params["AWS::Region"]; r != "" {
+		return r
+	}
+
+	if fileRegion != "" {
+		return fileRegion
+	}
+
+	for _, key := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if r := projectCfg.Env[key]; r != "" {
+			return r
+		}
+		if r := os.Getenv(key); r != "" {
+			return r
+		}
+	}
+
+	return defaultRegion
+}
+
+// stackName returns the name used for the AWS::StackName pseudo parameter,
+// which is the template file name since the stack hasn't been deployed.
+func stackName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
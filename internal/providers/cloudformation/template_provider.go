@@ -1,15 +1,20 @@
 package cloudformation
 
 import (
-	"github.com/awslabs/goformation/v4"
+	"os"
+
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
 	"github.com/pkg/errors"
 )
 
 type TemplateProvider struct {
 	ctx  *config.ProjectContext
 	Path string
+	// serverless is true if the path is a Serverless Framework file, which is
+	// converted to a template when it's loaded.
+	serverless bool
 }
 
 func NewTemplateProvider(ctx *config.ProjectContext) schema.Provider {
@@ -19,11 +24,27 @@ func NewTemplateProvider(ctx *config.ProjectContext) schema.Provider {
 	}
 }
 
+func NewServerlessProvider(ctx *config.ProjectContext) schema.Provider {
+	return &TemplateProvider{
+		ctx:        ctx,
+		Path:       ctx.ProjectConfig.Path,
+		serverless: true,
+	}
+}
+
 func (p *TemplateProvider) Type() string {
+	if p.serverless {
+		return "serverless_framework"
+	}
+
 	return "cloudformation_state_json"
 }
 
 func (p *TemplateProvider) DisplayType() string {
+	if p.serverless {
+		return "Serverless Framework file"
+	}
+
 	return "Cloudformation state JSON file"
 }
 
@@ -32,9 +53,9 @@ func (p *TemplateProvider) AddMetadata(metadata *schema.ProjectMetadata) {
 }
 
 func (p *TemplateProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
-	template, err := goformation.Open(p.Path)
+	template, region, err := loadTemplate(p.ctx, p.Path)
 	if err != nil {
-		return []*schema.Project{}, errors.Wrap(err, "Error reading Cloudformation template file")
+		return []*schema.Project{}, err
 	}
 
 	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
@@ -44,13 +65,50 @@ func (p *TemplateProvider) LoadResources(usage map[string]*schema.UsageData) ([]
 
 	project := schema.NewProject(name, metadata)
 	parser := NewParser(p.ctx)
-	pastResources, resources, err := parser.parseTemplate(template, usage)
+	pastResources, resources, err := parser.parseTemplate(template, region, usage)
 	if err != nil {
 		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Cloudformation template file")
 	}
 
+	if previousPath := p.ctx.ProjectConfig.PreviousTemplatePath; previousPath != "" {
+		pastResources, err = p.loadPastResources(previousPath, resources, usage)
+		if err != nil {
+			return []*schema.Project{project}, err
+		}
+	}
+
 	project.PastResources = pastResources
 	project.Resources = resources
 
 	return []*schema.Project{project}, nil
 }
+
+// loadPastResources loads the resources the template is diffed against from
+// the previous template, or from a change set JSON description which lists the
+// changes made to the current resources.
+func (p *TemplateProvider) loadPastResources(path string, resources []*schema.Resource, usage map[string]*schema.UsageData) ([]*schema.Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading previous Cloudformation template file")
+	}
+
+	if cs := parseChangeSet(data); cs != nil {
+		if msg := cs.warning(); msg != "" {
+			ui.PrintWarning(os.Stderr, msg)
+		}
+
+		return cs.pastResources(resources), nil
+	}
+
+	template, region, err := loadTemplate(p.ctx, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error loading previous Cloudformation template file")
+	}
+
+	_, pastResources, err := NewParser(p.ctx).parseTemplate(template, region, usage)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing previous Cloudformation template file")
+	}
+
+	return pastResources, nil
+}
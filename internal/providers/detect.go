@@ -13,6 +13,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/pulumi"
 	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -28,6 +29,22 @@ func Detect(ctx *config.ProjectContext) (schema.Provider, error) {
 		return cloudformation.NewTemplateProvider(ctx), nil
 	}
 
+	if cloudformation.IsServerlessFramework(path) {
+		return cloudformation.NewServerlessProvider(ctx), nil
+	}
+
+	if cloudformation.IsCDKProject(path) {
+		return cloudformation.NewCDKProvider(ctx), nil
+	}
+
+	if isPulumiPreviewJSON(path) {
+		return pulumi.NewPreviewJSONProvider(ctx), nil
+	}
+
+	if isPulumiStackExportJSON(path) {
+		return pulumi.NewStackExportJSONProvider(ctx), nil
+	}
+
 	if isTerraformPlanJSON(path) {
 		return terraform.NewPlanJSONProvider(ctx), nil
 	}
@@ -45,6 +62,10 @@ func Detect(ctx *config.ProjectContext) (schema.Provider, error) {
 	}
 
 	if isTerraformDir(path) {
+		if ctx.ProjectConfig.TerraformParseHCL {
+			return terraform.NewHCLProvider(ctx), nil
+		}
+
 		return terraform.NewDirProvider(ctx), nil
 	}
 
@@ -103,6 +124,49 @@ func isTerraformStateJSON(path string) bool {
 	return jsonFormat.FormatVersion != "" && jsonFormat.Values != nil
 }
 
+func isPulumiPreviewJSON(path string) bool {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var jsonFormat struct {
+		Steps []struct {
+			URN string `json:"urn"`
+		} `json:"steps"`
+		ChangeSummary interface{} `json:"changeSummary"`
+	}
+
+	err = json.Unmarshal(b, &jsonFormat)
+	if err != nil {
+		return false
+	}
+
+	return jsonFormat.Steps != nil && jsonFormat.ChangeSummary != nil
+}
+
+func isPulumiStackExportJSON(path string) bool {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var jsonFormat struct {
+		Version    int `json:"version"`
+		Deployment struct {
+			Manifest  interface{} `json:"manifest"`
+			Resources interface{} `json:"resources"`
+		} `json:"deployment"`
+	}
+
+	err = json.Unmarshal(b, &jsonFormat)
+	if err != nil {
+		return false
+	}
+
+	return jsonFormat.Version != 0 && jsonFormat.Deployment.Manifest != nil
+}
+
 func isTerraformPlan(path string) bool {
 	r, err := zip.OpenReader(path)
 	if err != nil {
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetDBInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:rds/instance:Instance",
+		RFunc: NewDBInstance,
+	}
+}
+
+func NewDBInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.DbInstance{
+		Address:       strPtr(d.Address),
+		Region:        strPtr(d.Get("region").String()),
+		InstanceClass: strPtr(d.Get("instanceClass").String()),
+		Engine:        strPtr(d.Get("engine").String()),
+		MultiAz:       boolPtr(d.Get("multiAz").Bool()),
+		LicenseModel:  strPtr(d.Get("licenseModel").String()),
+	}
+
+	if !d.IsEmpty("backupRetentionPeriod") {
+		r.BackupRetentionPeriod = strPtr(d.Get("backupRetentionPeriod").String())
+	}
+	if !d.IsEmpty("storageType") {
+		r.StorageType = strPtr(d.Get("storageType").String())
+	}
+	if !d.IsEmpty("iops") {
+		r.Iops = floatPtr(d.Get("iops").Float())
+	}
+	if !d.IsEmpty("allocatedStorage") {
+		r.AllocatedStorage = floatPtr(d.Get("allocatedStorage").Float())
+	}
+
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetDynamoDBTableRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "aws:dynamodb/table:Table",
+		Notes: []string{
+			"DAX is not yet supported.",
+		},
+		RFunc: NewDynamoDBTable,
+	}
+}
+
+func NewDynamoDBTable(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	replicaRegions := []string{}
+	for _, data := range d.Get("replicas").Array() {
+		replicaRegions = append(replicaRegions, data.Get("regionName").String())
+	}
+
+	billingMode := "PROVISIONED"
+	if !d.IsEmpty("billingMode") {
+		billingMode = d.Get("billingMode").String()
+	}
+
+	a := &aws.DynamoDBTable{
+		Address:        d.Address,
+		Region:         d.Get("region").String(),
+		Name:           d.Get("name").String(),
+		BillingMode:    billingMode,
+		WriteCapacity:  intPtr(d.Get("writeCapacity").Int()),
+		ReadCapacity:   intPtr(d.Get("readCapacity").Int()),
+		ReplicaRegions: replicaRegions,
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,34 @@
+package aws
+
+import (
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetEBSVolumeRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:ebs/volume:Volume",
+		RFunc: NewEBSVolume,
+	}
+}
+
+func NewEBSVolume(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	var size *int64
+	if d.Get("size").Type != gjson.Null {
+		size = intPtr(d.Get("size").Int())
+	}
+
+	a := &aws.EBSVolume{
+		Address:    d.Address,
+		Region:     d.Get("region").String(),
+		Type:       d.Get("type").String(),
+		IOPS:       d.Get("iops").Int(),
+		Throughput: d.Get("throughput").Int(),
+		Size:       size,
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetECRRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:ecr/repository:Repository",
+		RFunc: NewECRRepository,
+	}
+}
+
+func NewECRRepository(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.EcrRepository{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
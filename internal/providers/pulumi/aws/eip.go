@@ -0,0 +1,34 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetEIPRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:ec2/eip:Eip",
+		RFunc: NewEIP,
+	}
+}
+
+func NewEIP(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.Eip{
+		Address: strPtr(d.Address),
+		Region:  strPtr(d.Get("region").String()),
+	}
+
+	if !d.IsEmpty("networkInterface") {
+		r.NetworkInterface = strPtr(d.Get("networkInterface").String())
+	}
+	if !d.IsEmpty("customerOwnedIpv4Pool") {
+		r.CustomerOwnedIpv4Pool = strPtr(d.Get("customerOwnedIpv4Pool").String())
+	}
+	if !d.IsEmpty("instance") {
+		r.Instance = strPtr(d.Get("instance").String())
+	}
+
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
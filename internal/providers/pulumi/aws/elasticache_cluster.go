@@ -0,0 +1,36 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetElastiCacheClusterItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:elasticache/cluster:Cluster",
+		RFunc: NewElastiCacheCluster,
+	}
+}
+
+func NewElastiCacheCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	// Clusters in a replication group are priced with the replication group
+	if !d.IsEmpty("replicationGroupId") {
+		return &schema.Resource{
+			Name:      d.Address,
+			NoPrice:   true,
+			IsSkipped: true,
+		}
+	}
+
+	a := &aws.ElastiCache{
+		Address:                d.Address,
+		Region:                 d.Get("region").String(),
+		NodeType:               d.Get("nodeType").String(),
+		Engine:                 d.Get("engine").String(),
+		CacheNodes:             d.Get("numCacheNodes").Int(),
+		SnapshotRetentionLimit: d.Get("snapshotRetentionLimit").Int(),
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
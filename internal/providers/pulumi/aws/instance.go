@@ -0,0 +1,65 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "aws:ec2/instance:Instance",
+		Notes: []string{
+			"Costs associated with marketplace AMIs are not supported.",
+			"For non-standard Linux AMIs such as Windows and RHEL, the operating system should be specified in usage file.",
+			"EC2 detailed monitoring assumes the standard 7 metrics and the lowest tier of prices for CloudWatch.",
+			"If a root volume is not specified then an 8Gi gp2 volume is assumed.",
+		},
+		RFunc: NewInstance,
+	}
+}
+
+func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	a := &aws.Instance{
+		Address:          d.Address,
+		Region:           region,
+		Tenancy:          d.Get("tenancy").String(),
+		PurchaseOption:   "on_demand",
+		AMI:              d.Get("ami").String(),
+		InstanceType:     d.Get("instanceType").String(),
+		EBSOptimized:     d.Get("ebsOptimized").Bool(),
+		EnableMonitoring: d.Get("monitoring").Bool(),
+		CPUCredits:       d.Get("creditSpecification.cpuCredits").String(),
+	}
+
+	a.RootBlockDevice = newInstanceEBSVolume("root_block_device", region, d.Get("rootBlockDevice"))
+
+	for i, data := range d.Get("ebsBlockDevices").Array() {
+		a.EBSBlockDevices = append(a.EBSBlockDevices, newInstanceEBSVolume(fmt.Sprintf("ebs_block_device[%d]", i), region, data))
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
+
+func newInstanceEBSVolume(address string, region string, data gjson.Result) *aws.EBSVolume {
+	v := &aws.EBSVolume{
+		Address:    address,
+		Region:     region,
+		Type:       data.Get("volumeType").String(),
+		IOPS:       data.Get("iops").Int(),
+		Throughput: data.Get("throughput").Int(),
+	}
+
+	if data.Get("volumeSize").Type != gjson.Null {
+		v.Size = intPtr(data.Get("volumeSize").Int())
+	}
+
+	return v
+}
@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetNewKMSKeyRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:kms/key:Key",
+		RFunc: NewKMSKey,
+	}
+}
+
+func NewKMSKey(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.KmsKey{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String()), CustomerMasterKeySpec: strPtr(d.Get("customerMasterKeySpec").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,31 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetLambdaFunctionRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:lambda/function:Function",
+		Notes: []string{"Provisioned concurrency is not yet supported."},
+		RFunc: NewLambdaFunction,
+	}
+}
+
+func NewLambdaFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	memorySize := int64(128)
+	if d.Get("memorySize").Exists() {
+		memorySize = d.Get("memorySize").Int()
+	}
+
+	a := &aws.LambdaFunction{
+		Address:    d.Address,
+		Region:     d.Get("region").String(),
+		Name:       d.Get("name").String(),
+		MemorySize: memorySize,
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
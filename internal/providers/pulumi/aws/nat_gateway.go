@@ -0,0 +1,23 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetNATGatewayRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:ec2/natGateway:NatGateway",
+		RFunc: NewNATGateway,
+	}
+}
+
+func NewNATGateway(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := &aws.NATGateway{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,56 @@
+package aws
+
+import "github.com/infracost/infracost/internal/schema"
+
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	GetCloudwatchLogGroupItem(),
+	GetDBInstanceRegistryItem(),
+	GetDynamoDBTableRegistryItem(),
+	GetEBSVolumeRegistryItem(),
+	GetECRRegistryItem(),
+	GetEIPRegistryItem(),
+	GetElastiCacheClusterItem(),
+	GetInstanceRegistryItem(),
+	GetLambdaFunctionRegistryItem(),
+	GetNATGatewayRegistryItem(),
+	GetNewEKSClusterItem(),
+	GetNewKMSKeyRegistryItem(),
+	GetS3BucketRegistryItem(),
+	GetS3BucketV2RegistryItem(),
+	GetSecretsManagerSecret(),
+	GetSNSTopicRegistryItem(),
+	GetSQSQueueRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	// AWS EC2
+	"aws:ec2/internetGateway:InternetGateway",
+	"aws:ec2/routeTable:RouteTable",
+	"aws:ec2/routeTableAssociation:RouteTableAssociation",
+	"aws:ec2/route:Route",
+	"aws:ec2/securityGroup:SecurityGroup",
+	"aws:ec2/securityGroupRule:SecurityGroupRule",
+	"aws:ec2/subnet:Subnet",
+	"aws:ec2/vpc:Vpc",
+
+	// AWS IAM
+	"aws:iam/instanceProfile:InstanceProfile",
+	"aws:iam/policy:Policy",
+	"aws:iam/role:Role",
+	"aws:iam/rolePolicy:RolePolicy",
+	"aws:iam/rolePolicyAttachment:RolePolicyAttachment",
+
+	// AWS Lambda
+	"aws:lambda/permission:Permission",
+
+	// AWS S3
+	"aws:s3/bucketPolicy:BucketPolicy",
+	"aws:s3/bucketPublicAccessBlock:BucketPublicAccessBlock",
+
+	// AWS SNS
+	"aws:sns/topicPolicy:TopicPolicy",
+
+	// AWS SQS
+	"aws:sqs/queuePolicy:QueuePolicy",
+}
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var s3StorageClassNames = map[string]string{
+	"STANDARD":            "standard",
+	"INTELLIGENT_TIERING": "intelligent_tiering",
+	"STANDARD_IA":         "standard_infrequent_access",
+	"ONEZONE_IA":          "one_zone_infrequent_access",
+	"GLACIER":             "glacier_flexible_retrieval",
+	"DEEP_ARCHIVE":        "glacier_deep_archive",
+}
+
+func GetS3BucketRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "aws:s3/bucket:Bucket",
+		Notes: []string{
+			"S3 replication time control data transfer, and batch operations are not supported by Pulumi.",
+		},
+		RFunc: NewS3Bucket,
+	}
+}
+
+func GetS3BucketV2RegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "aws:s3/bucketV2:BucketV2",
+		Notes: []string{
+			"S3 replication time control data transfer, and batch operations are not supported by Pulumi.",
+		},
+		RFunc: NewS3Bucket,
+	}
+}
+
+func NewS3Bucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	objTagsEnabled := false
+
+	// Always add the standard storage class
+	lifecycleStorageClassMap := map[string]bool{
+		"standard": true,
+	}
+
+	for _, rule := range d.Get("lifecycleRules").Array() {
+		if !rule.Get("enabled").Bool() {
+			continue
+		}
+
+		if len(rule.Get("tags").Map()) > 0 {
+			objTagsEnabled = true
+		}
+
+		for _, key := range []string{"transitions", "noncurrentVersionTransitions"} {
+			for _, t := range rule.Get(key).Array() {
+				if storageClass := s3StorageClassNames[t.Get("storageClass").String()]; storageClass != "" {
+					lifecycleStorageClassMap[storageClass] = true
+				}
+			}
+		}
+	}
+
+	lifecycleStorageClasses := make([]string, 0, len(lifecycleStorageClassMap))
+	for storageClass := range lifecycleStorageClassMap {
+		lifecycleStorageClasses = append(lifecycleStorageClasses, storageClass)
+	}
+
+	a := &aws.S3Bucket{
+		Address:                 d.Address,
+		Region:                  d.Get("region").String(),
+		Name:                    d.Get("bucket").String(),
+		ObjectTagsEnabled:       objTagsEnabled,
+		LifecycleStorageClasses: lifecycleStorageClasses,
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetSecretsManagerSecret() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:secretsmanager/secret:Secret",
+		RFunc: NewSecretsManagerSecret,
+	}
+}
+
+func NewSecretsManagerSecret(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.SecretsmanagerSecret{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
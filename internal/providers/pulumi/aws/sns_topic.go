@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetSNSTopicRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:sns/topic:Topic",
+		RFunc: NewSNSTopic,
+	}
+}
+
+func NewSNSTopic(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.SnsTopic{Address: strPtr(d.Address), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
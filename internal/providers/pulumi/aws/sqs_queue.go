@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetSQSQueueRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws:sqs/queue:Queue",
+		RFunc: NewSQSQueue,
+	}
+}
+
+func NewSQSQueue(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &aws.SqsQueue{Address: strPtr(d.Address), FifoQueue: boolPtr(d.Get("fifoQueue").Bool()), Region: strPtr(d.Get("region").String())}
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
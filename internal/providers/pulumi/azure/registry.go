@@ -0,0 +1,26 @@
+package azure
+
+import "github.com/infracost/infracost/internal/schema"
+
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getSQLDatabaseRegistryItem(),
+	getStorageAccountRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	// Azure Base
+	"azure-native:resources:ResourceGroup",
+
+	// Azure Network
+	"azure-native:network:NetworkSecurityGroup",
+	"azure-native:network:Subnet",
+	"azure-native:network:VirtualNetwork",
+
+	// Azure SQL
+	"azure-native:sql:FirewallRule",
+	"azure-native:sql:Server",
+
+	// Azure Storage
+	"azure-native:storage:BlobContainer",
+}
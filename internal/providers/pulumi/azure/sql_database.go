@@ -0,0 +1,92 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const gbInBytes float64 = 1073741824
+
+// sqlTierMapping maps the vCore SKU tiers to the tiers used in the prices.
+// Other tiers, e.g. Basic and Standard, use DTUs.
+var sqlTierMapping = map[string]string{
+	"GeneralPurpose":   "General Purpose",
+	"BusinessCritical": "Business Critical",
+	"Hyperscale":       "Hyperscale",
+}
+
+var sqlFamilyMapping = map[string]string{
+	"Gen5": "Compute Gen5",
+	"Gen4": "Compute Gen4",
+	"M":    "Compute M Series",
+}
+
+func getSQLDatabaseRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azure-native:sql:Database",
+		RFunc: newSQLDatabase,
+	}
+}
+
+func newSQLDatabase(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	licenceType := "LicenseIncluded"
+	if !d.IsEmpty("licenseType") {
+		licenceType = d.Get("licenseType").String()
+	}
+
+	var maxSizeGB *float64
+	if maxBytes := d.Get("maxSizeBytes").Float(); maxBytes > 0 {
+		maxSizeGB = floatPtr(maxBytes / gbInBytes)
+	}
+
+	var readReplicas *int64
+	if d.Get("highAvailabilityReplicaCount").Exists() {
+		readReplicas = intPtr(d.Get("highAvailabilityReplicaCount").Int())
+	} else if strings.EqualFold(d.Get("readScale").String(), "Enabled") {
+		readReplicas = intPtr(1)
+	}
+
+	r := &azure.SQLDatabase{
+		Address:          d.Address,
+		Region:           lookupRegion(d),
+		LicenceType:      licenceType,
+		MaxSizeGB:        maxSizeGB,
+		ReadReplicaCount: readReplicas,
+		ZoneRedundant:    d.Get("zoneRedundant").Bool(),
+	}
+
+	// Databases default to the General Purpose Gen5 SKU with 2 vCores
+	skuName := d.Get("sku.name").String()
+	tier := d.Get("sku.tier").String()
+	if skuName == "" && tier == "" {
+		skuName = "GP_Gen5_2"
+		tier = "GeneralPurpose"
+	}
+
+	r.SKU = skuName
+
+	if t, ok := sqlTierMapping[tier]; ok {
+		r.Tier = t
+		if strings.HasPrefix(skuName, "GP_S_") {
+			r.Tier = "General Purpose - Serverless"
+		}
+
+		family := "Gen5"
+		if !d.IsEmpty("sku.family") {
+			family = d.Get("sku.family").String()
+		}
+		r.Family = sqlFamilyMapping[family]
+
+		cores := int64(2)
+		if d.Get("sku.capacity").Exists() {
+			cores = d.Get("sku.capacity").Int()
+		}
+		r.Cores = intPtr(cores)
+	}
+
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
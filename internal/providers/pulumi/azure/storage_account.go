@@ -0,0 +1,56 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getStorageAccountRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azure-native:storage:StorageAccount",
+		RFunc: newStorageAccount,
+	}
+}
+
+func newStorageAccount(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	accountKind := "StorageV2"
+	if !d.IsEmpty("kind") {
+		accountKind = d.Get("kind").String()
+	}
+
+	// The SKU name is the tier and the replication type, e.g. Standard_RAGRS
+	accountTier := "Standard"
+	accountReplicationType := "LRS"
+	if parts := strings.SplitN(d.Get("sku.name").String(), "_", 2); len(parts) == 2 {
+		accountTier = parts[0]
+		accountReplicationType = parts[1]
+	}
+
+	switch strings.ToLower(accountReplicationType) {
+	case "ragrs":
+		accountReplicationType = "RA-GRS"
+	case "ragzrs":
+		accountReplicationType = "RA-GZRS"
+	}
+
+	accessTier := "Hot"
+	if !d.IsEmpty("accessTier") {
+		accessTier = d.Get("accessTier").String()
+	}
+
+	r := &azure.StorageAccount{
+		Address:                d.Address,
+		Region:                 lookupRegion(d),
+		ID:                     d.Get("id").String(),
+		AccessTier:             accessTier,
+		AccountKind:            accountKind,
+		AccountReplicationType: accountReplicationType,
+		AccountTier:            accountTier,
+		NFSv3:                  d.Get("enableNfsV3").Bool(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
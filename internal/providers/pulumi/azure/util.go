@@ -0,0 +1,27 @@
+package azure
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func intPtr(i int64) *int64 {
+	return &i
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// lookupRegion returns the location of the resource, or the location of its
+// provider if it doesn't have one.
+func lookupRegion(d *schema.ResourceData) string {
+	if d.Get("location").String() != "" {
+		return d.Get("location").String()
+	}
+
+	defaultRegion := d.Get("region").String()
+	log.Warnf("Using %s for resource %s as its 'location' property could not be found.", defaultRegion, d.Address)
+	return defaultRegion
+}
@@ -0,0 +1,30 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getArtifactRegistryRepositoryRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "gcp:artifactregistry/repository:Repository",
+		RFunc: newArtifactRegistryRepository,
+	}
+}
+
+func newArtifactRegistryRepository(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	location := d.Get("location").String()
+	if location != "" {
+		region = location
+	}
+
+	r := &google.ArtifactRegistryRepository{
+		Address: d.Address,
+		Region:  region,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,26 @@
+package google
+
+import "github.com/infracost/infracost/internal/schema"
+
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getArtifactRegistryRepositoryRegistryItem(),
+	getSecretManagerSecretRegistryItem(),
+	getSecretManagerSecretVersionRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	// Google Cloud IAM
+	"gcp:projects/iAMBinding:IAMBinding",
+	"gcp:projects/iAMMember:IAMMember",
+	"gcp:serviceaccount/account:Account",
+
+	// Google Compute
+	"gcp:compute/firewall:Firewall",
+	"gcp:compute/network:Network",
+	"gcp:compute/subnetwork:Subnetwork",
+
+	// Google Secret Manager
+	"gcp:secretmanager/secretIamBinding:SecretIamBinding",
+	"gcp:secretmanager/secretIamMember:SecretIamMember",
+}
@@ -0,0 +1,34 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSecretManagerSecretRegistryItem() *schema.RegistryItem {
+	rfunc := func(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+		r := newSecretManagerSecret(d)
+		r.PopulateUsage(u)
+
+		return r.BuildResource()
+	}
+
+	return &schema.RegistryItem{
+		Name:  "gcp:secretmanager/secret:Secret",
+		RFunc: rfunc,
+	}
+}
+
+func newSecretManagerSecret(d *schema.ResourceData) *google.SecretManagerSecret {
+	replicasCount := int64(1)
+
+	if replicas := d.Get("replication.userManaged.replicas").Array(); len(replicas) > 0 {
+		replicasCount = int64(len(replicas))
+	}
+
+	return &google.SecretManagerSecret{
+		Address:              d.Address,
+		Region:               d.Get("region").String(),
+		ReplicationLocations: replicasCount,
+	}
+}
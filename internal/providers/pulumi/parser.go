@@ -0,0 +1,486 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// unknownValue is the value Pulumi uses in a preview for outputs that aren't
+// known until the resource is created.
+const unknownValue = "04da6b54-80e4-46f7-96ec-b56ff0331ba9"
+
+const providerTypePrefix = "pulumi:providers:"
+
+// defaultRegions are the regions used for resources when neither the resource
+// nor its provider set one. The AWS region can also be set with the usual
+// environment variables.
+var defaultRegions = map[string]string{
+	"aws":          "us-east-1",
+	"azure-native": "eastus",
+	"gcp":          "us-central1",
+}
+
+// resourceState is the state of a resource in `pulumi preview --json` and
+// `pulumi stack export`.
+type resourceState struct {
+	URN      string                 `json:"urn"`
+	Custom   bool                   `json:"custom"`
+	Delete   bool                   `json:"delete"`
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Inputs   map[string]interface{} `json:"inputs"`
+	Outputs  map[string]interface{} `json:"outputs"`
+	Provider string                 `json:"provider"`
+	// PropertyDependencies are the URNs of the resources each property
+	// depends on, which are used to add the references.
+	PropertyDependencies map[string][]string `json:"propertyDependencies"`
+}
+
+// previewStep is a step in the output of `pulumi preview --json`.
+type previewStep struct {
+	Op       string         `json:"op"`
+	URN      string         `json:"urn"`
+	OldState *resourceState `json:"oldState"`
+	NewState *resourceState `json:"newState"`
+}
+
+type previewJSON struct {
+	Config map[string]interface{} `json:"config"`
+	Steps  []previewStep          `json:"steps"`
+}
+
+type stackExportJSON struct {
+	Version    int `json:"version"`
+	Deployment struct {
+		Resources []*resourceState `json:"resources"`
+	} `json:"deployment"`
+}
+
+// stackInfo is the Pulumi stack and project the resources are in, which are
+// parsed from the resource URNs.
+type stackInfo struct {
+	Stack   string
+	Project string
+}
+
+type Parser struct {
+	ctx *config.ProjectContext
+}
+
+func NewParser(ctx *config.ProjectContext) *Parser {
+	return &Parser{ctx}
+}
+
+func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	registryMap := GetResourceRegistryMap()
+
+	if registryItem, ok := (*registryMap)[d.Type]; ok {
+		if registryItem.NoPrice {
+			return &schema.Resource{
+				Name:         d.Address,
+				ResourceType: d.Type,
+				Tags:         d.Tags,
+				IsSkipped:    true,
+				NoPrice:      true,
+				SkipMessage:  "Free resource.",
+			}
+		}
+
+		res := registryItem.RFunc(d, u)
+		if res != nil {
+			res.ResourceType = d.Type
+			res.Tags = d.Tags
+			res.SetRebuildFunc(u, func(u *schema.UsageData) *schema.Resource {
+				return registryItem.RFunc(d, u)
+			})
+			res.CloudResourceIDs = cloudResourceIDs(d)
+			if u != nil {
+				res.EstimationSummary = u.CalcEstimationSummary()
+				res.EstimatedUsageKeys = u.EstimatedKeys
+			}
+			return res
+		}
+	}
+
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		SkipMessage:  "This resource is not currently supported",
+	}
+}
+
+// cloudResourceIDs returns the IDs of the deployed resource, so it can be
+// matched to the line items in cloud billing exports.
+func cloudResourceIDs(d *schema.ResourceData) []string {
+	ids := make([]string, 0)
+	for _, attr := range []string{"id", "arn", "selfLink"} {
+		if v := d.Get(attr).String(); v != "" {
+			ids = append(ids, v)
+		}
+	}
+
+	return ids
+}
+
+// parsePreviewJSON returns the resources before and after the changes in the
+// output of `pulumi preview --json`.
+func (p *Parser) parsePreviewJSON(j []byte, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, stackInfo, error) {
+	var preview previewJSON
+	if err := json.Unmarshal(j, &preview); err != nil {
+		return nil, nil, stackInfo{}, errors.Wrap(err, "Error unmarshalling Pulumi preview JSON")
+	}
+
+	var past, current []*resourceState
+
+	for _, step := range preview.Steps {
+		switch step.Op {
+		case "replace":
+			// Pulumi also emits create-replacement and delete-replaced steps
+			// for each replacement, which add the new and old states
+			continue
+		case "same", "update", "refresh":
+			if step.OldState != nil {
+				past = append(past, step.OldState)
+			}
+			if step.NewState != nil {
+				current = append(current, step.NewState)
+			}
+		case "create", "create-replacement", "import", "import-replacement":
+			if step.NewState != nil {
+				current = append(current, step.NewState)
+			}
+		case "delete", "delete-replaced", "discard", "discard-replaced":
+			if step.OldState != nil {
+				past = append(past, step.OldState)
+			}
+		default:
+			log.Debugf("Skipping Pulumi %s step for %s", step.Op, step.URN)
+		}
+	}
+
+	info := stackInfo{}
+	for _, step := range preview.Steps {
+		if info = parseStackInfo(step.URN); info.Stack != "" {
+			break
+		}
+	}
+
+	// The addresses are derived from all the resources in the preview so a
+	// resource has the same address before and after the changes
+	addresses := resourceAddresses(append(append([]*resourceState{}, past...), current...))
+
+	pastResources := p.parseResources(past, addresses, preview.Config, usage)
+	resources := p.parseResources(current, addresses, preview.Config, usage)
+
+	return pastResources, resources, info, nil
+}
+
+// parseStackExportJSON returns the resources in the output of
+// `pulumi stack export`. Since this is the deployed state the past resources
+// are the same as the current ones.
+func (p *Parser) parseStackExportJSON(j []byte, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, stackInfo, error) {
+	var export stackExportJSON
+	if err := json.Unmarshal(j, &export); err != nil {
+		return nil, nil, stackInfo{}, errors.Wrap(err, "Error unmarshalling Pulumi stack export JSON")
+	}
+
+	states := make([]*resourceState, 0, len(export.Deployment.Resources))
+	info := stackInfo{}
+
+	for _, s := range export.Deployment.Resources {
+		if info.Stack == "" {
+			info = parseStackInfo(s.URN)
+		}
+
+		// Resources pending deletion have already been replaced
+		if s.Delete {
+			continue
+		}
+
+		states = append(states, s)
+	}
+
+	resources := p.parseResources(states, resourceAddresses(states), map[string]interface{}{}, usage)
+
+	return resources, resources, info, nil
+}
+
+func (p *Parser) parseResources(states []*resourceState, addresses map[string]string, cfg map[string]interface{}, usage map[string]*schema.UsageData) []*schema.Resource {
+	resources := make([]*schema.Resource, 0, len(states))
+
+	providerRegions := make(map[string]string)
+	for _, s := range states {
+		if strings.HasPrefix(s.Type, providerTypePrefix) {
+			if region := providerRegion(s); region != "" {
+				providerRegions[providerReference(s)] = region
+			}
+		}
+	}
+
+	resourceData := make(map[string]*schema.ResourceData, len(states))
+	urns := make([]string, 0, len(states))
+
+	for _, s := range states {
+		if !s.Custom || strings.HasPrefix(s.Type, providerTypePrefix) {
+			continue
+		}
+
+		address := addresses[s.URN]
+		values := resourceValues(s)
+		providerName := packageName(s.Type)
+
+		d := schema.NewResourceData(s.Type, providerName, address, parseTags(values), gjson.ParseBytes(mustMarshal(values)))
+		// Some resources, e.g. most GCP resources, have their own region
+		if d.IsEmpty("region") {
+			d.Set("region", p.resourceRegion(s, providerName, providerRegions, cfg))
+		}
+		if s.ID != "" {
+			d.Set("id", s.ID)
+		}
+
+		resourceData[s.URN] = d
+		urns = append(urns, s.URN)
+	}
+
+	registryMap := GetResourceRegistryMap()
+
+	for _, s := range states {
+		d, ok := resourceData[s.URN]
+		if !ok {
+			continue
+		}
+
+		registryItem, ok := (*registryMap)[d.Type]
+		if !ok {
+			continue
+		}
+
+		for _, attr := range registryItem.ReferenceAttributes {
+			for _, urn := range s.PropertyDependencies[attr] {
+				if refData, ok := resourceData[urn]; ok {
+					d.AddReference(attr, refData)
+				}
+			}
+		}
+	}
+
+	for _, urn := range urns {
+		d := resourceData[urn]
+		if r := p.createResource(d, usage[d.Address]); r != nil {
+			resources = append(resources, r)
+		}
+	}
+
+	return resources
+}
+
+// resourceRegion returns the region of the resource's provider, or the region
+// set in the stack config for the default provider.
+func (p *Parser) resourceRegion(s *resourceState, providerName string, providerRegions map[string]string, cfg map[string]interface{}) string {
+	if region := providerRegions[s.Provider]; region != "" {
+		return region
+	}
+
+	for _, key := range []string{"region", "location"} {
+		if region, ok := cfg[fmt.Sprintf("%s:%s", providerName, key)].(string); ok && region != "" {
+			return region
+		}
+	}
+
+	if providerName == "aws" {
+		for _, key := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+			if r := p.ctx.ProjectConfig.Env[key]; r != "" {
+				return r
+			}
+			if r := os.Getenv(key); r != "" {
+				return r
+			}
+		}
+	}
+
+	return defaultRegions[providerName]
+}
+
+// resourceValues returns the properties of the resource. The outputs include
+// the provider defaults so these are used where they're known, otherwise the
+// inputs are used, e.g. for resources that are created by the preview.
+func resourceValues(s *resourceState) map[string]interface{} {
+	values := make(map[string]interface{}, len(s.Inputs)+len(s.Outputs))
+
+	for k, v := range s.Inputs {
+		if v = knownValue(v); v != nil {
+			values[k] = v
+		}
+	}
+
+	for k, v := range s.Outputs {
+		if v = knownValue(v); v != nil {
+			values[k] = v
+		}
+	}
+
+	return values
+}
+
+// knownValue returns the value with any unknown values removed, or nil if the
+// value is unknown.
+func knownValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		if t == unknownValue {
+			return nil
+		}
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			if val = knownValue(val); val != nil {
+				m[k] = val
+			}
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, 0, len(t))
+		for _, val := range t {
+			if val = knownValue(val); val != nil {
+				l = append(l, val)
+			}
+		}
+		return l
+	}
+
+	return v
+}
+
+func mustMarshal(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Debugf("Error marshalling Pulumi resource properties: %s", err)
+		return []byte("{}")
+	}
+
+	return b
+}
+
+// parseTags returns the tags of the resource. The AWS, Azure Native and GCP
+// providers all use a map of tags, or labels for GCP.
+func parseTags(values map[string]interface{}) map[string]string {
+	tags := make(map[string]string)
+
+	for _, key := range []string{"tags", "labels"} {
+		m, ok := values[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for k, v := range m {
+			tags[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return tags
+}
+
+func providerRegion(s *resourceState) string {
+	values := resourceValues(s)
+	for _, key := range []string{"region", "location"} {
+		if region, ok := values[key].(string); ok && region != "" {
+			return region
+		}
+	}
+
+	return ""
+}
+
+// providerReference returns the reference resources use for the provider,
+// which is the provider's URN and ID.
+func providerReference(s *resourceState) string {
+	return fmt.Sprintf("%s::%s", s.URN, s.ID)
+}
+
+// packageName returns the Pulumi package of the resource type, e.g. aws for
+// aws:ec2/instance:Instance.
+func packageName(resourceType string) string {
+	return strings.SplitN(resourceType, ":", 2)[0]
+}
+
+// resourceName returns the name of the resource from its URN, which has the
+// format urn:pulumi:<stack>::<project>::<qualified type>::<name>.
+func resourceName(urn string) string {
+	parts := strings.Split(urn, "::")
+	return parts[len(parts)-1]
+}
+
+// qualifiedName returns the qualified type and name from the URN, which
+// includes the types of the resource's parents, e.g.
+// my:component:Vpc$aws:ec2/subnet:Subnet::private.
+func qualifiedName(urn string) string {
+	parts := strings.Split(urn, "::")
+	if len(parts) < 4 {
+		return urn
+	}
+
+	return strings.Join(parts[2:], "::")
+}
+
+// resourceAddresses returns the address for each resource URN. Names only
+// need to be unique for each resource type, so when a name is used by more
+// than one resource the address is prefixed with the resource type, or if
+// that is still ambiguous, the qualified type from the URN. Since this only
+// depends on the set of URNs and not their order the addresses are stable
+// between the past and current resources.
+func resourceAddresses(states []*resourceState) map[string]string {
+	types := make(map[string]string, len(states))
+	for _, s := range states {
+		if !s.Custom || strings.HasPrefix(s.Type, providerTypePrefix) {
+			continue
+		}
+		types[s.URN] = s.Type
+	}
+
+	names := make(map[string]int, len(types))
+	typedNames := make(map[string]int, len(types))
+	for urn, t := range types {
+		names[resourceName(urn)]++
+		typedNames[fmt.Sprintf("%s::%s", t, resourceName(urn))]++
+	}
+
+	addresses := make(map[string]string, len(types))
+	for urn, t := range types {
+		name := resourceName(urn)
+		typedName := fmt.Sprintf("%s::%s", t, name)
+
+		switch {
+		case names[name] == 1:
+			addresses[urn] = name
+		case typedNames[typedName] == 1:
+			addresses[urn] = typedName
+		default:
+			addresses[urn] = qualifiedName(urn)
+		}
+	}
+
+	return addresses
+}
+
+func parseStackInfo(urn string) stackInfo {
+	parts := strings.Split(urn, "::")
+	if len(parts) < 4 || !strings.HasPrefix(parts[0], "urn:pulumi:") {
+		return stackInfo{}
+	}
+
+	return stackInfo{
+		Stack:   strings.TrimPrefix(parts[0], "urn:pulumi:"),
+		Project: parts[1],
+	}
+}
@@ -0,0 +1,250 @@
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const testPreviewJSON = `{
+  "config": {"aws:region": "us-west-2", "gcp:region": "europe-west1"},
+  "steps": [
+    {
+      "op": "same",
+      "urn": "urn:pulumi:dev::shop::pulumi:pulumi:Stack::shop-dev",
+      "oldState": {"urn": "urn:pulumi:dev::shop::pulumi:pulumi:Stack::shop-dev", "custom": false, "type": "pulumi:pulumi:Stack"},
+      "newState": {"urn": "urn:pulumi:dev::shop::pulumi:pulumi:Stack::shop-dev", "custom": false, "type": "pulumi:pulumi:Stack"}
+    },
+    {
+      "op": "same",
+      "urn": "urn:pulumi:dev::shop::pulumi:providers:aws::eu",
+      "oldState": {"urn": "urn:pulumi:dev::shop::pulumi:providers:aws::eu", "custom": true, "id": "p1", "type": "pulumi:providers:aws", "inputs": {"region": "eu-west-1"}},
+      "newState": {"urn": "urn:pulumi:dev::shop::pulumi:providers:aws::eu", "custom": true, "id": "p1", "type": "pulumi:providers:aws", "inputs": {"region": "eu-west-1"}}
+    },
+    {
+      "op": "update",
+      "urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web",
+      "oldState": {
+        "urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web",
+        "custom": true,
+        "id": "i-123",
+        "type": "aws:ec2/instance:Instance",
+        "provider": "urn:pulumi:dev::shop::pulumi:providers:aws::eu::p1",
+        "inputs": {"instanceType": "t3.micro", "ami": "ami-123"},
+        "outputs": {"instanceType": "t3.micro", "arn": "arn:aws:ec2:eu-west-1:123456789012:instance/i-123", "tags": {"Team": "web"}}
+      },
+      "newState": {
+        "urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web",
+        "custom": true,
+        "id": "i-123",
+        "type": "aws:ec2/instance:Instance",
+        "provider": "urn:pulumi:dev::shop::pulumi:providers:aws::eu::p1",
+        "inputs": {"instanceType": "m5.large", "ami": "ami-123", "tags": {"Team": "web"}},
+        "outputs": {"arn": "04da6b54-80e4-46f7-96ec-b56ff0331ba9"}
+      }
+    },
+    {
+      "op": "create",
+      "urn": "urn:pulumi:dev::shop::aws:lambda/function:Function::api",
+      "newState": {
+        "urn": "urn:pulumi:dev::shop::aws:lambda/function:Function::api",
+        "custom": true,
+        "type": "aws:lambda/function:Function",
+        "inputs": {"memorySize": 512, "name": "04da6b54-80e4-46f7-96ec-b56ff0331ba9"}
+      }
+    },
+    {
+      "op": "delete",
+      "urn": "urn:pulumi:dev::shop::aws:sqs/queue:Queue::jobs",
+      "oldState": {"urn": "urn:pulumi:dev::shop::aws:sqs/queue:Queue::jobs", "custom": true, "id": "q", "type": "aws:sqs/queue:Queue"}
+    },
+    {
+      "op": "create",
+      "urn": "urn:pulumi:dev::shop::gcp:secretmanager/secret:Secret::token",
+      "newState": {
+        "urn": "urn:pulumi:dev::shop::gcp:secretmanager/secret:Secret::token",
+        "custom": true,
+        "type": "gcp:secretmanager/secret:Secret",
+        "inputs": {"replication": {"userManaged": {"replicas": [{"location": "europe-west1"}, {"location": "europe-west2"}]}}}
+      }
+    },
+    {
+      "op": "create",
+      "urn": "urn:pulumi:dev::shop::gcp:secretmanager/secretVersion:SecretVersion::token",
+      "newState": {
+        "urn": "urn:pulumi:dev::shop::gcp:secretmanager/secretVersion:SecretVersion::token",
+        "custom": true,
+        "type": "gcp:secretmanager/secretVersion:SecretVersion",
+        "inputs": {"secret": "04da6b54-80e4-46f7-96ec-b56ff0331ba9"},
+        "propertyDependencies": {"secret": ["urn:pulumi:dev::shop::gcp:secretmanager/secret:Secret::token"]}
+      }
+    }
+  ],
+  "changeSummary": {"create": 3, "delete": 1, "same": 2, "update": 1}
+}`
+
+func newTestParser() *Parser {
+	return NewParser(&config.ProjectContext{ProjectConfig: &config.Project{}})
+}
+
+func findResource(resources []*schema.Resource, name string) *schema.Resource {
+	for _, r := range resources {
+		if r.Name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func TestParsePreviewJSON(t *testing.T) {
+	past, resources, info, err := newTestParser().parsePreviewJSON([]byte(testPreviewJSON), map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	assert.Equal(t, stackInfo{Stack: "dev", Project: "shop"}, info)
+
+	require.Len(t, past, 2)
+	assert.NotNil(t, findResource(past, "web"))
+	assert.NotNil(t, findResource(past, "jobs"))
+
+	require.Len(t, resources, 4)
+	assert.Nil(t, findResource(resources, "jobs"))
+
+	web := findResource(resources, "web")
+	require.NotNil(t, web)
+	assert.Equal(t, "aws:ec2/instance:Instance", web.ResourceType)
+	assert.Equal(t, map[string]string{"Team": "web"}, web.Tags)
+	assert.Equal(t, []string{"i-123"}, web.CloudResourceIDs)
+	assert.Equal(t, []string{"i-123", "arn:aws:ec2:eu-west-1:123456789012:instance/i-123"}, findResource(past, "web").CloudResourceIDs)
+
+	// The secret version has the same name as the secret
+	assert.Nil(t, findResource(resources, "token"))
+	assert.NotNil(t, findResource(resources, "gcp:secretmanager/secret:Secret::token"))
+	assert.NotNil(t, findResource(resources, "gcp:secretmanager/secretVersion:SecretVersion::token"))
+}
+
+func TestParsePreviewJSONReplacement(t *testing.T) {
+	oldState := `{"urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web", "custom": true, "id": "i-123", "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}`
+	newState := `{"urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web", "custom": true, "type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "m5.large"}}`
+
+	past, resources, _, err := newTestParser().parsePreviewJSON([]byte(`{
+  "config": {"aws:region": "us-west-2"},
+  "steps": [
+    {"op": "create-replacement", "urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web", "oldState": `+oldState+`, "newState": `+newState+`},
+    {"op": "replace", "urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web", "oldState": `+oldState+`, "newState": `+newState+`},
+    {"op": "delete-replaced", "urn": "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web", "oldState": `+oldState+`}
+  ]
+}`), map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	require.Len(t, past, 1)
+	assert.Equal(t, "web", past[0].Name)
+	assert.Equal(t, []string{"i-123"}, past[0].CloudResourceIDs)
+
+	require.Len(t, resources, 1)
+	assert.Equal(t, "web", resources[0].Name)
+	assert.Empty(t, resources[0].CloudResourceIDs)
+}
+
+func TestParsePreviewJSONSameNameReplacement(t *testing.T) {
+	secret := `{"urn": "urn:pulumi:dev::shop::gcp:secretmanager/secret:Secret::token", "custom": true, "id": "s", "type": "gcp:secretmanager/secret:Secret"}`
+	oldVersion := `{"urn": "urn:pulumi:dev::shop::gcp:secretmanager/secretVersion:SecretVersion::token", "custom": true, "id": "v1", "type": "gcp:secretmanager/secretVersion:SecretVersion"}`
+	newVersion := `{"urn": "urn:pulumi:dev::shop::gcp:secretmanager/secretVersion:SecretVersion::token", "custom": true, "type": "gcp:secretmanager/secretVersion:SecretVersion"}`
+
+	// The replaced secret version comes before the secret in the current
+	// resources but after it in the past resources
+	past, resources, _, err := newTestParser().parsePreviewJSON([]byte(`{
+  "steps": [
+    {"op": "create-replacement", "urn": "urn:pulumi:dev::shop::gcp:secretmanager/secretVersion:SecretVersion::token", "oldState": `+oldVersion+`, "newState": `+newVersion+`},
+    {"op": "same", "urn": "urn:pulumi:dev::shop::gcp:secretmanager/secret:Secret::token", "oldState": `+secret+`, "newState": `+secret+`},
+    {"op": "delete-replaced", "urn": "urn:pulumi:dev::shop::gcp:secretmanager/secretVersion:SecretVersion::token", "oldState": `+oldVersion+`}
+  ]
+}`), map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	for _, rs := range [][]*schema.Resource{past, resources} {
+		require.Len(t, rs, 2)
+
+		secret := findResource(rs, "gcp:secretmanager/secret:Secret::token")
+		require.NotNil(t, secret)
+		assert.Equal(t, "gcp:secretmanager/secret:Secret", secret.ResourceType)
+
+		version := findResource(rs, "gcp:secretmanager/secretVersion:SecretVersion::token")
+		require.NotNil(t, version)
+		assert.Equal(t, "gcp:secretmanager/secretVersion:SecretVersion", version.ResourceType)
+	}
+}
+
+func TestResourceAddresses(t *testing.T) {
+	states := []*resourceState{
+		{URN: "urn:pulumi:dev::shop::pulumi:providers:aws::web", Custom: true, Type: "pulumi:providers:aws"},
+		{URN: "urn:pulumi:dev::shop::pulumi:pulumi:Stack::web", Type: "pulumi:pulumi:Stack"},
+		{URN: "urn:pulumi:dev::shop::aws:ec2/instance:Instance::web", Custom: true, Type: "aws:ec2/instance:Instance"},
+		{URN: "urn:pulumi:dev::shop::aws:ec2/subnet:Subnet::private", Custom: true, Type: "aws:ec2/subnet:Subnet"},
+		{URN: "urn:pulumi:dev::shop::my:component:Vpc$aws:ec2/subnet:Subnet::private", Custom: true, Type: "aws:ec2/subnet:Subnet"},
+		{URN: "urn:pulumi:dev::shop::aws:ec2/eip:Eip::private", Custom: true, Type: "aws:ec2/eip:Eip"},
+	}
+
+	expected := map[string]string{
+		"urn:pulumi:dev::shop::aws:ec2/instance:Instance::web":                  "web",
+		"urn:pulumi:dev::shop::aws:ec2/subnet:Subnet::private":                  "aws:ec2/subnet:Subnet::private",
+		"urn:pulumi:dev::shop::my:component:Vpc$aws:ec2/subnet:Subnet::private": "my:component:Vpc$aws:ec2/subnet:Subnet::private",
+		"urn:pulumi:dev::shop::aws:ec2/eip:Eip::private":                        "aws:ec2/eip:Eip::private",
+	}
+
+	assert.Equal(t, expected, resourceAddresses(states))
+
+	// The addresses don't depend on the order of the resources
+	for i, j := 0, len(states)-1; i < j; i, j = i+1, j-1 {
+		states[i], states[j] = states[j], states[i]
+	}
+	assert.Equal(t, expected, resourceAddresses(states))
+}
+
+func TestParseResourcesRegion(t *testing.T) {
+	states := []*resourceState{
+		{URN: "urn:pulumi:dev::shop::pulumi:providers:aws::eu", Custom: true, ID: "p1", Type: "pulumi:providers:aws", Inputs: map[string]interface{}{"region": "eu-west-1"}},
+		{URN: "urn:pulumi:dev::shop::aws:sqs/queue:Queue::a", Custom: true, Type: "aws:sqs/queue:Queue", Provider: "urn:pulumi:dev::shop::pulumi:providers:aws::eu::p1"},
+		{URN: "urn:pulumi:dev::shop::aws:sqs/queue:Queue::b", Custom: true, Type: "aws:sqs/queue:Queue"},
+	}
+
+	p := newTestParser()
+	noProviders := map[string]string{}
+
+	assert.Equal(t, "eu-west-1", p.resourceRegion(states[1], "aws", map[string]string{providerReference(states[0]): "eu-west-1"}, nil))
+	assert.Equal(t, "us-west-2", p.resourceRegion(states[2], "aws", noProviders, map[string]interface{}{"aws:region": "us-west-2"}))
+	assert.Equal(t, "westeurope", p.resourceRegion(states[2], "azure-native", noProviders, map[string]interface{}{"azure-native:location": "westeurope"}))
+	assert.Equal(t, "us-central1", p.resourceRegion(states[2], "gcp", noProviders, nil))
+}
+
+func TestParseStackExportJSON(t *testing.T) {
+	past, resources, info, err := newTestParser().parseStackExportJSON([]byte(`{
+  "version": 3,
+  "deployment": {
+    "manifest": {"time": "2022-01-01T00:00:00Z"},
+    "resources": [
+      {"urn": "urn:pulumi:prod::shop::azure-native:storage:StorageAccount::assets", "custom": true, "id": "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/assets", "type": "azure-native:storage:StorageAccount", "outputs": {"location": "westeurope", "kind": "StorageV2", "sku": {"name": "Standard_RAGRS"}}},
+      {"urn": "urn:pulumi:prod::shop::azure-native:storage:StorageAccount::old", "custom": true, "delete": true, "type": "azure-native:storage:StorageAccount"}
+    ]
+  }
+}`), map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	assert.Equal(t, stackInfo{Stack: "prod", Project: "shop"}, info)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "assets", resources[0].Name)
+	assert.Equal(t, resources, past)
+}
+
+func TestKnownValue(t *testing.T) {
+	assert.Nil(t, knownValue(unknownValue))
+	assert.Equal(t, map[string]interface{}{"a": "b", "l": []interface{}{float64(1)}}, knownValue(map[string]interface{}{
+		"a": "b",
+		"c": unknownValue,
+		"l": []interface{}{float64(1), unknownValue},
+	}))
+}
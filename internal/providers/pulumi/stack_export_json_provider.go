@@ -0,0 +1,59 @@
+package pulumi
+
+import (
+	"os"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+)
+
+type StackExportJSONProvider struct {
+	ctx  *config.ProjectContext
+	Path string
+}
+
+func NewStackExportJSONProvider(ctx *config.ProjectContext) schema.Provider {
+	return &StackExportJSONProvider{
+		ctx:  ctx,
+		Path: ctx.ProjectConfig.Path,
+	}
+}
+
+func (p *StackExportJSONProvider) Type() string {
+	return "pulumi_stack_export_json"
+}
+
+func (p *StackExportJSONProvider) DisplayType() string {
+	return "Pulumi stack export JSON file"
+}
+
+func (p *StackExportJSONProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+func (p *StackExportJSONProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	j, err := os.ReadFile(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading Pulumi stack export JSON file")
+	}
+
+	parser := NewParser(p.ctx)
+	pastResources, resources, info, err := parser.parseStackExportJSON(j, usage)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error parsing Pulumi stack export JSON file")
+	}
+
+	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	metadata.PulumiStack = info.Stack
+	metadata.PulumiProject = info.Project
+	p.AddMetadata(metadata)
+	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	project := schema.NewProject(name, metadata)
+	project.PastResources = pastResources
+	project.Resources = resources
+
+	return []*schema.Project{project}, nil
+}
@@ -21,6 +21,7 @@ func GetAutoscalingGroupRegistryItem() *schema.RegistryItem {
 			"launch_template.0.id",
 			"launch_template.0.name",
 			"mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_id",
+			"mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_name",
 			"launch_template",
 		},
 	}
@@ -56,6 +57,9 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 		launchTemplateRef = d.References("launch_template.0.name")
 	}
 	mixedInstanceLaunchTemplateRef := d.References("mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_id")
+	if len(mixedInstanceLaunchTemplateRef) == 0 {
+		mixedInstanceLaunchTemplateRef = d.References("mixed_instances_policy.0.launch_template.0.launch_template_specification.0.launch_template_name")
+	}
 
 	if len(launchConfigurationRef) > 0 {
 		data := launchConfigurationRef[0]
@@ -191,17 +195,23 @@ func getInstanceTypeAndCount(mixedInstancePolicyData gjson.Result, capacity int6
 	override := mixedInstancePolicyData.Get("launch_template.0.override.0")
 	if override.Exists() {
 		instanceType = override.Get("instance_type").String()
-		weightedCapacity := int64(1)
-		if override.Get("weighted_capacity").Type != gjson.Null {
-			weightedCapacity = override.Get("weighted_capacity").Int()
-		}
-
-		if weightedCapacity == 0 {
-			count = int64(0)
-		} else {
-			count = decimal.NewFromInt(capacity).Div(decimal.NewFromInt(weightedCapacity)).Ceil().IntPart()
-		}
+		count = weightedInstanceCount(capacity, override.Get("weighted_capacity"))
 	}
 
 	return instanceType, count
 }
+
+// weightedInstanceCount returns the number of instances needed for the
+// capacity when each instance provides the weighted capacity.
+func weightedInstanceCount(capacity int64, weightedCapacity gjson.Result) int64 {
+	weight := decimal.NewFromInt(1)
+	if weightedCapacity.Type != gjson.Null {
+		weight = decimal.NewFromFloat(weightedCapacity.Float())
+	}
+
+	if weight.IsZero() {
+		return 0
+	}
+
+	return decimal.NewFromInt(capacity).Div(weight).Ceil().IntPart()
+}
@@ -38,6 +38,9 @@ func NewEKSNodeGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resour
 		DiskSize:      diskSize,
 	}
 
+	// Node groups with a SPOT capacity type only use spot instances
+	capacityType := strings.ToLower(d.Get("capacity_type").String())
+
 	launchTemplateRefID := d.References("launch_template.0.id")
 	launchTemplateRefName := d.References("launch_template.0.name")
 	launchTemplateRef := []*schema.ResourceData{}
@@ -52,7 +55,7 @@ func NewEKSNodeGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resour
 		data := launchTemplateRef[0]
 
 		onDemandPercentageAboveBaseCount := int64(100)
-		if strings.ToLower(launchTemplateRef[0].Get("instance_market_options.0.market_type").String()) == "spot" {
+		if capacityType == "spot" || strings.ToLower(launchTemplateRef[0].Get("instance_market_options.0.market_type").String()) == "spot" {
 			onDemandPercentageAboveBaseCount = int64(0)
 		}
 
@@ -68,7 +71,7 @@ func NewEKSNodeGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resour
 		}
 
 		a.InstanceType = instanceType
-		a.PurchaseOption = strings.ToLower(d.Get("capacity_type").String())
+		a.PurchaseOption = capacityType
 	}
 
 	a.PopulateUsage(u)
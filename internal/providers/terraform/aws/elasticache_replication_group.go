@@ -1,9 +1,9 @@
 package aws
 
 import (
-	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 
+	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/infracost/infracost/internal/schema"
 )
 
@@ -16,7 +16,7 @@ func GetElastiCacheReplicationGroupItem() *schema.RegistryItem {
 
 func NewElastiCacheReplicationGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	nodeType := d.Get("node_type").String()
-	var cacheNodes decimal.Decimal
+	var cacheNodes int64
 	cacheEngine := "redis"
 
 	if d.Get("engine").Exists() {
@@ -36,12 +36,22 @@ func NewElastiCacheReplicationGroup(d *schema.ResourceData, u *schema.UsageData)
 	// This will only be present in a state/diff run and won't be available in breakdown or output runs.
 	clusterDisabled := d.Get("cluster_enabled").Type != gjson.Null && !d.Get("cluster_enabled").Bool()
 	if d.Get("cluster_mode").Exists() && !clusterDisabled {
-		nodeGroups := decimal.NewFromInt(d.Get("cluster_mode.0.num_node_groups").Int())
-		shards := decimal.NewFromInt(d.Get("cluster_mode.0.replicas_per_node_group").Int())
-		cacheNodes = nodeGroups.Mul(shards).Add(nodeGroups)
+		nodeGroups := d.Get("cluster_mode.0.num_node_groups").Int()
+		shards := d.Get("cluster_mode.0.replicas_per_node_group").Int()
+		cacheNodes = nodeGroups*shards + nodeGroups
 	} else {
-		cacheNodes = decimal.NewFromInt(d.Get("number_cache_clusters").Int())
+		cacheNodes = d.Get("number_cache_clusters").Int()
 	}
 
-	return newElasticacheResource(d, u, nodeType, cacheNodes, cacheEngine)
+	a := &aws.ElastiCache{
+		Address:                d.Address,
+		Region:                 d.Get("region").String(),
+		NodeType:               nodeType,
+		Engine:                 cacheEngine,
+		CacheNodes:             cacheNodes,
+		SnapshotRetentionLimit: d.Get("snapshot_retention_limit").Int(),
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
 }
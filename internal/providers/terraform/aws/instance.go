@@ -24,13 +24,22 @@ func GetInstanceRegistryItem() *schema.RegistryItem {
 }
 
 func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
-	region := d.Get("region").String()
-
 	purchaseOption := "on_demand"
 	if d.Get("spot_price").String() != "" {
 		purchaseOption = "spot"
 	}
 
+	a := newInstance(d, purchaseOption)
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
+
+// newInstance returns the instance for an aws_instance or an
+// aws_spot_instance_request, which have the same instance attributes.
+func newInstance(d *schema.ResourceData, purchaseOption string) *aws.Instance {
+	region := d.Get("region").String()
+
 	a := &aws.Instance{
 		Address:          d.Address,
 		Region:           region,
@@ -78,8 +87,5 @@ func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		a.EBSBlockDevices = append(a.EBSBlockDevices, ebsBlockDevice)
 	}
 
-	a.PopulateUsage(u)
-
-	return a.BuildResource()
-
+	return a
 }
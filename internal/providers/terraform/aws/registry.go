@@ -78,6 +78,8 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getSNSTopicRegistryItem(),
 	getSNSTopicSubscriptionRegistryItem(),
 	getSQSQueueRegistryItem(),
+	getSpotFleetRequestRegistryItem(),
+	getSpotInstanceRequestRegistryItem(),
 	getNeptuneClusterRegistryItem(),
 	getNeptuneClusterInstanceRegistryItem(),
 	GetNeptuneClusterSnapshotRegistryItem(),
@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSpotFleetRequestRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "aws_spot_fleet_request",
+		Notes: []string{
+			"Only the first launch specification or launch template override is used to price the fleet's instances.",
+			"If there is no spot price for the instance type the on-demand price is used with the spot_discount_percent usage key applied.",
+		},
+		RFunc: NewSpotFleetRequest,
+		ReferenceAttributes: []string{
+			"launch_template_config.0.launch_template_specification.0.id",
+			"launch_template_config.0.launch_template_specification.0.name",
+		},
+	}
+}
+
+func NewSpotFleetRequest(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := &aws.SpotFleetRequest{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+
+	targetCapacity := d.Get("target_capacity").Int()
+	onDemandCapacity := d.Get("on_demand_target_capacity").Int()
+
+	launchTemplateRef := d.References("launch_template_config.0.launch_template_specification.0.id")
+	if len(launchTemplateRef) == 0 {
+		launchTemplateRef = d.References("launch_template_config.0.launch_template_specification.0.name")
+	}
+
+	if len(launchTemplateRef) > 0 {
+		data := launchTemplateRef[0]
+
+		override := d.Get("launch_template_config.0.overrides.0")
+		if override.Get("instance_type").String() != "" {
+			data.Set("instance_type", override.Get("instance_type").String())
+		}
+
+		weightedCapacity := override.Get("weighted_capacity")
+		instanceCount := weightedInstanceCount(targetCapacity, weightedCapacity)
+		onDemandCount := weightedInstanceCount(onDemandCapacity, weightedCapacity)
+
+		a.LaunchTemplate = newLaunchTemplate(data, u, a.Region, instanceCount, onDemandCount, int64(0))
+	} else if d.Get("launch_specification.0").Exists() {
+		a.LaunchTemplate = newSpotFleetLaunchSpecification(d.Get("launch_specification.0"), a.Region, targetCapacity, onDemandCapacity)
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
+
+// newSpotFleetLaunchSpecification returns a Launch Template for the instances of a launch specification, so the fleet's
+// spot and on-demand instances can be priced the same way as for a launch template.
+func newSpotFleetLaunchSpecification(spec gjson.Result, region string, targetCapacity, onDemandCapacity int64) *aws.LaunchTemplate {
+	weightedCapacity := spec.Get("weighted_capacity")
+
+	a := &aws.LaunchTemplate{
+		Address:                          "launch_specification",
+		Region:                           region,
+		AMI:                              spec.Get("ami").String(),
+		InstanceCount:                    intPtr(weightedInstanceCount(targetCapacity, weightedCapacity)),
+		OnDemandBaseCount:                weightedInstanceCount(onDemandCapacity, weightedCapacity),
+		OnDemandPercentageAboveBaseCount: int64(0),
+		Tenancy:                          spec.Get("placement_tenancy").String(),
+		InstanceType:                     spec.Get("instance_type").String(),
+		EBSOptimized:                     spec.Get("ebs_optimized").Bool(),
+		EnableMonitoring:                 spec.Get("monitoring").Bool(),
+	}
+
+	a.RootBlockDevice = &aws.EBSVolume{
+		Address: "root_block_device",
+		Region:  region,
+		Type:    spec.Get("root_block_device.0.volume_type").String(),
+		IOPS:    spec.Get("root_block_device.0.iops").Int(),
+	}
+
+	if spec.Get("root_block_device.0.volume_size").Type != gjson.Null {
+		a.RootBlockDevice.Size = intPtr(spec.Get("root_block_device.0.volume_size").Int())
+	}
+
+	for i, data := range spec.Get("ebs_block_device").Array() {
+		ebsBlockDevice := &aws.EBSVolume{
+			Address: fmt.Sprintf("ebs_block_device[%d]", i),
+			Region:  region,
+			Type:    data.Get("volume_type").String(),
+			IOPS:    data.Get("iops").Int(),
+		}
+
+		if data.Get("volume_size").Type != gjson.Null {
+			ebsBlockDevice.Size = intPtr(data.Get("volume_size").Int())
+		}
+
+		a.EBSBlockDevices = append(a.EBSBlockDevices, ebsBlockDevice)
+	}
+
+	return a
+}
@@ -0,0 +1,26 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSpotInstanceRequestRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "aws_spot_instance_request",
+		Notes: []string{
+			"Costs associated with marketplace AMIs are not supported.",
+			"For non-standard Linux AMIs such as Windows and RHEL, the operating system should be specified in usage file.",
+			"If there is no spot price for the instance type the on-demand price is used with the spot_discount_percent usage key applied.",
+			"If a root volume is not specified then an 8Gi gp2 volume is assumed.",
+		},
+		RFunc:               NewSpotInstanceRequest,
+		ReferenceAttributes: []string{"ebs_block_device.#.volume_id"},
+	}
+}
+
+func NewSpotInstanceRequest(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := newInstance(d, "spot")
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
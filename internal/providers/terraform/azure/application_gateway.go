@@ -1,11 +1,14 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
@@ -91,9 +94,30 @@ func NewAzureRMApplicationGateway(d *schema.ResourceData, u *schema.UsageData) *
 
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		resourceID := d.Get("id").String()
+
+		bytes, err := azure.ApplicationGatewayGetBytesProcessed(ctx, resourceID)
+		if err != nil {
+			return err
+		}
+		values["monthly_data_processed_gb"] = int64(math.Ceil(bytes / 1024 / 1024 / 1024))
+
+		if sku == "v2" {
+			units, err := azure.ApplicationGatewayGetCapacityUnits(ctx, resourceID)
+			if err != nil {
+				return err
+			}
+			values["monthly_v2_capacity_units"] = int64(math.Round(units))
+		}
+
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
+		EstimateUsage:  estimate,
 	}
 }
 
@@ -1,10 +1,13 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
@@ -35,12 +38,35 @@ func NewAzureRMCosmosdb(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 		return &schema.Resource{
 			Name:           d.Address,
 			CostComponents: cosmosDBCostComponents(d, u, account),
+			EstimateUsage:  cosmosDBEstimateUsage(d),
 		}
 	}
 	log.Warnf("Skipping resource %s as its 'account_name' property could not be found.", d.Address)
 	return nil
 }
 
+// cosmosDBEstimateUsage estimates the request units and storage of the
+// database or collection from the Azure Monitor metrics of its account.
+func cosmosDBEstimateUsage(d *schema.ResourceData) schema.EstimateFunc {
+	return func(ctx context.Context, values map[string]interface{}) error {
+		resourceID := d.Get("id").String()
+
+		units, err := azure.CosmosDBGetRequestUnits(ctx, resourceID)
+		if err != nil {
+			return err
+		}
+		values["monthly_serverless_request_units"] = int64(math.Round(units))
+
+		bytes, err := azure.CosmosDBGetDataUsageBytes(ctx, resourceID)
+		if err != nil {
+			return err
+		}
+		values["storage_gb"] = int64(math.Ceil(bytes / 1024 / 1024 / 1024))
+
+		return nil
+	}
+}
+
 func cosmosDBCostComponents(d *schema.ResourceData, u *schema.UsageData, account *schema.ResourceData) []*schema.CostComponent {
 	// Find the region in from the passed-in account
 	region := lookupRegion(account, []string{"account_name", "resource_group_name"})
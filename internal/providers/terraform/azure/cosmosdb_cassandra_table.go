@@ -24,6 +24,7 @@ func NewAzureRMCosmosdbCassandraTable(d *schema.ResourceData, u *schema.UsageDat
 			return &schema.Resource{
 				Name:           d.Address,
 				CostComponents: cosmosDBCostComponents(d, u, account),
+				EstimateUsage:  cosmosDBEstimateUsage(d),
 			}
 		}
 		log.Warnf("Skipping resource %s as its 'cassandra_keyspace_id.account_name' property could not be found.", d.Address)
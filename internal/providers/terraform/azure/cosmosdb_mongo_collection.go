@@ -25,6 +25,7 @@ func NewAzureRMCosmosdbMongoCollection(d *schema.ResourceData, u *schema.UsageDa
 			return &schema.Resource{
 				Name:           d.Address,
 				CostComponents: cosmosDBCostComponents(d, u, account),
+				EstimateUsage:  cosmosDBEstimateUsage(d),
 			}
 		}
 		log.Warnf("Skipping resource %s as its 'database_name.account_name' property could not be found.", d.Address)
@@ -0,0 +1,102 @@
+package azure_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/providers/terraform/azure"
+	"github.com/infracost/infracost/internal/schema"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
+)
+
+// stubMonitor returns a fake Azure Monitor server that responds with the
+// given time series data for each metric and checks the request is for the
+// expected resource ID.
+func stubMonitor(t *testing.T, resourceID string, values map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, resourceID+"/providers/Microsoft.Insights/metrics", r.URL.Path)
+
+		metric := r.URL.Query().Get("metricnames")
+		value, ok := values[metric]
+		if !ok {
+			t.Fatalf("received unexpected Azure Monitor query: %s", metric)
+		}
+
+		_, _ = w.Write([]byte(`{"value": [{"timeseries": [{"data": [` + value + `]}]}]}`))
+	}))
+}
+
+func estimateUsage(t *testing.T, server *httptest.Server, resource *schema.Resource) map[string]interface{} {
+	ctx := azureusage.WithTestEndpoint(context.TODO(), server.URL)
+
+	values := make(map[string]interface{})
+	err := resource.EstimateUsage(ctx, values)
+	require.NoError(t, err)
+
+	return values
+}
+
+func TestFunctionAppEstimateUsage(t *testing.T) {
+	id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/my-function"
+
+	server := stubMonitor(t, id, map[string]string{
+		"FunctionExecutionCount": `{"total": 600}, {"total": 400}`,
+		"FunctionExecutionUnits": `{"total": 64000000}`,
+	})
+	defer server.Close()
+
+	plan := schema.NewResourceData("azurerm_app_service_plan", "registry.terraform.io/hashicorp/azurerm", "azurerm_app_service_plan.plan", nil,
+		gjson.Parse(`{"kind": "FunctionApp", "sku": [{"tier": "Dynamic", "size": "Y1"}]}`))
+	d := schema.NewResourceData("azurerm_function_app", "registry.terraform.io/hashicorp/azurerm", "azurerm_function_app.function", nil,
+		gjson.Parse(`{"id": "`+id+`", "location": "eastus"}`))
+	d.AddReference("app_service_plan_id", plan)
+
+	values := estimateUsage(t, server, azure.NewAzureRMAppFunction(d, nil))
+	assert.Equal(t, int64(1000), values["monthly_executions"])
+	assert.Equal(t, int64(128), values["memory_mb"])
+	assert.Equal(t, int64(500), values["execution_duration_ms"])
+}
+
+func TestApplicationGatewayEstimateUsage(t *testing.T) {
+	id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/applicationGateways/my-gateway"
+
+	server := stubMonitor(t, id, map[string]string{
+		"BytesReceived": `{"total": 53687091200}`,
+		"BytesSent":     `{"total": 53687091200}`,
+		"CapacityUnits": `{"average": 2}, {"average": 4}`,
+	})
+	defer server.Close()
+
+	d := schema.NewResourceData("azurerm_application_gateway", "registry.terraform.io/hashicorp/azurerm", "azurerm_application_gateway.gateway", nil,
+		gjson.Parse(`{"id": "`+id+`", "location": "eastus", "sku": [{"name": "Standard_v2", "capacity": 2}]}`))
+
+	values := estimateUsage(t, server, azure.NewAzureRMApplicationGateway(d, nil))
+	assert.Equal(t, int64(100), values["monthly_data_processed_gb"])
+	assert.Equal(t, int64(2190), values["monthly_v2_capacity_units"])
+}
+
+func TestCosmosDBEstimateUsage(t *testing.T) {
+	accountID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.DocumentDB/databaseAccounts/my-account"
+
+	server := stubMonitor(t, accountID, map[string]string{
+		"TotalRequestUnits": `{"total": 7500000}, {"total": 2500000}`,
+		"DataUsage":         `{"average": 10737418240}`,
+	})
+	defer server.Close()
+
+	account := schema.NewResourceData("azurerm_cosmosdb_account", "registry.terraform.io/hashicorp/azurerm", "azurerm_cosmosdb_account.account", nil,
+		gjson.Parse(`{"id": "`+accountID+`", "location": "eastus", "geo_location": [{"location": "eastus"}]}`))
+	d := schema.NewResourceData("azurerm_cosmosdb_sql_container", "registry.terraform.io/hashicorp/azurerm", "azurerm_cosmosdb_sql_container.container", nil,
+		gjson.Parse(`{"id": "`+accountID+`/sqlDatabases/my-database/containers/my-container"}`))
+	d.AddReference("account_name", account)
+
+	values := estimateUsage(t, server, azure.NewAzureRMCosmosdb(d, nil))
+	assert.Equal(t, int64(10000000), values["monthly_serverless_request_units"])
+	assert.Equal(t, int64(10), values["storage_gb"])
+}
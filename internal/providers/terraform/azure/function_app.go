@@ -1,10 +1,13 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
@@ -90,12 +93,40 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 		return &schema.Resource{
 			Name:           d.Address,
 			CostComponents: costComponents,
+			EstimateUsage:  functionAppEstimateUsage(d.Get("id").String()),
 		}
 	}
 	log.Warnf("Skipping resource %s. Could not find a way to get its cost components from the resource or usage file.", d.Address)
 	return nil
 }
 
+// functionAppEstimateUsage estimates the executions from Azure Monitor.
+// Azure only reports the execution units in MB-milliseconds, so the memory is
+// set to the 128MB billing increment and the duration is derived from that,
+// which gives the same GB-seconds.
+func functionAppEstimateUsage(resourceID string) schema.EstimateFunc {
+	return func(ctx context.Context, values map[string]interface{}) error {
+		executions, err := azure.FunctionAppGetExecutionCount(ctx, resourceID)
+		if err != nil {
+			return err
+		}
+		values["monthly_executions"] = int64(math.Round(executions))
+
+		if executions == 0 {
+			return nil
+		}
+
+		units, err := azure.FunctionAppGetExecutionUnits(ctx, resourceID)
+		if err != nil {
+			return err
+		}
+		values["memory_mb"] = int64(128)
+		values["execution_duration_ms"] = int64(math.Round(units / executions / 128))
+
+		return nil
+	}
+}
+
 func AppFunctionPremiumCPUCostComponent(skuSize string, instances decimal.Decimal, skuCPU *int64, region string) *schema.CostComponent {
 	return &schema.CostComponent{
 		Name:           fmt.Sprintf("vCPU (%s)", strings.ToUpper(skuSize)),
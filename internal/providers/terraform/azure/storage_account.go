@@ -45,6 +45,7 @@ func newAzureRMStorageAccount(d *schema.ResourceData, u *schema.UsageData) *sche
 	r := &azure.StorageAccount{
 		Address:                d.Address,
 		Region:                 region,
+		ID:                     d.Get("id").String(),
 		AccessTier:             accessTier,
 		AccountKind:            accountKind,
 		AccountReplicationType: accountReplicationType,
@@ -1,10 +1,12 @@
 package google
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
@@ -94,9 +96,32 @@ func NewBigqueryTable(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		project := d.Get("project").String()
+		dataset := d.Get("dataset_id").String()
+		table := d.Get("table_id").String()
+
+		// Cloud Monitoring doesn't split the stored bytes into active and
+		// long-term storage, so it's all estimated as the more expensive active storage.
+		storedBytes, err := google.BigQueryGetTableStoredBytes(ctx, project, dataset, table)
+		if err != nil {
+			return err
+		}
+		values["monthly_active_storage_gb"] = storedBytes / 1000 / 1000 / 1000
+
+		insertBytes, err := google.BigQueryGetTableStreamingInsertBytes(ctx, project, dataset, table)
+		if err != nil {
+			return err
+		}
+		values["monthly_streaming_inserts_mb"] = insertBytes / 1000 / 1000
+
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
+		EstimateUsage:  estimate,
 	}
 }
 
@@ -1,7 +1,11 @@
 package google
 
 import (
+	"context"
+	"math"
+
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 	"github.com/shopspring/decimal"
 )
 
@@ -49,8 +53,28 @@ func NewCloudFunctions(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 		networkEgrees = decimalPtr(decimal.NewFromInt(u.Get("monthly_outbound_data_gb").Int()))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		project := d.Get("project").String()
+		name := d.Get("name").String()
+
+		inv, err := google.CloudFunctionsGetInvocations(ctx, project, region, name)
+		if err != nil {
+			return err
+		}
+		values["monthly_function_invocations"] = int64(math.Round(inv))
+
+		dur, err := google.CloudFunctionsGetDurationAvg(ctx, project, region, name)
+		if err != nil {
+			return err
+		}
+		values["request_duration_ms"] = int64(math.Round(dur))
+
+		return nil
+	}
+
 	return &schema.Resource{
-		Name: d.Address,
+		Name:          d.Address,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "CPU",
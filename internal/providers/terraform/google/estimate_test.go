@@ -0,0 +1,107 @@
+package google_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/providers/terraform/google"
+	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
+)
+
+// stubMonitoring returns a fake Cloud Monitoring server that responds with
+// the given int64 or double value for the time series of each metric.
+func stubMonitoring(t *testing.T, values map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("filter")
+		for metric, value := range values {
+			if strings.Contains(filter, `metric.type="`+metric+`"`) {
+				_, _ = w.Write([]byte(`{"timeSeries": [{"points": [{"value": ` + value + `}]}]}`))
+				return
+			}
+		}
+
+		t.Fatalf("received unexpected Cloud Monitoring query: %s", filter)
+	}))
+}
+
+func estimateUsage(t *testing.T, server *httptest.Server, resource *schema.Resource) map[string]interface{} {
+	ctx := googleusage.WithTestEndpoint(context.TODO(), server.URL)
+
+	values := make(map[string]interface{})
+	err := resource.EstimateUsage(ctx, values)
+	require.NoError(t, err)
+
+	return values
+}
+
+func TestCloudFunctionsEstimateUsage(t *testing.T) {
+	server := stubMonitoring(t, map[string]string{
+		"cloudfunctions.googleapis.com/function/execution_count": `{"int64Value": "1234"}`,
+		"cloudfunctions.googleapis.com/function/execution_times": `{"doubleValue": 350400000}`,
+	})
+	defer server.Close()
+
+	d := schema.NewResourceData("google_cloudfunctions_function", "registry.terraform.io/hashicorp/google", "google_cloudfunctions_function.function", nil,
+		gjson.Parse(`{"name": "my-function", "region": "us-central1", "project": "my-project"}`))
+
+	values := estimateUsage(t, server, google.NewCloudFunctions(d, nil))
+	assert.Equal(t, int64(1234), values["monthly_function_invocations"])
+	assert.Equal(t, int64(350), values["request_duration_ms"])
+}
+
+func TestStorageBucketEstimateUsage(t *testing.T) {
+	server := stubMonitoring(t, map[string]string{
+		"storage.googleapis.com/storage/total_bytes": `{"doubleValue": 161061273600}`,
+		"storage.googleapis.com/api/request_count":   `{"int64Value": "0"}`,
+	})
+	defer server.Close()
+
+	d := schema.NewResourceData("google_storage_bucket", "registry.terraform.io/hashicorp/google", "google_storage_bucket.bucket", nil,
+		gjson.Parse(`{"name": "my-bucket", "location": "US", "project": "my-project"}`))
+
+	values := estimateUsage(t, server, google.NewStorageBucket(d, nil))
+	assert.Equal(t, int64(150), values["storage_gb"])
+	assert.Equal(t, int64(0), values["monthly_class_a_operations"])
+	assert.Equal(t, int64(0), values["monthly_class_b_operations"])
+}
+
+func TestPubSubEstimateUsage(t *testing.T) {
+	server := stubMonitoring(t, map[string]string{
+		"pubsub.googleapis.com/topic/byte_cost":        `{"int64Value": "2199023255552"}`,
+		"pubsub.googleapis.com/subscription/byte_cost": `{"int64Value": "1099511627776"}`,
+	})
+	defer server.Close()
+
+	topic := schema.NewResourceData("google_pubsub_topic", "registry.terraform.io/hashicorp/google", "google_pubsub_topic.topic", nil,
+		gjson.Parse(`{"name": "my-topic", "project": "my-project"}`))
+	values := estimateUsage(t, server, google.NewPubSubTopic(topic, nil))
+	assert.Equal(t, float64(2), values["monthly_message_data_tb"])
+
+	sub := schema.NewResourceData("google_pubsub_subscription", "registry.terraform.io/hashicorp/google", "google_pubsub_subscription.subscription", nil,
+		gjson.Parse(`{"name": "my-subscription", "project": "my-project"}`))
+	values = estimateUsage(t, server, google.NewPubSubSubscription(sub, nil))
+	assert.Equal(t, float64(1), values["monthly_message_data_tb"])
+}
+
+func TestBigqueryTableEstimateUsage(t *testing.T) {
+	server := stubMonitoring(t, map[string]string{
+		"bigquery.googleapis.com/storage/stored_bytes":          `{"doubleValue": 500000000000}`,
+		"bigquery.googleapis.com/storage/uploaded_bytes_billed": `{"int64Value": "25000000"}`,
+	})
+	defer server.Close()
+
+	d := schema.NewResourceData("google_bigquery_table", "registry.terraform.io/hashicorp/google", "google_bigquery_table.table", nil,
+		gjson.Parse(`{"dataset_id": "my_dataset", "table_id": "my_table", "project": "my-project", "region": "us-central1"}`))
+
+	values := estimateUsage(t, server, google.NewBigqueryTable(d, nil))
+	assert.Equal(t, float64(500), values["monthly_active_storage_gb"])
+	assert.Equal(t, float64(25), values["monthly_streaming_inserts_mb"])
+}
@@ -1,7 +1,10 @@
 package google
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 	"github.com/shopspring/decimal"
 )
 
@@ -27,8 +30,19 @@ func NewPubSubSubscription(d *schema.ResourceData, u *schema.UsageData) *schema.
 		}
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		bytes, err := google.PubSubGetSubscriptionMessageBytes(ctx, d.Get("project").String(), d.Get("name").String())
+		if err != nil {
+			return err
+		}
+		values["monthly_message_data_tb"] = bytes / 1024 / 1024 / 1024 / 1024
+
+		return nil
+	}
+
 	return &schema.Resource{
-		Name: d.Address,
+		Name:          d.Address,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "Message delivery data",
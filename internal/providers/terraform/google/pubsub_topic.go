@@ -1,7 +1,10 @@
 package google
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 	"github.com/shopspring/decimal"
 )
 
@@ -19,8 +22,19 @@ func NewPubSubTopic(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 		messageDataTB = decimalPtr(decimal.NewFromFloat(u.Get("monthly_message_data_tb").Float()))
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		bytes, err := google.PubSubGetTopicMessageBytes(ctx, d.Get("project").String(), d.Get("name").String())
+		if err != nil {
+			return err
+		}
+		values["monthly_message_data_tb"] = bytes / 1024 / 1024 / 1024 / 1024
+
+		return nil
+	}
+
 	return &schema.Resource{
-		Name: d.Address,
+		Name:          d.Address,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "Message ingestion data",
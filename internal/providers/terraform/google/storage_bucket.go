@@ -1,12 +1,15 @@
 package google
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 )
 
 func GetStorageBucketRegistryItem() *schema.RegistryItem {
@@ -27,9 +30,31 @@ func NewStorageBucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		components = append(components, data)
 	}
 	components = append(components, operations(d, u)...)
+
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		project := d.Get("project").String()
+		name := d.Get("name").String()
+
+		bytes, err := google.StorageGetBucketSizeBytes(ctx, project, name)
+		if err != nil {
+			return err
+		}
+		values["storage_gb"] = int64(math.Ceil(bytes / 1024 / 1024 / 1024))
+
+		classA, classB, err := google.StorageGetBucketOperations(ctx, project, name)
+		if err != nil {
+			return err
+		}
+		values["monthly_class_a_operations"] = classA
+		values["monthly_class_b_operations"] = classB
+
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: components,
+		EstimateUsage:  estimate,
 		SubResources: []*schema.Resource{
 			networkEgress(region, u, "Network egress", "Data transfer", StorageBucketEgress),
 		},
@@ -0,0 +1,691 @@
+package terraform
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// moduleDownloadDir is where remote module sources are downloaded to when
+// they haven't already been downloaded by `terraform init` or `terraform get`.
+// It is relative to the Terraform directory, alongside the plan cache.
+var moduleDownloadDir = filepath.Join(infracostDir, "modules")
+
+const defaultRegistryHost = "registry.terraform.io"
+
+// registrySourceRegexp matches module registry sources, e.g.
+// terraform-aws-modules/vpc/aws or app.terraform.io/example-corp/vpc/aws.
+var registrySourceRegexp = regexp.MustCompile(`^(?:([0-9A-Za-z.-]+(?::[0-9]+)?)/)?([0-9A-Za-z](?:[0-9A-Za-z_-]{0,62}[0-9A-Za-z])?)/([0-9A-Za-z](?:[0-9A-Za-z_-]{0,62}[0-9A-Za-z])?)/([0-9a-z]{1,64})$`)
+
+// These hosts are shorthands for Git repositories rather than registries.
+var nonRegistryHosts = map[string]bool{
+	"github.com":    true,
+	"bitbucket.org": true,
+}
+
+var archiveExtensions = []string{".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar", ".zip"}
+
+type registrySource struct {
+	host      string
+	namespace string
+	name      string
+	system    string
+}
+
+func (s registrySource) path() string {
+	return fmt.Sprintf("%s/%s/%s", s.namespace, s.name, s.system)
+}
+
+// moduleDownloader downloads registry and remote module sources so they can
+// be parsed without running Terraform. Each source is only downloaded once,
+// so newer versions that match a version constraint aren't used until the
+// download directory is removed, like `terraform init` without -upgrade.
+type moduleDownloader struct {
+	dir        string
+	client     *http.Client
+	registries map[string]*url.URL
+}
+
+func newModuleDownloader(dir string) *moduleDownloader {
+	return &moduleDownloader{
+		dir:        dir,
+		client:     &http.Client{Timeout: 5 * time.Minute},
+		registries: make(map[string]*url.URL),
+	}
+}
+
+// isLocalModuleSource returns true if the module source is a path relative to
+// the module that calls it.
+func isLocalModuleSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
+
+// parseRegistrySource returns the parts of a module registry source, or false
+// if the source isn't for a registry.
+func parseRegistrySource(source string) (registrySource, bool) {
+	m := registrySourceRegexp.FindStringSubmatch(source)
+	if m == nil {
+		return registrySource{}, false
+	}
+
+	host := strings.ToLower(m[1])
+	if host == "" {
+		host = defaultRegistryHost
+	}
+
+	if nonRegistryHosts[host] {
+		return registrySource{}, false
+	}
+
+	return registrySource{host: host, namespace: m[2], name: m[3], system: m[4]}, true
+}
+
+// splitModuleSubDir splits the subdirectory from a module source, e.g.
+// hashicorp/consul/aws//modules/consul-cluster.
+func splitModuleSubDir(source string) (string, string) {
+	offset := 0
+	if i := strings.Index(source, "://"); i >= 0 {
+		offset = i + len("://")
+	}
+
+	i := strings.Index(source[offset:], "//")
+	if i < 0 {
+		return source, ""
+	}
+
+	i += offset
+	subDir := source[i+2:]
+
+	// Any query string belongs to the source, e.g. the ref of a Git source
+	if q := strings.Index(subDir, "?"); q >= 0 {
+		return source[:i] + subDir[q:], subDir[:q]
+	}
+
+	return source[:i], subDir
+}
+
+// download returns the directory of the module source, downloading it if it
+// hasn't been downloaded already. The version is only used for registry
+// sources.
+func (d *moduleDownloader) download(source string, version string) (string, error) {
+	source, subDir := splitModuleSubDir(source)
+
+	key := sha256.Sum256([]byte(source + "\x00" + version))
+	dest := filepath.Join(d.dir, hex.EncodeToString(key[:8]))
+
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		addr := source
+
+		if r, ok := parseRegistrySource(source); ok {
+			addr, err = d.registryDownloadAddr(r, version)
+			if err != nil {
+				return "", err
+			}
+		} else if version != "" {
+			return "", errors.New("version can only be set for registry modules")
+		}
+
+		log.Debugf("Downloading module source %s from %s", source, addr)
+
+		err = d.fetch(addr, dest)
+		if err != nil {
+			return "", err
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	return moduleSubDir(dest, subDir)
+}
+
+// registryDownloadAddr returns the address of the latest version of the
+// registry module that matches the version constraints.
+func (d *moduleDownloader) registryDownloadAddr(r registrySource, constraints string) (string, error) {
+	base, err := d.registryModulesURL(r.host)
+	if err != nil {
+		return "", err
+	}
+
+	versionsURL := base.ResolveReference(&url.URL{Path: r.path() + "/versions"})
+
+	var versionsResp struct {
+		Modules []struct {
+			Versions []struct {
+				Version string `json:"version"`
+			} `json:"versions"`
+		} `json:"modules"`
+	}
+
+	err = d.getJSON(r.host, versionsURL, &versionsResp)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error listing the versions of %s", r.path())
+	}
+
+	versions := make([]string, 0)
+	for _, m := range versionsResp.Modules {
+		for _, v := range m.Versions {
+			versions = append(versions, v.Version)
+		}
+	}
+
+	version, err := latestMatchingVersion(versions, constraints)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error finding a version of %s", r.path())
+	}
+
+	downloadURL := base.ResolveReference(&url.URL{Path: fmt.Sprintf("%s/%s/download", r.path(), version)})
+
+	resp, err := d.get(r.host, downloadURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error downloading %s %s", r.path(), version)
+	}
+	defer resp.Body.Close()
+
+	addr := resp.Header.Get("X-Terraform-Get")
+	if addr == "" && resp.StatusCode == http.StatusOK {
+		var body struct {
+			Location string `json:"location"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
+			addr = body.Location
+		}
+	}
+
+	if addr == "" {
+		return "", errors.Errorf("The registry didn't return a download location for %s %s", r.path(), version)
+	}
+
+	// The location can be relative to the download URL
+	if strings.HasPrefix(addr, "/") || strings.HasPrefix(addr, "./") || strings.HasPrefix(addr, "../") {
+		rel, err := url.Parse(addr)
+		if err != nil {
+			return "", errors.Wrapf(err, "Invalid download location %s", addr)
+		}
+		addr = downloadURL.ResolveReference(rel).String()
+	}
+
+	return addr, nil
+}
+
+// registryModulesURL returns the base URL of the modules API of a registry
+// using the registry's service discovery document.
+func (d *moduleDownloader) registryModulesURL(host string) (*url.URL, error) {
+	if u, ok := d.registries[host]; ok {
+		return u, nil
+	}
+
+	discoveryURL := &url.URL{Scheme: "https", Host: host, Path: "/.well-known/terraform.json"}
+
+	var services map[string]interface{}
+	err := d.getJSON(host, discoveryURL, &services)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error discovering the module registry for %s", host)
+	}
+
+	modules, ok := services["modules.v1"].(string)
+	if !ok {
+		return nil, errors.Errorf("%s does not provide a module registry", host)
+	}
+
+	if !strings.HasSuffix(modules, "/") {
+		modules += "/"
+	}
+
+	rel, err := url.Parse(modules)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid module registry URL for %s", host)
+	}
+
+	u := discoveryURL.ResolveReference(rel)
+	d.registries[host] = u
+
+	return u, nil
+}
+
+func (d *moduleDownloader) getJSON(registryHost string, u *url.URL, v interface{}) error {
+	resp, err := d.get(registryHost, u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// get sends a GET request. Requests to a registry host use the same TF_TOKEN_
+// environment variables as Terraform for private registries, but the token
+// isn't sent for other downloads so it isn't shared with other hosts.
+func (d *moduleDownloader) get(registryHost string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if registryHost != "" {
+		tokenEnv := "TF_TOKEN_" + strings.NewReplacer(".", "_", "-", "__", ":", "_").Replace(registryHost)
+		if token := os.Getenv(tokenEnv); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		resp.Body.Close()
+		return nil, errors.Errorf("%s returned %s", u.Redacted(), resp.Status)
+	}
+
+	return resp, nil
+}
+
+// fetch downloads a Git repository or an archive to dest. The address uses
+// the same format as Terraform module sources, e.g.
+// git::https://example.com/vpc.git?ref=v1.2.0 or
+// https://example.com/vpc.zip//modules/vpc.
+func (d *moduleDownloader) fetch(addr string, dest string) error {
+	addr, subDir := splitModuleSubDir(addr)
+
+	err := os.MkdirAll(d.dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(d.dir, ".download-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	switch {
+	case strings.HasPrefix(addr, "git::"):
+		err = fetchGit(strings.TrimPrefix(addr, "git::"), tmp)
+	case strings.HasPrefix(addr, "github.com/"):
+		err = fetchGit("https://"+addr, tmp)
+	case strings.HasPrefix(addr, "git@"):
+		err = fetchGit(addr, tmp)
+	case strings.HasPrefix(addr, "https://") || strings.HasPrefix(addr, "http://"):
+		err = d.fetchArchive(addr, tmp)
+	default:
+		err = errors.Errorf("The module source %s isn't supported", addr)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	src, err := moduleSubDir(tmp, subDir)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(src, dest)
+}
+
+// fetchGit clones a Git repository, checking out the ref query parameter if
+// it is set.
+func fetchGit(addr string, dest string) error {
+	ref := ""
+	if i := strings.Index(addr, "?"); i >= 0 {
+		query, err := url.ParseQuery(addr[i+1:])
+		if err != nil {
+			return errors.Wrapf(err, "Invalid Git module source %s", addr)
+		}
+
+		ref = query.Get("ref")
+		addr = addr[:i]
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return errors.New("Git must be installed to download Git module sources")
+	}
+
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+
+	err := runGit(append(args, addr, dest)...)
+	if err == nil || ref == "" {
+		return err
+	}
+
+	// The ref might be a commit rather than a branch or tag, so clone the
+	// whole repository and check it out
+	err = os.RemoveAll(dest)
+	if err != nil {
+		return err
+	}
+
+	err = runGit("clone", addr, dest)
+	if err != nil {
+		return err
+	}
+
+	return runGit("-C", dest, "checkout", ref)
+}
+
+func runGit(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Errorf("git %s failed: %s", args[0], strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// fetchArchive downloads and extracts an archive. The archive type is taken
+// from the archive query parameter or the extension of the URL.
+func (d *moduleDownloader) fetchArchive(addr string, dest string) error {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return errors.Wrapf(err, "Invalid module source %s", addr)
+	}
+
+	query := u.Query()
+	archive := query.Get("archive")
+	query.Del("archive")
+	u.RawQuery = query.Encode()
+
+	if archive == "" {
+		for _, ext := range archiveExtensions {
+			if strings.HasSuffix(u.Path, ext) {
+				archive = strings.TrimPrefix(ext, ".")
+				break
+			}
+		}
+	}
+
+	if archive == "" {
+		return errors.Errorf("The module source %s isn't a supported archive", addr)
+	}
+
+	resp, err := d.get("", u)
+	if err != nil {
+		return errors.Wrapf(err, "Error downloading module source %s", u.Redacted())
+	}
+	defer resp.Body.Close()
+
+	switch archive {
+	case "tar.gz", "tgz":
+		r, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "Error reading module archive")
+		}
+		return extractTar(r, dest)
+	case "tar.bz2", "tbz2":
+		return extractTar(bzip2.NewReader(resp.Body), dest)
+	case "tar":
+		return extractTar(resp.Body, dest)
+	case "zip":
+		return extractZip(resp.Body, dest)
+	}
+
+	return errors.Errorf("The module archive type %s isn't supported", archive)
+}
+
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "Error reading module archive")
+		}
+
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeDir {
+			continue
+		}
+
+		path, err := archivePath(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		if hdr.Typeflag == tar.TypeDir {
+			err = os.MkdirAll(path, 0700)
+		} else {
+			err = writeArchiveFile(path, tr)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractZip(r io.Reader, dest string) error {
+	f, err := os.CreateTemp("", "infracost-module-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		return errors.Wrap(err, "Error downloading module archive")
+	}
+
+	zr, err := zip.NewReader(f, size)
+	if err != nil {
+		return errors.Wrap(err, "Error reading module archive")
+	}
+
+	for _, file := range zr.File {
+		path, err := archivePath(dest, file.Name)
+		if err != nil {
+			return err
+		}
+
+		if file.FileInfo().IsDir() {
+			err = os.MkdirAll(path, 0700)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return errors.Wrap(err, "Error reading module archive")
+		}
+
+		err = writeArchiveFile(path, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// archivePath returns the path to extract an archive entry to, or an error if
+// the entry is outside of the destination.
+func archivePath(dest string, name string) (string, error) {
+	path := filepath.Join(dest, name)
+	if path != dest && !strings.HasPrefix(path, dest+string(os.PathSeparator)) {
+		return "", errors.Errorf("Invalid path %s in module archive", name)
+	}
+
+	return path, nil
+}
+
+func writeArchiveFile(path string, r io.Reader) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// moduleSubDir returns the subdirectory of a downloaded module. The
+// subdirectory can be a glob, e.g. * for archives that have a single
+// top-level directory.
+func moduleSubDir(dir string, subDir string) (string, error) {
+	if subDir == "" {
+		return dir, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, subDir))
+	if err != nil {
+		return "", errors.Wrapf(err, "Invalid module subdirectory %s", subDir)
+	}
+
+	if len(matches) != 1 {
+		return "", errors.Errorf("The module subdirectory %s matched %d directories, expected 1", subDir, len(matches))
+	}
+
+	path := matches[0]
+	if path != dir && !strings.HasPrefix(path, dir+string(os.PathSeparator)) {
+		return "", errors.Errorf("Invalid module subdirectory %s", subDir)
+	}
+
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return "", errors.Errorf("The module subdirectory %s does not exist", subDir)
+	}
+
+	return path, nil
+}
+
+// latestMatchingVersion returns the latest version that matches the
+// constraints, using the same constraint syntax as Terraform. Pre-release
+// versions are only matched by an exact version.
+func latestMatchingVersion(versions []string, constraints string) (string, error) {
+	parsed := make([]*semver.Version, 0, len(versions))
+	for _, v := range versions {
+		sv, err := semver.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		parsed = append(parsed, sv)
+	}
+
+	sort.Slice(parsed, func(i, j int) bool {
+		return parsed[i].GreaterThan(parsed[j])
+	})
+
+	for _, v := range parsed {
+		ok, err := versionMatches(v, constraints)
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			return v.Original(), nil
+		}
+	}
+
+	if constraints == "" {
+		return "", errors.New("No versions are available")
+	}
+
+	return "", errors.Errorf("No versions match the constraints %s", constraints)
+}
+
+func versionMatches(v *semver.Version, constraints string) (bool, error) {
+	if strings.TrimSpace(constraints) == "" {
+		return v.Prerelease() == "", nil
+	}
+
+	for _, c := range strings.Split(constraints, ",") {
+		c = strings.TrimSpace(c)
+
+		op := "="
+		for _, o := range []string{"~>", ">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(c, o) {
+				op = o
+				c = strings.TrimSpace(strings.TrimPrefix(c, o))
+				break
+			}
+		}
+
+		cv, err := semver.NewVersion(c)
+		if err != nil {
+			return false, errors.Errorf("Invalid version constraint %s", constraints)
+		}
+
+		if v.Prerelease() != "" && (op != "=" || !v.Equal(cv)) {
+			return false, nil
+		}
+
+		var ok bool
+		switch op {
+		case "=":
+			ok = v.Equal(cv)
+		case "!=":
+			ok = !v.Equal(cv)
+		case ">":
+			ok = v.GreaterThan(cv)
+		case ">=":
+			ok = !v.LessThan(cv)
+		case "<":
+			ok = v.LessThan(cv)
+		case "<=":
+			ok = !v.GreaterThan(cv)
+		case "~>":
+			ok = !v.LessThan(cv) && v.LessThan(pessimisticUpperBound(cv, c))
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// pessimisticUpperBound returns the exclusive upper bound of a ~> constraint,
+// which only allows the rightmost version segment to increase, e.g. ~> 1.2
+// allows versions below 2.0.0 and ~> 1.2.3 allows versions below 1.3.0.
+func pessimisticUpperBound(v *semver.Version, raw string) *semver.Version {
+	segments := strings.Count(strings.SplitN(strings.TrimPrefix(raw, "v"), "-", 2)[0], ".") + 1
+
+	var upper semver.Version
+	if segments >= 3 {
+		upper = v.IncMinor()
+	} else {
+		upper = v.IncMajor()
+	}
+
+	return &upper
+}
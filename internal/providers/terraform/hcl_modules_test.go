@@ -0,0 +1,213 @@
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func testModuleArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for name, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(contents)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	return buf.Bytes()
+}
+
+func TestHCLParserRegistryModules(t *testing.T) {
+	archive := testModuleArchive(t, map[string]string{
+		"example-vpc-abc123/main.tf": `
+			variable "instance_type" {}
+
+			resource "aws_instance" "nat" {
+				instance_type = var.instance_type
+			}
+
+			output "instance_type" {
+				value = var.instance_type
+			}
+		`,
+	})
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/.well-known/terraform.json":
+			fmt.Fprint(w, `{"modules.v1": "/v1/modules/"}`)
+		case "/v1/modules/example/vpc/aws/versions":
+			fmt.Fprint(w, `{"modules": [{"versions": [{"version": "1.0.0"}, {"version": "2.0.0"}, {"version": "1.2.0"}, {"version": "1.3.0-beta"}]}]}`)
+		case "/v1/modules/example/vpc/aws/1.2.0/download":
+			w.Header().Set("X-Terraform-Get", server.URL+"/archives/vpc-1.2.0.tar.gz//*")
+			w.WriteHeader(http.StatusNoContent)
+		case "/archives/vpc-1.2.0.tar.gz":
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": fmt.Sprintf(`
+			module "vpc" {
+				source        = "%s/example/vpc/aws"
+				version       = "~> 1.0"
+				instance_type = "t3.micro"
+			}
+
+			resource "aws_instance" "web" {
+				instance_type = module.vpc.instance_type
+			}
+		`, host),
+	})
+
+	parse := func() gjson.Result {
+		p := NewHCLParser(dir, HCLParserOptions{})
+		p.downloader.client = server.Client()
+
+		j, err := p.ParseJSON()
+		require.NoError(t, err)
+
+		return gjson.ParseBytes(j)
+	}
+
+	parsed := parse()
+
+	modules := parsed.Get("planned_values.root_module.child_modules")
+	require.Len(t, modules.Array(), 1)
+	assert.Equal(t, "module.vpc.aws_instance.nat", modules.Get("0.resources.0.address").String())
+	assert.Equal(t, "t3.micro", modules.Get("0.resources.0.values.instance_type").String())
+
+	r := parsed.Get(`planned_values.root_module.resources.#(address="aws_instance.web")`)
+	assert.Equal(t, "t3.micro", r.Get("values.instance_type").String())
+
+	// The downloaded module is used the next time
+	parse()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, requests["/archives/vpc-1.2.0.tar.gz"])
+	assert.Equal(t, 1, requests["/v1/modules/example/vpc/aws/versions"])
+}
+
+func TestHCLParserModuleDownloadError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/terraform.json" {
+			fmt.Fprint(w, `{"modules.v1": "/v1/modules/"}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": fmt.Sprintf(`
+			module "missing" {
+				source = "%s/example/missing/aws"
+			}
+		`, strings.TrimPrefix(server.URL, "https://")),
+	})
+
+	p := NewHCLParser(dir, HCLParserOptions{})
+	p.downloader.client = server.Client()
+
+	_, err := p.ParseJSON()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to download module")
+	assert.Contains(t, err.Error(), "module.missing")
+}
+
+func TestParseRegistrySource(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected registrySource
+		ok       bool
+	}{
+		{"terraform-aws-modules/vpc/aws", registrySource{"registry.terraform.io", "terraform-aws-modules", "vpc", "aws"}, true},
+		{"app.terraform.io/example-corp/k8s-cluster/azurerm", registrySource{"app.terraform.io", "example-corp", "k8s-cluster", "azurerm"}, true},
+		{"github.com/hashicorp/example", registrySource{}, false},
+		{"github.com/hashicorp/example/aws", registrySource{}, false},
+		{"git::https://example.com/vpc.git", registrySource{}, false},
+		{"./modules/vpc", registrySource{}, false},
+	}
+
+	for _, tt := range tests {
+		actual, ok := parseRegistrySource(tt.source)
+		assert.Equal(t, tt.ok, ok, tt.source)
+		assert.Equal(t, tt.expected, actual, tt.source)
+	}
+}
+
+func TestSplitModuleSubDir(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+		subDir   string
+	}{
+		{"hashicorp/consul/aws//modules/consul-cluster", "hashicorp/consul/aws", "modules/consul-cluster"},
+		{"git::https://example.com/network.git//modules/vpc?ref=v1.2.0", "git::https://example.com/network.git?ref=v1.2.0", "modules/vpc"},
+		{"https://example.com/vpc.tar.gz//*?archive=tar.gz", "https://example.com/vpc.tar.gz?archive=tar.gz", "*"},
+		{"https://example.com/vpc.zip", "https://example.com/vpc.zip", ""},
+	}
+
+	for _, tt := range tests {
+		source, subDir := splitModuleSubDir(tt.source)
+		assert.Equal(t, tt.expected, source, tt.source)
+		assert.Equal(t, tt.subDir, subDir, tt.source)
+	}
+}
+
+func TestLatestMatchingVersion(t *testing.T) {
+	versions := []string{"1.0.0", "1.2.0", "1.2.5", "1.3.0-beta", "2.0.0", "2.1.0"}
+
+	tests := []struct {
+		constraints string
+		expected    string
+	}{
+		{"", "2.1.0"},
+		{"~> 1.0", "1.2.5"},
+		{"~> 1.2.0", "1.2.5"},
+		{">= 1.0, < 2.0, != 1.2.5", "1.2.0"},
+		{"1.0.0", "1.0.0"},
+		{"= 1.3.0-beta", "1.3.0-beta"},
+	}
+
+	for _, tt := range tests {
+		actual, err := latestMatchingVersion(versions, tt.constraints)
+		require.NoError(t, err, tt.constraints)
+		assert.Equal(t, tt.expected, actual, tt.constraints)
+	}
+
+	_, err := latestMatchingVersion(versions, "> 3.0")
+	assert.EqualError(t, err, "No versions match the constraints > 3.0")
+}
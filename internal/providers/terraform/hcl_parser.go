@@ -0,0 +1,1255 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/kballard/go-shellquote"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// modulesManifestPath is where `terraform init` and `terraform get` record
+// the directories that remote module sources have been downloaded to.
+var modulesManifestPath = filepath.Join(".terraform", "modules", "modules.json")
+
+// These attributes are Terraform meta-arguments so they are not included in
+// the values of resources or the inputs of module calls.
+var resourceMetaArguments = map[string]bool{
+	"count":      true,
+	"for_each":   true,
+	"provider":   true,
+	"depends_on": true,
+}
+
+var resourceMetaBlocks = map[string]bool{
+	"lifecycle":   true,
+	"provisioner": true,
+	"connection":  true,
+}
+
+var moduleMetaArguments = map[string]bool{
+	"source":     true,
+	"version":    true,
+	"count":      true,
+	"for_each":   true,
+	"providers":  true,
+	"depends_on": true,
+}
+
+// HCLParserOptions are the inputs for parsing a Terraform directory without
+// running Terraform.
+type HCLParserOptions struct {
+	// VarFiles are tfvars files that are loaded after terraform.tfvars and any
+	// *.auto.tfvars files, in order.
+	VarFiles []string
+	// Vars are variable values that are loaded last, overriding any values
+	// from the var files.
+	Vars map[string]string
+	// Workspace is the value used for terraform.workspace.
+	Workspace string
+}
+
+// HCLParser evaluates the Terraform configuration in a directory directly
+// from the .tf files. Values that can only be known after an apply, such as
+// attributes of other resources or data sources, are treated as unknown and
+// are left out of the resource values.
+type HCLParser struct {
+	path      string
+	opts      HCLParserOptions
+	parser    *hclparse.Parser
+	functions map[string]function.Function
+	modules   map[string]string
+
+	downloader *moduleDownloader
+}
+
+// NewHCLParser returns a parser for the Terraform directory at path.
+func NewHCLParser(path string, opts HCLParserOptions) *HCLParser {
+	if opts.Workspace == "" {
+		opts.Workspace = "default"
+	}
+
+	return &HCLParser{
+		path:       path,
+		opts:       opts,
+		parser:     hclparse.NewParser(),
+		functions:  hclFunctions(),
+		downloader: newModuleDownloader(filepath.Join(path, moduleDownloadDir)),
+	}
+}
+
+// ParseHCLPlanFlags extracts the -var-file and -var flags from Terraform plan
+// flags so the same flags can be used whether or not Terraform is run.
+func ParseHCLPlanFlags(planFlags string) (HCLParserOptions, error) {
+	opts := HCLParserOptions{
+		Vars: make(map[string]string),
+	}
+
+	args, err := shellquote.Split(planFlags)
+	if err != nil {
+		return opts, errors.Wrap(err, "Error parsing Terraform plan flags")
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := strings.TrimLeft(args[i], "-")
+
+		var name, value string
+		if p := strings.SplitN(arg, "=", 2); len(p) == 2 {
+			name, value = p[0], p[1]
+		} else if i+1 < len(args) {
+			name, value = arg, args[i+1]
+			if name == "var-file" || name == "var" {
+				i++
+			}
+		}
+
+		switch name {
+		case "var-file":
+			opts.VarFiles = append(opts.VarFiles, value)
+		case "var":
+			p := strings.SplitN(value, "=", 2)
+			if len(p) != 2 {
+				return opts, errors.Errorf("Invalid -var flag '%s', expected the form name=value", value)
+			}
+			opts.Vars[p[0]] = p[1]
+		}
+	}
+
+	return opts, nil
+}
+
+// ParseJSON evaluates the root module and returns it in the same format as
+// the output of `terraform show -json` for a plan, so it can be parsed in the
+// same way as a plan.
+func (p *HCLParser) ParseJSON() ([]byte, error) {
+	err := p.loadModulesManifest()
+	if err != nil {
+		return []byte{}, err
+	}
+
+	vars, err := p.loadRootVariables()
+	if err != nil {
+		return []byte{}, err
+	}
+
+	root, err := p.loadModule(p.path, "", "", vars)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	root.evaluate()
+
+	if diags := root.allDiags(); diags.HasErrors() {
+		return []byte{}, errors.Wrap(diags, "Error evaluating Terraform")
+	}
+
+	providerConf := make(map[string]interface{})
+	root.addProviderConfig(providerConf)
+
+	variables := make(map[string]interface{})
+	for name, v := range root.vars {
+		variables[name] = map[string]interface{}{"value": ctyToJSONValue(v)}
+	}
+
+	plan := map[string]interface{}{
+		"format_version":    "0.1",
+		"terraform_version": "",
+		"variables":         variables,
+		"planned_values": map[string]interface{}{
+			"root_module": root.plannedValues(),
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": providerConf,
+			"root_module":     root.configuration(),
+		},
+	}
+
+	return json.Marshal(plan)
+}
+
+func (p *HCLParser) loadModulesManifest() error {
+	p.modules = make(map[string]string)
+
+	b, err := os.ReadFile(filepath.Join(p.path, modulesManifestPath))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "Error reading Terraform modules manifest")
+	}
+
+	var manifest struct {
+		Modules []struct {
+			Key string `json:"Key"`
+			Dir string `json:"Dir"`
+		} `json:"Modules"`
+	}
+
+	err = json.Unmarshal(b, &manifest)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing Terraform modules manifest")
+	}
+
+	for _, m := range manifest.Modules {
+		p.modules[m.Key] = filepath.Join(p.path, m.Dir)
+	}
+
+	return nil
+}
+
+// loadRootVariables loads the variable values for the root module in the
+// same order of precedence as Terraform: terraform.tfvars, *.auto.tfvars in
+// lexical order and then any var files. TF_VAR_ environment variables are
+// only used if the variable is not set in any of the files, and any vars
+// override everything else.
+func (p *HCLParser) loadRootVariables() (map[string]cty.Value, error) {
+	vars := make(map[string]cty.Value)
+
+	files := make([]string, 0)
+
+	for _, name := range []string{"terraform.tfvars", "terraform.tfvars.json"} {
+		f := filepath.Join(p.path, name)
+		if _, err := os.Stat(f); err == nil {
+			files = append(files, f)
+		}
+	}
+
+	for _, pattern := range []string{"*.auto.tfvars", "*.auto.tfvars.json"} {
+		matches, _ := filepath.Glob(filepath.Join(p.path, pattern))
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	for _, f := range p.opts.VarFiles {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(p.path, f)
+		}
+		files = append(files, f)
+	}
+
+	for _, f := range files {
+		err := p.loadVarFile(f, vars)
+		if err != nil {
+			return vars, err
+		}
+	}
+
+	for _, env := range os.Environ() {
+		kv := strings.SplitN(env, "=", 2)
+		if len(kv) == 2 && strings.HasPrefix(kv[0], "TF_VAR_") {
+			name := strings.TrimPrefix(kv[0], "TF_VAR_")
+			if _, ok := vars[name]; !ok {
+				vars[name] = parseRawVariable(kv[1])
+			}
+		}
+	}
+
+	for name, v := range p.opts.Vars {
+		vars[name] = parseRawVariable(v)
+	}
+
+	return vars, nil
+}
+
+func (p *HCLParser) loadVarFile(path string, vars map[string]cty.Value) error {
+	var file *hcl.File
+	var diags hcl.Diagnostics
+
+	if strings.HasSuffix(path, ".json") {
+		file, diags = p.parser.ParseJSONFile(path)
+	} else {
+		file, diags = p.parser.ParseHCLFile(path)
+	}
+
+	if diags.HasErrors() {
+		return errors.Wrapf(diags, "Error parsing Terraform variables file %s", path)
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return errors.Wrapf(diags, "Error parsing Terraform variables file %s", path)
+	}
+
+	for name, attr := range attrs {
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			log.Debugf("Could not evaluate variable %s in %s: %s", name, path, diags.Error())
+			v = cty.DynamicVal
+		}
+		vars[name] = v
+	}
+
+	return nil
+}
+
+// loadModule parses all the .tf files in dir. The key is the module path in
+// the same format as the modules manifest, e.g. "app.db".
+func (p *HCLParser) loadModule(dir string, key string, address string, inputs map[string]cty.Value) (*hclModule, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+
+	if jsonMatches, _ := filepath.Glob(filepath.Join(dir, "*.tf.json")); len(jsonMatches) > 0 {
+		log.Warnf("Skipping %d .tf.json files in %s since only .tf files can be parsed without Terraform", len(jsonMatches), dir)
+	}
+
+	m := newHCLModule(p, dir, key, address)
+
+	for _, path := range matches {
+		file, diags := p.parser.ParseHCLFile(path)
+		if diags.HasErrors() {
+			return nil, errors.Wrapf(diags, "Error parsing Terraform file %s", path)
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		m.addBody(body)
+	}
+
+	m.setVariables(inputs)
+
+	return m, nil
+}
+
+// moduleDir returns the directory for a module call. Modules that haven't
+// been downloaded by `terraform init` or `terraform get` are downloaded from
+// their registry or remote source.
+func (p *HCLParser) moduleDir(parentDir string, key string, source string, version string) (string, error) {
+	if isLocalModuleSource(source) {
+		return filepath.Join(parentDir, source), nil
+	}
+
+	if dir, ok := p.modules[key]; ok {
+		return dir, nil
+	}
+
+	return p.downloader.download(source, version)
+}
+
+// hclModule is an instance of a module. Locals and module calls are evaluated
+// when they are first referenced so they can be declared in any order.
+type hclModule struct {
+	parser  *HCLParser
+	dir     string
+	key     string
+	address string
+
+	variables   map[string]*hclsyntax.Block
+	locals      map[string]hcl.Expression
+	outputs     map[string]hcl.Expression
+	providers   []*hclsyntax.Block
+	resources   []*hclsyntax.Block
+	moduleCalls map[string]*hclsyntax.Block
+	callOrder   []string
+
+	vars        map[string]cty.Value
+	localVals   map[string]cty.Value
+	moduleVals  map[string]cty.Value
+	children    map[string][]*hclModule
+	evaluating  map[string]bool
+	instances   []*hclResourceInstance
+	confEntries []map[string]interface{}
+	diags       hcl.Diagnostics
+}
+
+type hclResourceInstance struct {
+	block   *hclsyntax.Block
+	address string
+	values  map[string]interface{}
+}
+
+func newHCLModule(p *HCLParser, dir string, key string, address string) *hclModule {
+	return &hclModule{
+		parser:      p,
+		dir:         dir,
+		key:         key,
+		address:     address,
+		variables:   make(map[string]*hclsyntax.Block),
+		locals:      make(map[string]hcl.Expression),
+		outputs:     make(map[string]hcl.Expression),
+		moduleCalls: make(map[string]*hclsyntax.Block),
+		vars:        make(map[string]cty.Value),
+		localVals:   make(map[string]cty.Value),
+		moduleVals:  make(map[string]cty.Value),
+		children:    make(map[string][]*hclModule),
+		evaluating:  make(map[string]bool),
+	}
+}
+
+func (m *hclModule) addBody(body *hclsyntax.Body) {
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "variable":
+			if len(block.Labels) == 1 {
+				m.variables[block.Labels[0]] = block
+			}
+		case "locals":
+			for name, attr := range block.Body.Attributes {
+				m.locals[name] = attr.Expr
+			}
+		case "output":
+			if attr, ok := block.Body.Attributes["value"]; ok && len(block.Labels) == 1 {
+				m.outputs[block.Labels[0]] = attr.Expr
+			}
+		case "provider":
+			m.providers = append(m.providers, block)
+		case "resource", "data":
+			m.resources = append(m.resources, block)
+		case "module":
+			if len(block.Labels) == 1 {
+				m.moduleCalls[block.Labels[0]] = block
+				m.callOrder = append(m.callOrder, block.Labels[0])
+			}
+		}
+	}
+}
+
+// setVariables sets the value of each declared variable from the inputs,
+// falling back to the default. Variables with neither are unknown.
+func (m *hclModule) setVariables(inputs map[string]cty.Value) {
+	for name, block := range m.variables {
+		if v, ok := inputs[name]; ok {
+			m.vars[name] = v
+			continue
+		}
+
+		if attr, ok := block.Body.Attributes["default"]; ok {
+			v, diags := attr.Expr.Value(nil)
+			if !diags.HasErrors() {
+				m.vars[name] = v
+				continue
+			}
+		}
+
+		log.Debugf("No value set for variable %s%s, treating it as unknown", m.prefix(), name)
+		m.vars[name] = cty.DynamicVal
+	}
+}
+
+// allDiags returns the diagnostics from evaluating the module and its child
+// modules.
+func (m *hclModule) allDiags() hcl.Diagnostics {
+	diags := m.diags
+
+	for _, name := range m.callOrder {
+		for _, child := range m.children[name] {
+			diags = append(diags, child.allDiags()...)
+		}
+	}
+
+	return diags
+}
+
+func (m *hclModule) prefix() string {
+	if m.address == "" {
+		return ""
+	}
+
+	return m.address + "."
+}
+
+func (m *hclModule) evaluate() {
+	for _, name := range m.callOrder {
+		m.evalModuleCall(name)
+	}
+
+	for _, block := range m.resources {
+		m.evalResource(block)
+	}
+}
+
+// evalContext returns the context for evaluating expressions in the module.
+// The extra values are used for count, each and dynamic block iterators.
+func (m *hclModule) evalContext(extra map[string]cty.Value) *hcl.EvalContext {
+	locals := make(map[string]cty.Value, len(m.locals))
+	for name := range m.locals {
+		if v, ok := m.localVals[name]; ok {
+			locals[name] = v
+		} else {
+			locals[name] = cty.DynamicVal
+		}
+	}
+
+	modules := make(map[string]cty.Value, len(m.moduleCalls))
+	for name := range m.moduleCalls {
+		if v, ok := m.moduleVals[name]; ok {
+			modules[name] = v
+		} else {
+			modules[name] = cty.DynamicVal
+		}
+	}
+
+	cwd, _ := os.Getwd()
+
+	vars := map[string]cty.Value{
+		"var":    objectVal(m.vars),
+		"local":  objectVal(locals),
+		"module": objectVal(modules),
+		"path": cty.ObjectVal(map[string]cty.Value{
+			"module": cty.StringVal(m.dir),
+			"root":   cty.StringVal(m.parser.path),
+			"cwd":    cty.StringVal(cwd),
+		}),
+		"terraform": cty.ObjectVal(map[string]cty.Value{
+			"workspace": cty.StringVal(m.parser.opts.Workspace),
+		}),
+	}
+
+	for k, v := range extra {
+		vars[k] = v
+	}
+
+	return &hcl.EvalContext{
+		Variables: vars,
+		Functions: m.parser.functions,
+	}
+}
+
+// evalExpr evaluates the expression after evaluating any locals and modules
+// it depends on. Any references to values that aren't available, such as
+// the attributes of other resources, evaluate as unknown.
+func (m *hclModule) evalExpr(expr hcl.Expression, extra map[string]cty.Value) cty.Value {
+	m.evalDependencies(expr.Variables())
+
+	ctx := m.evalContext(extra)
+
+	for _, t := range expr.Variables() {
+		if _, ok := ctx.Variables[t.RootName()]; !ok {
+			ctx.Variables[t.RootName()] = cty.DynamicVal
+		}
+	}
+
+	v, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		log.Debugf("Could not evaluate expression in %s, treating it as unknown: %s", m.dir, diags.Error())
+		return cty.DynamicVal
+	}
+
+	return v
+}
+
+func (m *hclModule) evalDependencies(traversals []hcl.Traversal) {
+	for _, t := range traversals {
+		name := traversalAttr(t, 1)
+		if name == "" {
+			continue
+		}
+
+		switch t.RootName() {
+		case "local":
+			m.evalLocal(name)
+		case "module":
+			m.evalModuleCall(name)
+		}
+	}
+}
+
+func (m *hclModule) evalLocal(name string) {
+	expr, ok := m.locals[name]
+	if !ok {
+		return
+	}
+
+	if _, ok := m.localVals[name]; ok {
+		return
+	}
+
+	key := "local." + name
+	if m.evaluating[key] {
+		log.Debugf("Cycle found evaluating %s%s", m.prefix(), key)
+		return
+	}
+
+	m.evaluating[key] = true
+	m.localVals[name] = m.evalExpr(expr, nil)
+	delete(m.evaluating, key)
+}
+
+func (m *hclModule) evalModuleCall(name string) {
+	block, ok := m.moduleCalls[name]
+	if !ok {
+		return
+	}
+
+	if _, ok := m.moduleVals[name]; ok {
+		return
+	}
+
+	key := "module." + name
+	if m.evaluating[key] {
+		log.Debugf("Cycle found evaluating %s%s", m.prefix(), key)
+		return
+	}
+
+	m.evaluating[key] = true
+	defer delete(m.evaluating, key)
+
+	source := moduleSource(block)
+
+	childKey := name
+	if m.key != "" {
+		childKey = m.key + "." + name
+	}
+
+	dir, err := m.parser.moduleDir(m.dir, childKey, source, moduleVersion(block))
+	if err != nil {
+		m.diags = append(m.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to download module",
+			Detail:   fmt.Sprintf("Module %s%s could not be downloaded from %s: %s. Run 'terraform get' to download it.", m.prefix(), key, source, err),
+			Subject:  block.DefRange().Ptr(),
+		})
+		m.moduleVals[name] = cty.DynamicVal
+		return
+	}
+
+	var outputs []cty.Value
+	keys := make([]string, 0)
+
+	instances, diags := m.expand(block, m.prefix()+key)
+	m.diags = append(m.diags, diags...)
+
+	for _, inst := range instances {
+		inputs := make(map[string]cty.Value)
+		for attrName, attr := range block.Body.Attributes {
+			if moduleMetaArguments[attrName] {
+				continue
+			}
+			inputs[attrName] = m.evalExpr(attr.Expr, inst.extra)
+		}
+
+		child, err := m.parser.loadModule(dir, childKey, inst.address, inputs)
+		if err != nil {
+			m.diags = append(m.diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to load module",
+				Detail:   fmt.Sprintf("Module %s could not be loaded: %s.", inst.address, err),
+				Subject:  block.DefRange().Ptr(),
+			})
+			continue
+		}
+
+		child.evaluate()
+
+		childOutputs := make(map[string]cty.Value, len(child.outputs))
+		for outputName, expr := range child.outputs {
+			childOutputs[outputName] = child.evalExpr(expr, nil)
+		}
+
+		m.children[name] = append(m.children[name], child)
+		outputs = append(outputs, objectVal(childOutputs))
+		keys = append(keys, inst.key)
+	}
+
+	switch {
+	case block.Body.Attributes["for_each"] != nil:
+		vals := make(map[string]cty.Value, len(outputs))
+		for i, k := range keys {
+			vals[k] = outputs[i]
+		}
+		m.moduleVals[name] = objectVal(vals)
+	case block.Body.Attributes["count"] != nil:
+		if len(outputs) == 0 {
+			m.moduleVals[name] = cty.EmptyTupleVal
+		} else {
+			m.moduleVals[name] = cty.TupleVal(outputs)
+		}
+	case len(outputs) == 1:
+		m.moduleVals[name] = outputs[0]
+	default:
+		m.moduleVals[name] = cty.DynamicVal
+	}
+}
+
+type hclInstance struct {
+	address string
+	key     string
+	extra   map[string]cty.Value
+}
+
+// expand returns an instance of the block for each count index or for_each
+// element. An unknown count is treated as a single instance, whereas blocks
+// with an unknown for_each are skipped since their keys aren't known. A
+// for_each map with known keys but unknown values is expanded with unknown
+// each.value values.
+func (m *hclModule) expand(block *hclsyntax.Block, address string) ([]hclInstance, hcl.Diagnostics) {
+	if attr, ok := block.Body.Attributes["count"]; ok {
+		v := m.evalExpr(attr.Expr, nil)
+
+		count := int64(1)
+		if v.IsKnown() && !v.IsNull() && v.Type() == cty.Number {
+			bf := v.AsBigFloat()
+			if !bf.IsInt() {
+				return nil, hcl.Diagnostics{invalidCountDiag(attr, "must be a whole number")}
+			}
+
+			count, _ = bf.Int64()
+			if count < 0 {
+				return nil, hcl.Diagnostics{invalidCountDiag(attr, "negative numbers are not supported")}
+			}
+		} else {
+			log.Debugf("Count for %s is unknown, treating it as 1", address)
+		}
+
+		instances := make([]hclInstance, 0, count)
+		for i := int64(0); i < count; i++ {
+			instances = append(instances, hclInstance{
+				address: fmt.Sprintf("%s[%d]", address, i),
+				key:     fmt.Sprintf("%d", i),
+				extra: map[string]cty.Value{
+					"count": cty.ObjectVal(map[string]cty.Value{"index": cty.NumberIntVal(i)}),
+				},
+			})
+		}
+
+		return instances, nil
+	}
+
+	if attr, ok := block.Body.Attributes["for_each"]; ok {
+		v := m.evalExpr(attr.Expr, nil)
+
+		// The keys of a set are its values, so it has to be wholly known
+		if !v.IsKnown() || v.IsNull() || !v.CanIterateElements() || (v.Type().IsSetType() && !v.IsWhollyKnown()) {
+			log.Warnf("Skipping %s since its for_each value is unknown", address)
+			return []hclInstance{}, nil
+		}
+
+		instances := make([]hclInstance, 0)
+		for it := v.ElementIterator(); it.Next(); {
+			k, val := it.Element()
+			if v.Type().IsSetType() {
+				k = val
+			}
+
+			if k.Type() != cty.String {
+				log.Warnf("Skipping %s since its for_each value is not a map or set of strings", address)
+				return []hclInstance{}, nil
+			}
+
+			key := k.AsString()
+			instances = append(instances, hclInstance{
+				address: fmt.Sprintf("%s[%q]", address, key),
+				key:     key,
+				extra: map[string]cty.Value{
+					"each": cty.ObjectVal(map[string]cty.Value{"key": k, "value": val}),
+				},
+			})
+		}
+
+		return instances, nil
+	}
+
+	return []hclInstance{{address: address}}, nil
+}
+
+func invalidCountDiag(attr *hclsyntax.Attribute, detail string) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid count argument",
+		Detail:   fmt.Sprintf(`The given "count" argument value is unsuitable: %s.`, detail),
+		Subject:  attr.Expr.Range().Ptr(),
+	}
+}
+
+func (m *hclModule) evalResource(block *hclsyntax.Block) {
+	if len(block.Labels) != 2 {
+		return
+	}
+
+	resourceType, name := block.Labels[0], block.Labels[1]
+
+	addr := fmt.Sprintf("%s.%s", resourceType, name)
+	if block.Type == "data" {
+		addr = "data." + addr
+	}
+
+	instances, diags := m.expand(block, m.prefix()+addr)
+	m.diags = append(m.diags, diags...)
+
+	for _, inst := range instances {
+		m.instances = append(m.instances, &hclResourceInstance{
+			block:   block,
+			address: inst.address,
+			values:  m.evalBody(block.Body, inst.extra, true),
+		})
+	}
+
+	m.confEntries = append(m.confEntries, m.resourceConf(block, addr))
+}
+
+// evalBody returns the values of the attributes and nested blocks. Nested
+// blocks are returned as lists of objects, the same as in the plan JSON.
+func (m *hclModule) evalBody(body *hclsyntax.Body, extra map[string]cty.Value, isResource bool) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	for name, attr := range body.Attributes {
+		if isResource && resourceMetaArguments[name] {
+			continue
+		}
+
+		if v := ctyToJSONValue(m.evalExpr(attr.Expr, extra)); v != nil {
+			values[name] = v
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if isResource && resourceMetaBlocks[block.Type] {
+			continue
+		}
+
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			blockType := block.Labels[0]
+			for _, v := range m.evalDynamicBlock(block, extra) {
+				values[blockType] = append(listValue(values[blockType]), v)
+			}
+			continue
+		}
+
+		values[block.Type] = append(listValue(values[block.Type]), m.evalBody(block.Body, extra, false))
+	}
+
+	return values
+}
+
+// evalDynamicBlock expands a dynamic block into the values of each of its
+// content blocks.
+func (m *hclModule) evalDynamicBlock(block *hclsyntax.Block, extra map[string]cty.Value) []interface{} {
+	values := make([]interface{}, 0)
+
+	attr, ok := block.Body.Attributes["for_each"]
+	if !ok {
+		return values
+	}
+
+	var content *hclsyntax.Block
+	for _, b := range block.Body.Blocks {
+		if b.Type == "content" {
+			content = b
+		}
+	}
+
+	if content == nil {
+		return values
+	}
+
+	iterator := block.Labels[0]
+	if attr, ok := block.Body.Attributes["iterator"]; ok {
+		if t, diags := hcl.AbsTraversalForExpr(attr.Expr); !diags.HasErrors() {
+			iterator = t.RootName()
+		}
+	}
+
+	v := m.evalExpr(attr.Expr, extra)
+	if !v.IsWhollyKnown() || v.IsNull() || !v.CanIterateElements() {
+		log.Debugf("Skipping dynamic %s block in %s since its for_each value is unknown", block.Labels[0], m.dir)
+		return values
+	}
+
+	for it := v.ElementIterator(); it.Next(); {
+		k, val := it.Element()
+
+		iterExtra := make(map[string]cty.Value, len(extra)+1)
+		for name, e := range extra {
+			iterExtra[name] = e
+		}
+		iterExtra[iterator] = cty.ObjectVal(map[string]cty.Value{"key": k, "value": val})
+
+		values = append(values, m.evalBody(content.Body, iterExtra, false))
+	}
+
+	return values
+}
+
+// resourceConf returns the configuration of the resource in the same format
+// as the plan JSON. Only the references of the top-level attributes are
+// included since those are what's used to link resources together.
+func (m *hclModule) resourceConf(block *hclsyntax.Block, addr string) map[string]interface{} {
+	expressions := make(map[string]interface{})
+	for name, attr := range block.Body.Attributes {
+		refs := traversalReferences(attr.Expr.Variables())
+		if len(refs) > 0 {
+			expressions[name] = map[string]interface{}{"references": refs}
+		}
+	}
+
+	providerKey := strings.Split(block.Labels[0], "_")[0]
+	if attr, ok := block.Body.Attributes["provider"]; ok {
+		if t, diags := hcl.AbsTraversalForExpr(attr.Expr); !diags.HasErrors() {
+			providerKey = strings.Join(traversalNames(t), ".")
+		}
+	}
+
+	if m.key != "" {
+		providerKey = fmt.Sprintf("%s:%s", m.key, providerKey)
+	}
+
+	mode := "managed"
+	if block.Type == "data" {
+		mode = "data"
+	}
+
+	return map[string]interface{}{
+		"address":             addr,
+		"mode":                mode,
+		"type":                block.Labels[0],
+		"name":                block.Labels[1],
+		"provider_config_key": providerKey,
+		"expressions":         expressions,
+	}
+}
+
+func (m *hclModule) plannedValues() map[string]interface{} {
+	resources := make([]interface{}, 0, len(m.instances))
+	for _, inst := range m.instances {
+		mode := "managed"
+		if inst.block.Type == "data" {
+			mode = "data"
+		}
+
+		resources = append(resources, map[string]interface{}{
+			"address":       inst.address,
+			"mode":          mode,
+			"type":          inst.block.Labels[0],
+			"name":          inst.block.Labels[1],
+			"provider_name": providerName(inst.block.Labels[0]),
+			"values":        inst.values,
+		})
+	}
+
+	childModules := make([]interface{}, 0)
+	for _, name := range m.callOrder {
+		for _, child := range m.children[name] {
+			childModules = append(childModules, child.plannedValues())
+		}
+	}
+
+	v := map[string]interface{}{
+		"resources":     resources,
+		"child_modules": childModules,
+	}
+
+	if m.address != "" {
+		v["address"] = m.address
+	}
+
+	return v
+}
+
+func (m *hclModule) configuration() map[string]interface{} {
+	moduleCalls := make(map[string]interface{})
+	for _, name := range m.callOrder {
+		call := map[string]interface{}{
+			"source": moduleSource(m.moduleCalls[name]),
+		}
+
+		if children := m.children[name]; len(children) > 0 {
+			call["module"] = children[0].configuration()
+		}
+
+		moduleCalls[name] = call
+	}
+
+	resources := m.confEntries
+	if resources == nil {
+		resources = []map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"resources":    resources,
+		"module_calls": moduleCalls,
+	}
+}
+
+// addProviderConfig adds the evaluated provider blocks so the region can be
+// found for each resource. The region is added as a constant value since the
+// variables it references have already been evaluated.
+func (m *hclModule) addProviderConfig(conf map[string]interface{}) {
+	for _, block := range m.providers {
+		if len(block.Labels) != 1 {
+			continue
+		}
+
+		key := block.Labels[0]
+
+		values := m.evalBody(block.Body, nil, false)
+		if alias, ok := values["alias"].(string); ok {
+			key = fmt.Sprintf("%s.%s", key, alias)
+		}
+
+		if m.key != "" {
+			key = fmt.Sprintf("%s:%s", m.key, key)
+		}
+
+		expressions := make(map[string]interface{})
+		if region, ok := values["region"].(string); ok {
+			expressions["region"] = map[string]interface{}{"constant_value": region}
+		}
+
+		conf[key] = map[string]interface{}{
+			"name":        block.Labels[0],
+			"expressions": expressions,
+		}
+	}
+
+	for _, name := range m.callOrder {
+		for _, child := range m.children[name] {
+			child.addProviderConfig(conf)
+		}
+	}
+}
+
+// moduleSource returns the source of a module call. Terraform requires this
+// to be a literal string.
+func moduleSource(block *hclsyntax.Block) string {
+	return literalString(block, "source")
+}
+
+// moduleVersion returns the version constraints of a registry module call.
+// Terraform requires this to be a literal string.
+func moduleVersion(block *hclsyntax.Block) string {
+	return literalString(block, "version")
+}
+
+func literalString(block *hclsyntax.Block, name string) string {
+	attr, ok := block.Body.Attributes[name]
+	if !ok {
+		return ""
+	}
+
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !v.IsKnown() || v.IsNull() || v.Type() != cty.String {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+func providerName(resourceType string) string {
+	prefix := strings.Split(resourceType, "_")[0]
+	if prefix == "infracost" {
+		return "registry.terraform.io/infracost/infracost"
+	}
+
+	return fmt.Sprintf("registry.terraform.io/hashicorp/%s", prefix)
+}
+
+// traversalReferences returns the references in the same format as the plan
+// JSON, e.g. an expression referencing aws_instance.web.id has the references
+// aws_instance.web.id and aws_instance.web.
+func traversalReferences(traversals []hcl.Traversal) []string {
+	refs := make([]string, 0, len(traversals))
+	seen := make(map[string]bool)
+
+	add := func(ref string) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, t := range traversals {
+		names := traversalNames(t)
+
+		switch names[0] {
+		case "count", "each", "path", "terraform", "self", "var", "local":
+			if len(names) > 1 {
+				add(strings.Join(names[:2], "."))
+			}
+		case "data":
+			add(strings.Join(names, "."))
+			if len(names) > 3 {
+				add(strings.Join(names[:3], "."))
+			}
+		default:
+			add(strings.Join(names, "."))
+			if len(names) > 2 {
+				add(strings.Join(names[:2], "."))
+			}
+		}
+	}
+
+	return refs
+}
+
+// traversalNames returns the names of the root and any attributes up to the
+// first index or splat.
+func traversalNames(t hcl.Traversal) []string {
+	names := []string{t.RootName()}
+
+	for _, step := range t[1:] {
+		attr, ok := step.(hcl.TraverseAttr)
+		if !ok {
+			break
+		}
+		names = append(names, attr.Name)
+	}
+
+	return names
+}
+
+func traversalAttr(t hcl.Traversal, i int) string {
+	if len(t) <= i {
+		return ""
+	}
+
+	if attr, ok := t[i].(hcl.TraverseAttr); ok {
+		return attr.Name
+	}
+
+	return ""
+}
+
+// parseRawVariable parses a variable value passed as a string. Lists and maps
+// are parsed as HCL, everything else is treated as a string in the same way
+// as Terraform does for variables with no type.
+func parseRawVariable(s string) cty.Value {
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+		expr, diags := hclsyntax.ParseExpression([]byte(trimmed), "", hcl.Pos{Line: 1, Column: 1})
+		if !diags.HasErrors() {
+			v, diags := expr.Value(nil)
+			if !diags.HasErrors() {
+				return v
+			}
+		}
+	}
+
+	return cty.StringVal(s)
+}
+
+func objectVal(vals map[string]cty.Value) cty.Value {
+	if len(vals) == 0 {
+		return cty.EmptyObjectVal
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+func listValue(v interface{}) []interface{} {
+	if l, ok := v.([]interface{}); ok {
+		return l
+	}
+
+	return []interface{}{}
+}
+
+// ctyToJSONValue converts the value into a value that can be marshalled to
+// JSON. Unknown and null values are returned as nil and are left out of
+// objects so they are treated as missing when the resource is parsed.
+func ctyToJSONValue(v cty.Value) interface{} {
+	if !v.IsKnown() || v.IsNull() {
+		return nil
+	}
+
+	t := v.Type()
+
+	switch {
+	case t == cty.String:
+		return v.AsString()
+	case t == cty.Number:
+		return json.Number(v.AsBigFloat().Text('f', -1))
+	case t == cty.Bool:
+		return v.True()
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		l := make([]interface{}, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			_, e := it.Element()
+			l = append(l, ctyToJSONValue(e))
+		}
+		return l
+	case t.IsMapType() || t.IsObjectType():
+		m := make(map[string]interface{})
+		for it := v.ElementIterator(); it.Next(); {
+			k, e := it.Element()
+			if j := ctyToJSONValue(e); j != nil {
+				m[k.AsString()] = j
+			}
+		}
+		return m
+	}
+
+	return nil
+}
+
+// hclFunctions returns the functions that can be used in expressions. These
+// are the functions from the cty standard library that match the Terraform
+// built-in functions. Calls to any other functions evaluate as unknown.
+func hclFunctions() map[string]function.Function {
+	return map[string]function.Function{
+		"abs":             stdlib.AbsoluteFunc,
+		"ceil":            stdlib.CeilFunc,
+		"chomp":           stdlib.ChompFunc,
+		"chunklist":       stdlib.ChunklistFunc,
+		"coalesce":        stdlib.CoalesceFunc,
+		"coalescelist":    stdlib.CoalesceListFunc,
+		"compact":         stdlib.CompactFunc,
+		"concat":          stdlib.ConcatFunc,
+		"contains":        stdlib.ContainsFunc,
+		"csvdecode":       stdlib.CSVDecodeFunc,
+		"distinct":        stdlib.DistinctFunc,
+		"element":         stdlib.ElementFunc,
+		"flatten":         stdlib.FlattenFunc,
+		"floor":           stdlib.FloorFunc,
+		"format":          stdlib.FormatFunc,
+		"formatdate":      stdlib.FormatDateFunc,
+		"formatlist":      stdlib.FormatListFunc,
+		"indent":          stdlib.IndentFunc,
+		"join":            stdlib.JoinFunc,
+		"jsondecode":      stdlib.JSONDecodeFunc,
+		"jsonencode":      stdlib.JSONEncodeFunc,
+		"keys":            stdlib.KeysFunc,
+		"length":          stdlib.LengthFunc,
+		"log":             stdlib.LogFunc,
+		"lookup":          stdlib.LookupFunc,
+		"lower":           stdlib.LowerFunc,
+		"max":             stdlib.MaxFunc,
+		"merge":           stdlib.MergeFunc,
+		"min":             stdlib.MinFunc,
+		"parseint":        stdlib.ParseIntFunc,
+		"pow":             stdlib.PowFunc,
+		"range":           stdlib.RangeFunc,
+		"regex":           stdlib.RegexFunc,
+		"regexall":        stdlib.RegexAllFunc,
+		"replace":         stdlib.ReplaceFunc,
+		"reverse":         stdlib.ReverseListFunc,
+		"setintersection": stdlib.SetIntersectionFunc,
+		"setproduct":      stdlib.SetProductFunc,
+		"setsubtract":     stdlib.SetSubtractFunc,
+		"setunion":        stdlib.SetUnionFunc,
+		"signum":          stdlib.SignumFunc,
+		"slice":           stdlib.SliceFunc,
+		"sort":            stdlib.SortFunc,
+		"split":           stdlib.SplitFunc,
+		"strrev":          stdlib.ReverseFunc,
+		"substr":          stdlib.SubstrFunc,
+		"timeadd":         stdlib.TimeAddFunc,
+		"title":           stdlib.TitleFunc,
+		"tobool":          stdlib.MakeToFunc(cty.Bool),
+		"tolist":          stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+		"tomap":           stdlib.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+		"tonumber":        stdlib.MakeToFunc(cty.Number),
+		"toset":           stdlib.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
+		"tostring":        stdlib.MakeToFunc(cty.String),
+		"trim":            stdlib.TrimFunc,
+		"trimprefix":      stdlib.TrimPrefixFunc,
+		"trimspace":       stdlib.TrimSpaceFunc,
+		"trimsuffix":      stdlib.TrimSuffixFunc,
+		"upper":           stdlib.UpperFunc,
+		"values":          stdlib.ValuesFunc,
+		"zipmap":          stdlib.ZipmapFunc,
+	}
+}
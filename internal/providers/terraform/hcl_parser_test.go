@@ -0,0 +1,309 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func writeHCLFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	return dir
+}
+
+func parseHCLDir(t *testing.T, dir string, opts HCLParserOptions) gjson.Result {
+	t.Helper()
+
+	j, err := NewHCLParser(dir, opts).ParseJSON()
+	require.NoError(t, err)
+
+	return gjson.ParseBytes(j)
+}
+
+func TestHCLParserVariablesAndLocals(t *testing.T) {
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": `
+			provider "aws" {
+				region = var.region
+			}
+
+			variable "region" {
+				default = "us-east-1"
+			}
+
+			variable "instance_type" {}
+
+			variable "volume_size" {
+				default = 10
+			}
+
+			locals {
+				size = local.base_size * 2
+				base_size = var.volume_size
+				tags = merge({ team = "payments" }, { env = terraform.workspace })
+			}
+
+			resource "aws_instance" "web" {
+				ami           = "ami-674cbc1e"
+				instance_type = var.instance_type
+				tags          = local.tags
+
+				root_block_device {
+					volume_size = local.size
+				}
+			}
+		`,
+		"terraform.tfvars": `instance_type = "m5.large"`,
+		"prod.tfvars":      `region = "eu-west-1"`,
+	})
+
+	parsed := parseHCLDir(t, dir, HCLParserOptions{
+		VarFiles:  []string{"prod.tfvars"},
+		Workspace: "prod",
+	})
+
+	r := parsed.Get(`planned_values.root_module.resources.#(address="aws_instance.web")`)
+	require.True(t, r.Exists())
+
+	assert.Equal(t, "registry.terraform.io/hashicorp/aws", r.Get("provider_name").String())
+	assert.Equal(t, "m5.large", r.Get("values.instance_type").String())
+	assert.Equal(t, int64(20), r.Get("values.root_block_device.0.volume_size").Int())
+	assert.Equal(t, "payments", r.Get("values.tags.team").String())
+	assert.Equal(t, "prod", r.Get("values.tags.env").String())
+
+	assert.Equal(t, "eu-west-1", parsed.Get("configuration.provider_config.aws.expressions.region.constant_value").String())
+}
+
+func TestHCLParserCountAndForEach(t *testing.T) {
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": `
+			variable "unknown" {}
+
+			resource "aws_instance" "count" {
+				count         = 2
+				instance_type = "t3.micro"
+				tags          = { Name = "web-${count.index}" }
+			}
+
+			resource "aws_instance" "zero" {
+				count         = 0
+				instance_type = "t3.micro"
+			}
+
+			resource "aws_instance" "unknown_count" {
+				count         = var.unknown
+				instance_type = "t3.micro"
+			}
+
+			resource "aws_instance" "for_each" {
+				for_each      = { small = "t3.micro", large = "m5.large" }
+				instance_type = each.value
+			}
+
+			resource "aws_instance" "unknown_for_each" {
+				for_each      = var.unknown
+				instance_type = each.value
+			}
+
+			resource "aws_eip" "unknown_for_each_values" {
+				for_each = { small = aws_instance.for_each["small"].id, large = aws_instance.for_each["large"].id }
+				instance = each.value
+			}
+		`,
+	})
+
+	parsed := parseHCLDir(t, dir, HCLParserOptions{})
+	resources := parsed.Get("planned_values.root_module.resources")
+
+	addrs := make([]string, 0)
+	for _, r := range resources.Array() {
+		addrs = append(addrs, r.Get("address").String())
+	}
+
+	assert.ElementsMatch(t, []string{
+		"aws_instance.count[0]",
+		"aws_instance.count[1]",
+		"aws_instance.unknown_count[0]",
+		`aws_instance.for_each["large"]`,
+		`aws_instance.for_each["small"]`,
+		`aws_eip.unknown_for_each_values["large"]`,
+		`aws_eip.unknown_for_each_values["small"]`,
+	}, addrs)
+
+	assert.Equal(t, "web-1", resources.Get(`#(address="aws_instance.count[1]").values.tags.Name`).String())
+	assert.Equal(t, "m5.large", resources.Get(`#(address="aws_instance.for_each[\"large\"]").values.instance_type`).String())
+}
+
+func TestHCLParserInvalidCount(t *testing.T) {
+	tests := map[string]string{
+		"negative":     "-1",
+		"whole number": "1.5",
+	}
+
+	for name, count := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeHCLFiles(t, map[string]string{
+				"main.tf": `
+					resource "aws_instance" "web" {
+						count         = ` + count + `
+						instance_type = "t3.micro"
+					}
+				`,
+			})
+
+			_, err := NewHCLParser(dir, HCLParserOptions{}).ParseJSON()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Invalid count argument")
+		})
+	}
+
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": `
+			module "app" {
+				source = "./app"
+			}
+		`,
+		"app/main.tf": `
+			resource "aws_instance" "web" {
+				count         = -1
+				instance_type = "t3.micro"
+			}
+		`,
+	})
+
+	_, err := NewHCLParser(dir, HCLParserOptions{}).ParseJSON()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "negative numbers are not supported")
+}
+
+func TestHCLParserUnknownValues(t *testing.T) {
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": `
+			data "aws_ami" "ubuntu" {
+				most_recent = true
+			}
+
+			resource "aws_instance" "web" {
+				ami           = data.aws_ami.ubuntu.id
+				instance_type = upper("t3.micro")
+				user_data     = templatefile("init.tpl", {})
+			}
+
+			resource "aws_eip" "web" {
+				instance = aws_instance.web.id
+			}
+		`,
+	})
+
+	parsed := parseHCLDir(t, dir, HCLParserOptions{})
+	resources := parsed.Get("planned_values.root_module.resources")
+
+	web := resources.Get(`#(address="aws_instance.web")`)
+	assert.False(t, web.Get("values.ami").Exists())
+	assert.False(t, web.Get("values.user_data").Exists())
+	assert.Equal(t, "T3.MICRO", web.Get("values.instance_type").String())
+
+	assert.True(t, resources.Get(`#(address="data.aws_ami.ubuntu")`).Exists())
+
+	eipConf := parsed.Get(`configuration.root_module.resources.#(address="aws_eip.web")`)
+	assert.Equal(t, []interface{}{"aws_instance.web.id", "aws_instance.web"}, eipConf.Get("expressions.instance.references").Value())
+}
+
+func TestHCLParserModules(t *testing.T) {
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": `
+			module "app" {
+				source        = "./modules/app"
+				count         = 2
+				instance_type = "m5.large"
+			}
+
+			module "db" {
+				source = "terraform-aws-modules/rds/aws"
+				size   = module.app[0].volume_size
+			}
+
+		`,
+		"modules/app/main.tf": `
+			variable "instance_type" {}
+
+			resource "aws_instance" "this" {
+				instance_type = var.instance_type
+			}
+
+			output "volume_size" {
+				value = 50
+			}
+		`,
+		".terraform/modules/modules.json": `{"Modules": [{"Key": "db", "Source": "terraform-aws-modules/rds/aws", "Dir": ".terraform/modules/db"}]}`,
+		".terraform/modules/db/main.tf": `
+			variable "size" {}
+
+			resource "aws_ebs_volume" "this" {
+				size = var.size
+			}
+		`,
+	})
+
+	parsed := parseHCLDir(t, dir, HCLParserOptions{})
+	modules := parsed.Get("planned_values.root_module.child_modules")
+	require.Len(t, modules.Array(), 3)
+
+	assert.Equal(t, "module.app[1]", modules.Get("1.address").String())
+	assert.Equal(t, "module.app[1].aws_instance.this", modules.Get("1.resources.0.address").String())
+	assert.Equal(t, "m5.large", modules.Get("1.resources.0.values.instance_type").String())
+
+	assert.Equal(t, "module.db.aws_ebs_volume.this", modules.Get("2.resources.0.address").String())
+	assert.Equal(t, int64(50), modules.Get("2.resources.0.values.size").Int())
+
+	conf := parsed.Get("configuration.root_module.module_calls")
+	assert.Equal(t, "terraform-aws-modules/rds/aws", conf.Get("db.source").String())
+	assert.True(t, conf.Get(`db.module.resources.#(address="aws_ebs_volume.this")`).Exists())
+}
+
+func TestHCLParserDynamicBlocks(t *testing.T) {
+	dir := writeHCLFiles(t, map[string]string{
+		"main.tf": `
+			resource "aws_instance" "web" {
+				instance_type = "t3.micro"
+
+				dynamic "ebs_block_device" {
+					for_each = [100, 200]
+					iterator = disk
+					content {
+						volume_size = disk.value
+					}
+				}
+			}
+		`,
+	})
+
+	parsed := parseHCLDir(t, dir, HCLParserOptions{})
+	devices := parsed.Get(`planned_values.root_module.resources.#(address="aws_instance.web").values.ebs_block_device`)
+
+	require.Len(t, devices.Array(), 2)
+	assert.Equal(t, int64(200), devices.Get("1.volume_size").Int())
+}
+
+func TestParseHCLPlanFlags(t *testing.T) {
+	opts, err := ParseHCLPlanFlags(`-var-file=prod.tfvars -var "instance_type=m5.large" --var-file common.tfvars -lock=false`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"prod.tfvars", "common.tfvars"}, opts.VarFiles)
+	assert.Equal(t, map[string]string{"instance_type": "m5.large"}, opts.Vars)
+
+	_, err = ParseHCLPlanFlags(`-var instance_type`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+)
+
+// HCLProvider loads the resources in a Terraform directory by parsing the
+// .tf files directly, so it doesn't need the Terraform binary, cloud
+// credentials or access to the backend.
+type HCLProvider struct {
+	ctx         *config.ProjectContext
+	Path        string
+	PlanFlags   string
+	Workspace   string
+	spinnerOpts ui.SpinnerOptions
+}
+
+func NewHCLProvider(ctx *config.ProjectContext) schema.Provider {
+	return &HCLProvider{
+		ctx:       ctx,
+		Path:      ctx.ProjectConfig.Path,
+		PlanFlags: ctx.ProjectConfig.TerraformPlanFlags,
+		Workspace: ctx.ProjectConfig.TerraformWorkspace,
+		spinnerOpts: ui.SpinnerOptions{
+			EnableLogging: ctx.RunContext.Config.IsLogging(),
+			NoColor:       ctx.RunContext.Config.NoColor,
+			Indent:        "  ",
+		},
+	}
+}
+
+func (p *HCLProvider) Type() string {
+	return "terraform_hcl"
+}
+
+func (p *HCLProvider) DisplayType() string {
+	return "Terraform directory (HCL)"
+}
+
+func (p *HCLProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	metadata.TerraformWorkspace = p.Workspace
+}
+
+func (p *HCLProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	opts, err := ParseHCLPlanFlags(p.PlanFlags)
+	if err != nil {
+		return []*schema.Project{}, err
+	}
+	opts.Workspace = p.Workspace
+
+	spinner := ui.NewSpinner("Parsing Terraform HCL files", p.spinnerOpts)
+	defer spinner.Fail()
+
+	j, err := NewHCLParser(p.Path, opts).ParseJSON()
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error parsing Terraform HCL files")
+	}
+
+	spinner.Success()
+
+	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	p.AddMetadata(metadata)
+	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	project := schema.NewProject(name, metadata)
+	parser := NewParser(p.ctx)
+
+	pastResources, resources, err := parser.parseJSON(j, usage)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Terraform HCL files")
+	}
+
+	project.PastResources = pastResources
+	project.Resources = resources
+
+	return []*schema.Project{project}, nil
+}
@@ -69,8 +69,13 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		if res != nil {
 			res.ResourceType = d.Type
 			res.Tags = d.Tags
+			res.SetRebuildFunc(u, func(u *schema.UsageData) *schema.Resource {
+				return registryItem.RFunc(d, u)
+			})
+			res.CloudResourceIDs = cloudResourceIDs(d)
 			if u != nil {
 				res.EstimationSummary = u.CalcEstimationSummary()
+				res.EstimatedUsageKeys = u.EstimatedKeys
 			}
 			return res
 		}
@@ -85,6 +90,20 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 	}
 }
 
+// cloudResourceIDs returns the IDs of the deployed resource from the state,
+// e.g. the ID and ARN of AWS resources, so it can be matched to the line items
+// in cloud billing exports.
+func cloudResourceIDs(d *schema.ResourceData) []string {
+	ids := make([]string, 0)
+	for _, attr := range []string{"id", "arn", "self_link"} {
+		if v := d.Get(attr).String(); v != "" {
+			ids = append(ids, v)
+		}
+	}
+
+	return ids
+}
+
 func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Resource, usage map[string]*schema.UsageData, parsed, providerConf, conf, vars gjson.Result) []*schema.Resource {
 	var resources []*schema.Resource
 	resources = append(resources, baseResources...)
@@ -21,6 +21,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/prices"
 	"github.com/infracost/infracost/internal/schema"
@@ -266,11 +267,12 @@ func RunCostCalculations(t *testing.T, runCtx *config.RunContext, tfProject Terr
 		return projects, err
 	}
 
+	err = prices.PopulatePrices(apiclient.NewPricingAPIClient(runCtx), projects, nil)
+	if err != nil {
+		return projects, err
+	}
+
 	for _, project := range projects {
-		err = prices.PopulatePrices(runCtx, project)
-		if err != nil {
-			return projects, err
-		}
 		schema.CalculateCosts(project)
 	}
 
@@ -312,7 +314,7 @@ func RunSyncUsage(t *testing.T, runCtx *config.RunContext, tfProject TerraformPr
 		return nil, err
 	}
 
-	_, err = usage.SyncUsageData(usageFile, projects)
+	_, err = usage.SyncUsageData(context.Background(), usageFile, projects)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,147 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// ToJSON formats the report as JSON.
+func ToJSON(report *Report) ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// ToTable formats the report for the terminal, showing the estimated and
+// actual monthly costs of each resource and its cost components, followed by
+// the resource types and the accuracy score.
+func ToTable(report *Report) []byte {
+	var b strings.Builder
+
+	t := newTable(report.Currency, "Name")
+	for _, r := range report.Resources {
+		t.AppendRow(append(table.Row{ui.BoldString(r.Name)}, costCells(report.Currency, r.EstimatedMonthlyCost, r.ActualMonthlyCost, r.VariancePercent)...))
+
+		for i, c := range r.Components {
+			prefix := "├─"
+			if i == len(r.Components)-1 && r.UnattributedMonthlyCost.IsZero() {
+				prefix = "└─"
+			}
+
+			t.AppendRow(append(table.Row{fmt.Sprintf("%s %s", ui.FaintString(prefix), c.Name)}, costCells(report.Currency, c.EstimatedMonthlyCost, c.ActualMonthlyCost, nil)[:2]...))
+		}
+
+		if !r.UnattributedMonthlyCost.IsZero() {
+			t.AppendRow(table.Row{fmt.Sprintf("%s %s", ui.FaintString("└─"), ui.FaintString("Unattributed")), "", output.FormatCost2DP(report.Currency, &r.UnattributedMonthlyCost)})
+		}
+
+		t.AppendRow(table.Row{""})
+	}
+	b.WriteString(t.Render())
+	b.WriteString("\n\n")
+
+	t = newTable(report.Currency, "Resource type")
+	for _, rt := range report.ResourceTypes {
+		name := fmt.Sprintf("%s (%d)", rt.ResourceType, rt.ResourceCount)
+		if rt.Flagged {
+			name = ui.WarningString(name + " ⚠")
+		}
+
+		t.AppendRow(append(table.Row{name}, costCells(report.Currency, rt.EstimatedMonthlyCost, rt.ActualMonthlyCost, rt.VariancePercent)...))
+	}
+	b.WriteString(t.Render())
+	b.WriteString("\n\n")
+
+	fmt.Fprintf(&b, "%s %.1f%%\n", ui.BoldString("Accuracy score:"), report.AccuracyScore)
+	fmt.Fprintf(&b, "Actual costs are averaged over %d month%s of billing data\n", report.Months, pluralize(report.Months))
+
+	flagged := 0
+	for _, rt := range report.ResourceTypes {
+		if rt.Flagged {
+			flagged++
+		}
+	}
+
+	if flagged > 0 {
+		fmt.Fprintf(&b, "%d resource type%s %s off by more than %.0f%%, check the pricing and default usage for %s\n",
+			flagged,
+			pluralize(flagged),
+			pluralizeVerb(flagged),
+			report.Threshold,
+			pluralizeIt(flagged),
+		)
+	}
+
+	if len(report.UnmatchedResources) > 0 {
+		fmt.Fprintf(&b, "%d resource%s couldn't be matched to the billing export\n", len(report.UnmatchedResources), pluralize(len(report.UnmatchedResources)))
+	}
+
+	return []byte(b.String())
+}
+
+func newTable(currency string, title string) table.Writer {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString(title),
+		ui.UnderlineString(fmt.Sprintf("Estimated (%s)", currency)),
+		ui.UnderlineString(fmt.Sprintf("Actual (%s)", currency)),
+		ui.UnderlineString("Variance"),
+	})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	t.AppendRow(table.Row{""})
+
+	return t
+}
+
+func costCells(currency string, estimated decimal.Decimal, actual decimal.Decimal, variancePercent *float64) table.Row {
+	variance := "-"
+	if variancePercent != nil {
+		variance = fmt.Sprintf("%+.1f%%", *variancePercent)
+	}
+
+	return table.Row{
+		output.FormatCost2DP(currency, &estimated),
+		output.FormatCost2DP(currency, &actual),
+		variance,
+	}
+}
+
+func pluralize(count int) string {
+	if count == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func pluralizeVerb(count int) string {
+	if count == 1 {
+		return "is"
+	}
+	return "are"
+}
+
+func pluralizeIt(count int) string {
+	if count == 1 {
+		return "it"
+	}
+	return "them"
+}
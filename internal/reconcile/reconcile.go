@@ -0,0 +1,312 @@
+package reconcile
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/billing"
+)
+
+// DefaultThreshold is the default percentage that the estimates can be off by
+// before they're flagged.
+const DefaultThreshold = 25
+
+// minFlaggedResources is the number of resources of a type that need to be
+// reconciled before the type can be flagged, so a single unusual resource
+// doesn't flag the pricing logic of the whole type.
+const minFlaggedResources = 2
+
+// flaggedResourcesRatio is the ratio of the reconciled resources of a type that
+// need to be off by more than the threshold in the same direction for the type
+// to be flagged.
+const flaggedResourcesRatio = 0.75
+
+var errNoMatches = errors.New("None of the resources matched the line items in the billing export. Resources are matched by their IDs, so the Infracost JSON file should be generated from deployed resources with --include-cloud-resource-ids, or by their tags")
+
+var addressIndexRegexp = regexp.MustCompile(`\[[^\]]*\]`)
+
+// Options for reconciling the estimates.
+type Options struct {
+	// Threshold is the percentage that the estimates can be off by before
+	// they're flagged, e.g. 25 for 25%.
+	Threshold float64
+}
+
+// Component is the estimated and actual monthly cost of a cost component.
+type Component struct {
+	Name                 string          `json:"name"`
+	EstimatedMonthlyCost decimal.Decimal `json:"estimatedMonthlyCost"`
+	ActualMonthlyCost    decimal.Decimal `json:"actualMonthlyCost"`
+}
+
+// Resource is the estimated and actual monthly cost of a resource. Actual
+// costs that can't be attributed to one of the cost components, e.g. because
+// the resource is billed for something Infracost doesn't price, are included
+// in the resource's actual cost and shown as unattributed.
+type Resource struct {
+	Name                    string          `json:"name"`
+	ResourceType            string          `json:"resourceType"`
+	EstimatedMonthlyCost    decimal.Decimal `json:"estimatedMonthlyCost"`
+	ActualMonthlyCost       decimal.Decimal `json:"actualMonthlyCost"`
+	UnattributedMonthlyCost decimal.Decimal `json:"unattributedMonthlyCost"`
+	// VariancePercent is how far the estimate is from the actual cost, which
+	// is positive if the estimate is higher. It's nil if there's no actual cost.
+	VariancePercent *float64    `json:"variancePercent"`
+	Components      []Component `json:"costComponents"`
+}
+
+// ResourceType is the estimated and actual monthly cost of all the reconciled
+// resources of a type. A type is flagged if most of its resources are off by
+// more than the threshold in the same direction, which means the pricing
+// logic or the default usage of the type is probably wrong.
+type ResourceType struct {
+	ResourceType         string          `json:"resourceType"`
+	ResourceCount        int             `json:"resourceCount"`
+	EstimatedMonthlyCost decimal.Decimal `json:"estimatedMonthlyCost"`
+	ActualMonthlyCost    decimal.Decimal `json:"actualMonthlyCost"`
+	VariancePercent      *float64        `json:"variancePercent"`
+	Flagged              bool            `json:"flagged"`
+}
+
+// Report is the reconciliation of the estimates in an Infracost JSON output
+// with the actual costs from a billing export.
+type Report struct {
+	Currency string `json:"currency"`
+	// Months is the number of months in the billing export that the actual
+	// costs are averaged over.
+	Months    int     `json:"months"`
+	Threshold float64 `json:"threshold"`
+	// AccuracyScore is a percentage of how close the estimates are to the
+	// actual costs over all the reconciled resources, where 100 means they're
+	// the same.
+	AccuracyScore      float64        `json:"accuracyScore"`
+	Resources          []Resource     `json:"resources"`
+	ResourceTypes      []ResourceType `json:"resourceTypes"`
+	UnmatchedResources []string       `json:"unmatchedResources"`
+}
+
+// Reconcile matches the resources in the Infracost JSON output to the line
+// items of the billing export and compares the estimated monthly costs with
+// the actual monthly costs. The actual costs are averaged over the months in
+// the export.
+func Reconcile(out output.Root, export *billing.Export, opts Options) (*Report, error) {
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	resources := make([]output.Resource, 0)
+	for _, p := range out.Projects {
+		if p.Breakdown != nil {
+			resources = append(resources, p.Breakdown.Resources...)
+		}
+	}
+
+	refs := make([]*schema.Resource, 0, len(resources))
+	for _, r := range resources {
+		refs = append(refs, &schema.Resource{
+			Name:             r.Name,
+			ResourceType:     resourceType(r.Name),
+			Tags:             r.Tags,
+			CloudResourceIDs: r.CloudResourceIDs,
+		})
+	}
+
+	matched := export.MatchLineItems(refs)
+	if len(matched) == 0 {
+		return nil, errNoMatches
+	}
+
+	months := decimal.NewFromInt(int64(export.Months()))
+
+	report := &Report{
+		Currency:           out.Currency,
+		Months:             export.Months(),
+		Threshold:          threshold,
+		Resources:          make([]Resource, 0, len(matched)),
+		ResourceTypes:      make([]ResourceType, 0),
+		UnmatchedResources: make([]string, 0),
+	}
+
+	for i, r := range resources {
+		items, ok := matched[r.Name]
+		if !ok {
+			report.UnmatchedResources = append(report.UnmatchedResources, r.Name)
+			continue
+		}
+
+		report.Resources = append(report.Resources, reconcileResource(r, refs[i].ResourceType, items, export, months))
+	}
+
+	report.ResourceTypes = summarizeResourceTypes(report.Resources, threshold)
+	report.AccuracyScore = accuracyScore(report.Resources)
+
+	return report, nil
+}
+
+func reconcileResource(r output.Resource, resourceType string, items []*billing.LineItem, export *billing.Export, months decimal.Decimal) Resource {
+	components := flattenedComponents(r)
+	actuals := make([]decimal.Decimal, len(components))
+
+	actual := decimal.Zero
+	unattributed := decimal.Zero
+
+	for _, item := range items {
+		cost := decimal.NewFromFloat(item.Cost).Div(months)
+		actual = actual.Add(cost)
+
+		attributed := false
+		for i, c := range components {
+			if export.MatchesComponent(resourceType, item, c.Name) {
+				actuals[i] = actuals[i].Add(cost)
+				attributed = true
+				break
+			}
+		}
+
+		if !attributed {
+			unattributed = unattributed.Add(cost)
+		}
+	}
+
+	res := Resource{
+		Name:                    r.Name,
+		ResourceType:            resourceType,
+		EstimatedMonthlyCost:    decimalOrZero(r.MonthlyCost),
+		ActualMonthlyCost:       actual,
+		UnattributedMonthlyCost: unattributed,
+		Components:              make([]Component, 0, len(components)),
+	}
+	res.VariancePercent = variancePercent(res.EstimatedMonthlyCost, res.ActualMonthlyCost)
+
+	for i, c := range components {
+		res.Components = append(res.Components, Component{
+			Name:                 c.Name,
+			EstimatedMonthlyCost: decimalOrZero(c.MonthlyCost),
+			ActualMonthlyCost:    actuals[i],
+		})
+	}
+
+	return res
+}
+
+// flattenedComponents returns the cost components of the resource and its
+// sub-resources.
+func flattenedComponents(r output.Resource) []output.CostComponent {
+	components := append([]output.CostComponent{}, r.CostComponents...)
+	for _, s := range r.SubResources {
+		components = append(components, flattenedComponents(s)...)
+	}
+
+	return components
+}
+
+func summarizeResourceTypes(resources []Resource, threshold float64) []ResourceType {
+	byType := make(map[string]*ResourceType)
+	over := make(map[string]int)
+	under := make(map[string]int)
+
+	for _, r := range resources {
+		t, ok := byType[r.ResourceType]
+		if !ok {
+			t = &ResourceType{
+				ResourceType:         r.ResourceType,
+				EstimatedMonthlyCost: decimal.Zero,
+				ActualMonthlyCost:    decimal.Zero,
+			}
+			byType[r.ResourceType] = t
+		}
+
+		t.ResourceCount++
+		t.EstimatedMonthlyCost = t.EstimatedMonthlyCost.Add(r.EstimatedMonthlyCost)
+		t.ActualMonthlyCost = t.ActualMonthlyCost.Add(r.ActualMonthlyCost)
+
+		if r.VariancePercent == nil {
+			continue
+		}
+
+		if *r.VariancePercent > threshold {
+			over[r.ResourceType]++
+		} else if *r.VariancePercent < -threshold {
+			under[r.ResourceType]++
+		}
+	}
+
+	types := make([]ResourceType, 0, len(byType))
+	for _, t := range byType {
+		t.VariancePercent = variancePercent(t.EstimatedMonthlyCost, t.ActualMonthlyCost)
+
+		minCount := int(math.Ceil(float64(t.ResourceCount) * flaggedResourcesRatio))
+		if t.ResourceCount >= minFlaggedResources && (over[t.ResourceType] >= minCount || under[t.ResourceType] >= minCount) {
+			t.Flagged = true
+		}
+
+		types = append(types, *t)
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].ResourceType < types[j].ResourceType
+	})
+
+	return types
+}
+
+// accuracyScore returns how close the estimates are to the actual costs as a
+// percentage, using the total absolute difference relative to the total
+// actual cost so that the larger resources count for more.
+func accuracyScore(resources []Resource) float64 {
+	totalDiff := decimal.Zero
+	totalActual := decimal.Zero
+
+	for _, r := range resources {
+		totalDiff = totalDiff.Add(r.EstimatedMonthlyCost.Sub(r.ActualMonthlyCost).Abs())
+		totalActual = totalActual.Add(r.ActualMonthlyCost)
+	}
+
+	if totalActual.IsZero() {
+		if totalDiff.IsZero() {
+			return 100
+		}
+		return 0
+	}
+
+	score, _ := decimal.NewFromInt(1).Sub(totalDiff.Div(totalActual)).Mul(decimal.NewFromInt(100)).Round(1).Float64()
+
+	return math.Max(score, 0)
+}
+
+func variancePercent(estimated decimal.Decimal, actual decimal.Decimal) *float64 {
+	if actual.IsZero() {
+		return nil
+	}
+
+	perc, _ := estimated.Sub(actual).Div(actual).Mul(decimal.NewFromInt(100)).Round(1).Float64()
+
+	return &perc
+}
+
+// resourceType returns the resource type from a resource address, e.g.
+// aws_instance for module.app.aws_instance.web["a"].
+func resourceType(address string) string {
+	parts := strings.Split(addressIndexRegexp.ReplaceAllString(address, ""), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[len(parts)-2]
+}
+
+func decimalOrZero(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+
+	return *d
+}
@@ -0,0 +1,149 @@
+package reconcile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/usage/billing"
+)
+
+func decimalPtr(f float64) *decimal.Decimal {
+	d := decimal.NewFromFloat(f)
+	return &d
+}
+
+func lambdaResource(name string, id string, requests float64, duration float64) output.Resource {
+	return output.Resource{
+		Name:             name,
+		CloudResourceIDs: []string{id},
+		MonthlyCost:      decimalPtr(requests + duration),
+		CostComponents: []output.CostComponent{
+			{Name: "Requests", MonthlyCost: decimalPtr(requests)},
+			{Name: "Duration", MonthlyCost: decimalPtr(duration)},
+		},
+	}
+}
+
+func testExport(t *testing.T) *billing.Export {
+	export, err := billing.ReadExport(strings.NewReader(`lineItem/LineItemType,lineItem/UsageStartDate,lineItem/ProductCode,lineItem/UsageType,lineItem/ResourceId,lineItem/UsageAmount,lineItem/UnblendedCost
+Usage,2021-09-01T00:00:00Z,AWSLambda,USE1-Request,arn:aws:lambda:us-east-1:123456789012:function:fn-a,1000000,2
+Usage,2021-10-01T00:00:00Z,AWSLambda,USE1-Request,arn:aws:lambda:us-east-1:123456789012:function:fn-a,1000000,2
+Usage,2021-10-01T00:00:00Z,AWSLambda,USE1-Lambda-GB-Second,arn:aws:lambda:us-east-1:123456789012:function:fn-a,100000,16
+Usage,2021-10-01T00:00:00Z,AWSLambda,USE1-Lambda-Provisioned-Concurrency,arn:aws:lambda:us-east-1:123456789012:function:fn-a,100,2
+Usage,2021-10-01T00:00:00Z,AWSLambda,USE1-Request,arn:aws:lambda:us-east-1:123456789012:function:fn-b,1000000,20
+Usage,2021-10-01T00:00:00Z,AWSLambda,USE1-Request,arn:aws:lambda:us-east-1:123456789012:function:fn-c,1000000,10
+`))
+	require.NoError(t, err)
+
+	return export
+}
+
+func TestReconcile(t *testing.T) {
+	out := output.Root{
+		Currency: "USD",
+		Projects: []output.Project{
+			{
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						lambdaResource("aws_lambda_function.a", "fn-a", 4, 8),
+						lambdaResource("module.app.aws_lambda_function.b[\"x.y\"]", "fn-b", 5, 0),
+						lambdaResource("aws_lambda_function.c", "fn-c", 2, 0),
+						lambdaResource("aws_lambda_function.not_deployed", "fn-d", 1, 0),
+					},
+				},
+			},
+		},
+	}
+
+	report, err := Reconcile(out, testExport(t), Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Months)
+	assert.Equal(t, float64(DefaultThreshold), report.Threshold)
+	assert.Equal(t, []string{"aws_lambda_function.not_deployed"}, report.UnmatchedResources)
+	require.Len(t, report.Resources, 3)
+
+	// The costs are averaged over the two months in the export
+	a := report.Resources[0]
+	assert.Equal(t, "aws_lambda_function", a.ResourceType)
+	assert.Equal(t, "12", a.EstimatedMonthlyCost.String())
+	assert.Equal(t, "11", a.ActualMonthlyCost.String())
+	assert.Equal(t, "1", a.UnattributedMonthlyCost.String())
+	assert.Equal(t, 9.1, *a.VariancePercent)
+	assert.Equal(t, "2", a.Components[0].ActualMonthlyCost.String())
+	assert.Equal(t, "8", a.Components[1].ActualMonthlyCost.String())
+
+	b := report.Resources[1]
+	assert.Equal(t, "aws_lambda_function", b.ResourceType)
+	assert.Equal(t, -50.0, *b.VariancePercent)
+
+	c := report.Resources[2]
+	assert.Equal(t, -60.0, *c.VariancePercent)
+
+	require.Len(t, report.ResourceTypes, 1)
+	rt := report.ResourceTypes[0]
+	assert.Equal(t, "aws_lambda_function", rt.ResourceType)
+	assert.Equal(t, 3, rt.ResourceCount)
+	assert.Equal(t, "19", rt.EstimatedMonthlyCost.String())
+	assert.Equal(t, "26", rt.ActualMonthlyCost.String())
+	assert.Equal(t, -26.9, *rt.VariancePercent)
+	// Only two of the three resources are off by more than the threshold
+	assert.False(t, rt.Flagged)
+
+	// |12-11| + |5-10| + |2-5| = 9 over an actual cost of 26
+	assert.Equal(t, 65.4, report.AccuracyScore)
+}
+
+func TestReconcileFlagsResourceTypes(t *testing.T) {
+	out := output.Root{
+		Currency: "USD",
+		Projects: []output.Project{
+			{
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						lambdaResource("aws_lambda_function.b", "fn-b", 5, 0),
+						lambdaResource("aws_lambda_function.c", "fn-c", 2, 0),
+					},
+				},
+			},
+		},
+	}
+
+	report, err := Reconcile(out, testExport(t), Options{Threshold: 40})
+	require.NoError(t, err)
+
+	require.Len(t, report.ResourceTypes, 1)
+	assert.True(t, report.ResourceTypes[0].Flagged)
+
+	table := string(ToTable(report))
+	assert.Contains(t, table, "1 resource type is off by more than 40%")
+}
+
+func TestReconcileNoMatches(t *testing.T) {
+	out := output.Root{
+		Projects: []output.Project{
+			{
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						lambdaResource("aws_lambda_function.not_deployed", "fn-d", 1, 0),
+					},
+				},
+			},
+		},
+	}
+
+	_, err := Reconcile(out, testExport(t), Options{})
+	assert.Equal(t, errNoMatches, err)
+}
+
+func TestResourceType(t *testing.T) {
+	assert.Equal(t, "aws_instance", resourceType("aws_instance.web"))
+	assert.Equal(t, "aws_instance", resourceType("module.app.aws_instance.web[0]"))
+	assert.Equal(t, "aws_instance", resourceType(`module.app["a.b"].aws_instance.web["c.d"]`))
+	assert.Equal(t, "", resourceType("web"))
+}
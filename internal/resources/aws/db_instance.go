@@ -23,9 +23,12 @@ type DbInstance struct {
 	Region                    *string
 	MonthlyStandardIoRequests *int64   `infracost_usage:"monthly_standard_io_requests"`
 	AdditionalBackupStorageGb *float64 `infracost_usage:"additional_backup_storage_gb"`
+
+	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
 }
 
-var DbInstanceUsageSchema = []*schema.UsageItem{{Key: "monthly_standard_io_requests", ValueType: schema.Int64, DefaultValue: 0}, {Key: "additional_backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0}}
+var DbInstanceUsageSchema = []*schema.UsageItem{{Key: "monthly_standard_io_requests", ValueType: schema.Int64, DefaultValue: 0}, {Key: "additional_backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0}, {Key: "reserved_instance_term", ValueType: schema.String, DefaultValue: ""}, {Key: "reserved_instance_payment_option", ValueType: schema.String, DefaultValue: ""}}
 
 func (r *DbInstance) PopulateUsage(u *schema.UsageData) {
 	resources.PopulateArgsWithUsage(r, u)
@@ -170,6 +173,14 @@ func (r *DbInstance) BuildResource() *schema.Resource {
 		},
 	}
 
+	// Replace the on-demand instance usage with the reserved instance fees
+	reserved := newReservedUsage(*r.Address, r.ReservedInstanceTerm, r.ReservedInstancePaymentOption, reservedPaymentOptions)
+	if reserved != nil {
+		labels := fmt.Sprintf("%s, %s", deploymentOption, instanceType)
+		one := decimal.NewFromInt(1)
+		costComponents = append(reserved.costComponents(costComponents[0], "Database instance", labels, one, "Hrs", one, "instances"), costComponents[1:]...)
+	}
+
 	if strings.ToLower(volumeType) == "magnetic" {
 		costComponents = append(costComponents, &schema.CostComponent{
 			Name:            "I/O requests",
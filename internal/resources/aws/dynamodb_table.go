@@ -30,6 +30,10 @@ type DynamoDBTable struct {
 	OnDemandBackupStorageGB        *int64 `infracost_usage:"on_demand_backup_storage_gb"`
 	MonthlyDataRestoredGB          *int64 `infracost_usage:"monthly_data_restored_gb"`
 	MonthlyStreamsReadRequestUnits *int64 `infracost_usage:"monthly_streams_read_request_units"`
+
+	ReservedCapacityTerm       *string `infracost_usage:"reserved_capacity_term"`
+	ReservedWriteCapacityUnits *int64  `infracost_usage:"reserved_write_capacity_units"`
+	ReservedReadCapacityUnits  *int64  `infracost_usage:"reserved_read_capacity_units"`
 }
 
 var DynamoDBTableUsageSchema = []*schema.UsageItem{
@@ -40,6 +44,9 @@ var DynamoDBTableUsageSchema = []*schema.UsageItem{
 	{Key: "on_demand_backup_storage_gb", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "monthly_data_restored_gb", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "monthly_streams_read_request_units", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "reserved_capacity_term", DefaultValue: "", ValueType: schema.String},
+	{Key: "reserved_write_capacity_units", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "reserved_read_capacity_units", DefaultValue: 0, ValueType: schema.Int64},
 }
 
 func (a *DynamoDBTable) PopulateUsage(u *schema.UsageData) {
@@ -52,10 +59,13 @@ func (a *DynamoDBTable) BuildResource() *schema.Resource {
 	subResources := make([]*schema.Resource, 0)
 
 	if a.BillingMode == "PROVISIONED" {
+		// Reserved capacity is only offered with the heavy utilization payment option
+		reserved := newReservedUsage(a.Address, a.ReservedCapacityTerm, strPtr("heavy_utilization"), []string{"heavy_utilization"})
+
 		// Write capacity units (WCU)
-		costComponents = append(costComponents, a.wcuCostComponent(a.Region, a.WriteCapacity))
+		costComponents = append(costComponents, a.reservedCapacityCostComponents(reserved, a.wcuCostComponent(a.Region, a.WriteCapacity), "Write capacity unit", "WCU", a.ReservedWriteCapacityUnits, "WriteCapacityUnit-Hrs")...)
 		// Read capacity units (RCU)
-		costComponents = append(costComponents, a.rcuCostComponent(a.Region, a.ReadCapacity))
+		costComponents = append(costComponents, a.reservedCapacityCostComponents(reserved, a.rcuCostComponent(a.Region, a.ReadCapacity), "Read capacity unit", "RCU", a.ReservedReadCapacityUnits, "ReadCapacityUnit-Hrs")...)
 	}
 
 	// Infracost usage data
@@ -165,6 +175,21 @@ func (a *DynamoDBTable) rcuCostComponent(region string, provisionedRCU *int64) *
 	}
 }
 
+// reservedCapacityCostComponents returns the onDemand provisioned capacity
+// cost component with the reserved capacity cost components. The reserved
+// units are taken off the on-demand units, and can't be more than the
+// provisioned units.
+func (a *DynamoDBTable) reservedCapacityCostComponents(reserved *reservedUsage, onDemand *schema.CostComponent, name string, unit string, reservedUnits *int64, hourlyPriceUnit string) []*schema.CostComponent {
+	if reserved == nil || reservedUnits == nil || *reservedUnits <= 0 || onDemand.HourlyQuantity == nil {
+		return []*schema.CostComponent{onDemand}
+	}
+
+	units := decimal.Min(decimal.NewFromInt(*reservedUnits), *onDemand.HourlyQuantity)
+	onDemand.HourlyQuantity = decimalPtr(onDemand.HourlyQuantity.Sub(units))
+
+	return append([]*schema.CostComponent{onDemand}, reserved.costComponents(onDemand, name, unit, units, hourlyPriceUnit, units, unit)...)
+}
+
 func (a *DynamoDBTable) globalTables(billingMode string, replicaRegions []string, writeCapacity *int64, monthlyWRU *int64) []*schema.Resource {
 	resources := make([]*schema.Resource, 0)
 
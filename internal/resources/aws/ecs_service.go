@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+type ECSService struct {
+	// "required" args that can't really be missing.
+	Address      string
+	Region       string
+	LaunchType   string
+	DesiredCount int64
+
+	// "optional" args, that may be empty depending on the resource config.
+	// These come from the task definition of the service.
+	Memory                         string
+	CPU                            string
+	InferenceAcceleratorDeviceType string
+}
+
+var ECSServiceUsageSchema = []*schema.UsageItem{}
+
+func (a *ECSService) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *ECSService) BuildResource() *schema.Resource {
+	if a.LaunchType != "FARGATE" {
+		return &schema.Resource{
+			Name:        a.Address,
+			IsSkipped:   true,
+			NoPrice:     true,
+			UsageSchema: ECSServiceUsageSchema,
+		}
+	}
+
+	desiredCount := decimal.NewFromInt(a.DesiredCount)
+	memory := convertResourceString(a.Memory)
+	cpu := convertResourceString(a.CPU)
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           "Per GB per hour",
+			Unit:           "GB",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: decimalPtr(desiredCount.Mul(memory)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonECS"),
+				ProductFamily: strPtr("Compute"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/Fargate-GB-Hours/")},
+				},
+			},
+		},
+		{
+			Name:           "Per vCPU per hour",
+			Unit:           "CPU",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: decimalPtr(desiredCount.Mul(cpu)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonECS"),
+				ProductFamily: strPtr("Compute"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/Fargate-vCPU-Hours:perCPU/")},
+				},
+			},
+		},
+	}
+
+	if a.InferenceAcceleratorDeviceType != "" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           fmt.Sprintf("Inference accelerator (%s)", a.InferenceAcceleratorDeviceType),
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(desiredCount),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonEI"),
+				ProductFamily: strPtr("Elastic Inference"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/i", a.InferenceAcceleratorDeviceType))},
+				},
+			},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           a.Address,
+		CostComponents: costComponents,
+		UsageSchema:    ECSServiceUsageSchema,
+	}
+}
+
+// convertResourceString converts the CPU or memory of a task definition to
+// vCPUs or GB. The values can be given with units, e.g. "1 vCPU" or "2 GB",
+// or as CPU units or MiB.
+func convertResourceString(rawValue string) decimal.Decimal {
+	var quantity decimal.Decimal
+	noSpaceString := strings.ReplaceAll(rawValue, " ", "")
+	reg := regexp.MustCompile(`(?i)vcpu|gb`)
+	if reg.MatchString(noSpaceString) {
+		quantity, _ = decimal.NewFromString(reg.ReplaceAllString(noSpaceString, ""))
+	} else {
+		quantity, _ = decimal.NewFromString(noSpaceString)
+		quantity = quantity.Div(decimal.NewFromInt(1024))
+	}
+	return quantity
+}
@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+// ElastiCache is used for both ElastiCache clusters and replication groups,
+// which are priced by the number of cache nodes.
+type ElastiCache struct {
+	// "required" args that can't really be missing.
+	Address    string
+	Region     string
+	NodeType   string
+	Engine     string
+	CacheNodes int64
+
+	// "optional" args, that may be empty depending on the resource config
+	SnapshotRetentionLimit int64
+
+	// "usage" args
+	SnapshotStorageSizeGB     *float64 `infracost_usage:"snapshot_storage_size_gb"`
+	ReservedNodeTerm          *string  `infracost_usage:"reserved_node_term"`
+	ReservedNodePaymentOption *string  `infracost_usage:"reserved_node_payment_option"`
+}
+
+var ElastiCacheUsageSchema = []*schema.UsageItem{
+	{Key: "snapshot_storage_size_gb", DefaultValue: 0.0, ValueType: schema.Float64},
+	{Key: "reserved_node_term", DefaultValue: "", ValueType: schema.String},
+	{Key: "reserved_node_payment_option", DefaultValue: "", ValueType: schema.String},
+}
+
+func (a *ElastiCache) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *ElastiCache) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Elasticache (on-demand, %s)", a.NodeType),
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(a.CacheNodes)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonElastiCache"),
+				ProductFamily: strPtr("Cache Instance"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr(a.NodeType)},
+					{Key: "locationType", Value: strPtr("AWS Region")},
+					{Key: "cacheEngine", Value: strPtr(strings.Title(a.Engine))},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("on_demand"),
+			},
+		},
+	}
+
+	// Replace the on-demand node usage with the reserved node fees
+	reserved := newReservedUsage(a.Address, a.ReservedNodeTerm, a.ReservedNodePaymentOption, reservedPaymentOptions)
+	if reserved != nil {
+		nodes := decimal.NewFromInt(a.CacheNodes)
+		costComponents = reserved.costComponents(costComponents[0], "Elasticache", a.NodeType, nodes, "Hrs", nodes, "nodes")
+	}
+
+	if strings.ToLower(a.Engine) == "redis" && a.SnapshotRetentionLimit > 1 {
+		backupRetention := decimal.NewFromInt(a.SnapshotRetentionLimit - 1)
+
+		var monthlyBackupStorageTotal *decimal.Decimal
+		if a.SnapshotStorageSizeGB != nil {
+			monthlyBackupStorageTotal = decimalPtr(decimal.NewFromFloat(*a.SnapshotStorageSizeGB).Mul(backupRetention))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Backup storage",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: monthlyBackupStorageTotal,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonElastiCache"),
+				ProductFamily: strPtr("Storage Snapshot"),
+			},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           a.Address,
+		CostComponents: costComponents,
+		UsageSchema:    ElastiCacheUsageSchema,
+	}
+}
@@ -0,0 +1,179 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+type ElasticsearchDomain struct {
+	// "required" args that can't really be missing.
+	Address       string
+	Region        string
+	InstanceType  string
+	InstanceCount int64
+
+	// "optional" args, that may be empty depending on the resource config
+	EBSEnabled           bool
+	EBSVolumeType        string
+	EBSVolumeSize        *float64
+	EBSIOPS              *float64
+	DedicatedMasterType  string
+	DedicatedMasterCount int64
+	UltrawarmType        string
+	UltrawarmCount       int64
+
+	// "usage" args
+	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
+}
+
+var ElasticsearchDomainUsageSchema = []*schema.UsageItem{
+	{Key: "reserved_instance_term", DefaultValue: "", ValueType: schema.String},
+	{Key: "reserved_instance_payment_option", DefaultValue: "", ValueType: schema.String},
+}
+
+func (a *ElasticsearchDomain) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *ElasticsearchDomain) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		a.instanceCostComponent("Instance", a.InstanceType, a.InstanceCount),
+	}
+
+	// Replace the on-demand instance usage with the reserved instance fees
+	reserved := newReservedUsage(a.Address, a.ReservedInstanceTerm, a.ReservedInstancePaymentOption, reservedPaymentOptions)
+	if reserved != nil {
+		instances := decimal.NewFromInt(a.InstanceCount)
+		costComponents = reserved.costComponents(costComponents[0], "Instance", a.InstanceType, instances, "Hrs", instances, "instances")
+	}
+
+	if a.EBSEnabled {
+		costComponents = append(costComponents, a.storageCostComponents()...)
+	}
+
+	if a.DedicatedMasterType != "" {
+		costComponents = append(costComponents, a.instanceCostComponent("Dedicated master", a.DedicatedMasterType, a.DedicatedMasterCount))
+	}
+
+	if a.UltrawarmType != "" && a.UltrawarmCount > 0 {
+		costComponents = append(costComponents, a.instanceCostComponent("UltraWarm instance", a.UltrawarmType, a.UltrawarmCount))
+	}
+
+	return &schema.Resource{
+		Name:           a.Address,
+		CostComponents: costComponents,
+		UsageSchema:    ElasticsearchDomainUsageSchema,
+	}
+}
+
+func (a *ElasticsearchDomain) instanceCostComponent(name string, instanceType string, count int64) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:           fmt.Sprintf("%s (on-demand, %s)", name, instanceType),
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(count)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(a.Region),
+			Service:       strPtr("AmazonES"),
+			ProductFamily: strPtr("Amazon OpenSearch Service Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/ESInstance/")},
+				{Key: "instanceType", Value: opensearchifyInstanceType(instanceType)},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		},
+	}
+}
+
+func (a *ElasticsearchDomain) storageCostComponents() []*schema.CostComponent {
+	gbVal := decimal.NewFromInt(defaultVolumeSize)
+	if a.EBSVolumeSize != nil {
+		gbVal = decimal.NewFromFloat(*a.EBSVolumeSize)
+	}
+
+	ebsType := "gp2"
+	if a.EBSVolumeType != "" {
+		ebsType = a.EBSVolumeType
+	}
+
+	ebsTypeMap := map[string]string{
+		"gp2":      "GP2",
+		"io1":      "PIOPS-Storage",
+		"standard": "Magnetic",
+	}
+
+	ebsFilter := "gp2"
+	if val, ok := ebsTypeMap[ebsType]; ok {
+		ebsFilter = val
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            fmt.Sprintf("Storage (%s)", ebsType),
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &gbVal,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonES"),
+				ProductFamily: strPtr("Amazon OpenSearch Service Volume"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/ES.+-Storage/")},
+					{Key: "storageMedia", Value: strPtr(ebsFilter)},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("on_demand"),
+			},
+		},
+	}
+
+	if strings.ToLower(ebsType) == "io1" {
+		iopsVal := decimal.NewFromInt(1)
+		if a.EBSIOPS != nil {
+			iopsVal = decimal.NewFromFloat(*a.EBSIOPS)
+
+			if iopsVal.LessThan(decimal.NewFromInt(1)) {
+				iopsVal = decimal.NewFromInt(1)
+			}
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            fmt.Sprintf("Storage IOPS (%s)", ebsType),
+			Unit:            "IOPS",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &iopsVal,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonES"),
+				ProductFamily: strPtr("Amazon OpenSearch Service Volume"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/ES:PIOPS/")},
+					{Key: "storageMedia", Value: strPtr("PIOPS")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("on_demand"),
+			},
+		})
+	}
+
+	return costComponents
+}
+
+// AWS renamed Elasticsearch Service to OpenSearch Service and changed the 'instancetype' field in prices
+// "m4.large.elasticsearch" to "m4.large.search"
+func opensearchifyInstanceType(instanceType string) *string {
+	s := strings.Replace(instanceType, ".elasticsearch", ".search", 1)
+	return &s
+}
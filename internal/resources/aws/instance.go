@@ -15,6 +15,10 @@ import (
 var defaultEC2InstanceMetricCount = 7
 var burstableInstanceTypePrefixes = []string{"t2.", "t3.", "t4."}
 
+// defaultSpotDiscountPercent is the discount from the on-demand price that is
+// used for spot instances when the pricing source has no spot price.
+var defaultSpotDiscountPercent = 70.0
+
 type Instance struct {
 	// "required" args that can't really be missing.
 	Address          string
@@ -33,12 +37,13 @@ type Instance struct {
 	EBSBlockDevices                 []*EBSVolume
 
 	// "usage" args
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	SpotDiscountPercent           *float64 `infracost_usage:"spot_discount_percent"`
 }
 
 var InstanceUsageSchema = []*schema.UsageItem{
@@ -48,6 +53,7 @@ var InstanceUsageSchema = []*schema.UsageItem{
 	{Key: "reserved_instance_payment_option", DefaultValue: "", ValueType: schema.String},
 	{Key: "monthly_cpu_credit_hrs", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "vcpu_count", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "spot_discount_percent", DefaultValue: defaultSpotDiscountPercent, ValueType: schema.Float64},
 }
 
 func (a *Instance) PopulateUsage(u *schema.UsageData) {
@@ -163,7 +169,7 @@ func (a *Instance) computeCostComponent() *schema.CostComponent {
 		}
 	}
 
-	return &schema.CostComponent{
+	c := &schema.CostComponent{
 		Name:           fmt.Sprintf("Instance usage (%s, %s, %s)", osLabel, purchaseOptionLabel, a.InstanceType),
 		Unit:           "hours",
 		UnitMultiplier: decimal.NewFromInt(1),
@@ -186,6 +192,33 @@ func (a *Instance) computeCostComponent() *schema.CostComponent {
 			PurchaseOption: strPtr(a.PurchaseOption),
 		},
 	}
+
+	// Spot prices aren't available for every instance type and region, so
+	// fallback to the on-demand price with the spot discount applied.
+	if a.PurchaseOption == "spot" {
+		c.FallbackPriceFilter = &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		}
+		c.FallbackPriceMultiplier = a.spotPriceMultiplier()
+	}
+
+	return c
+}
+
+// spotPriceMultiplier returns the multiplier for the on-demand price to get
+// the estimated spot price.
+func (a *Instance) spotPriceMultiplier() decimal.Decimal {
+	discount := defaultSpotDiscountPercent
+	if a.SpotDiscountPercent != nil {
+		discount = *a.SpotDiscountPercent
+	}
+
+	if discount < 0 || discount > 100 {
+		log.Warnf("Invalid spot_discount_percent %v for %s, expected a value between 0 and 100. Using %v", discount, a.Address, defaultSpotDiscountPercent)
+		discount = defaultSpotDiscountPercent
+	}
+
+	return decimal.NewFromInt(100).Sub(decimal.NewFromFloat(discount)).Div(decimal.NewFromInt(100))
 }
 
 func (a *Instance) validateReserveInstanceParams() (bool, string) {
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceSpotFallbackPrice(t *testing.T) {
+	tests := []struct {
+		name         string
+		discount     *float64
+		expectedMult string
+	}{
+		{name: "default discount", expectedMult: "0.3"},
+		{name: "custom discount", discount: floatPtr(55), expectedMult: "0.45"},
+		{name: "invalid discount", discount: floatPtr(120), expectedMult: "0.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Instance{
+				Address:             "aws_spot_instance_request.web",
+				Region:              "us-east-1",
+				Tenancy:             "Shared",
+				PurchaseOption:      "spot",
+				InstanceType:        "m5.large",
+				OperatingSystem:     strPtr("linux"),
+				SpotDiscountPercent: tt.discount,
+			}
+
+			c := a.computeCostComponent()
+			assert.Equal(t, "Instance usage (Linux/UNIX, spot, m5.large)", c.Name)
+			assert.Equal(t, "spot", *c.PriceFilter.PurchaseOption)
+			require.NotNil(t, c.FallbackPriceFilter)
+			assert.Equal(t, "on_demand", *c.FallbackPriceFilter.PurchaseOption)
+			assert.True(t, decimal.RequireFromString(tt.expectedMult).Equal(c.FallbackPriceMultiplier))
+		})
+	}
+
+	onDemand := &Instance{Region: "us-east-1", Tenancy: "Shared", PurchaseOption: "on_demand", InstanceType: "m5.large", OperatingSystem: strPtr("linux")}
+	assert.Nil(t, onDemand.computeCostComponent().FallbackPriceFilter)
+}
+
+func TestLaunchTemplateSpotInstanceCounts(t *testing.T) {
+	a := &LaunchTemplate{
+		Address:                          "aws_launch_template.lt",
+		Region:                           "us-east-1",
+		InstanceType:                     "m5.large",
+		InstanceCount:                    intPtr(10),
+		OnDemandBaseCount:                2,
+		OnDemandPercentageAboveBaseCount: 25,
+	}
+
+	r := a.BuildResource()
+	require.NotNil(t, r)
+	require.GreaterOrEqual(t, len(r.CostComponents), 2)
+
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, m5.large)", r.CostComponents[0].Name)
+	assert.True(t, decimal.NewFromInt(4).Equal(*r.CostComponents[0].HourlyQuantity))
+	assert.Nil(t, r.CostComponents[0].FallbackPriceFilter)
+
+	assert.Equal(t, "Instance usage (Linux/UNIX, spot, m5.large)", r.CostComponents[1].Name)
+	assert.True(t, decimal.NewFromInt(6).Equal(*r.CostComponents[1].HourlyQuantity))
+	assert.NotNil(t, r.CostComponents[1].FallbackPriceFilter)
+}
@@ -28,13 +28,14 @@ type LaunchConfiguration struct {
 
 	// "usage" args
 	// These are populated from the Autoscaling Group resource
-	InstanceCount                 *int64  `infracost_usage:"instances"`
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	InstanceCount                 *int64   `infracost_usage:"instances"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	SpotDiscountPercent           *float64 `infracost_usage:"spot_discount_percent"`
 }
 
 var LaunchConfigurationUsageSchema = InstanceUsageSchema
@@ -71,6 +72,7 @@ func (a *LaunchConfiguration) BuildResource() *schema.Resource {
 		ReservedInstancePaymentOption:   a.ReservedInstancePaymentOption,
 		MonthlyCPUCreditHours:           a.MonthlyCPUCreditHours,
 		VCPUCount:                       a.VCPUCount,
+		SpotDiscountPercent:             a.SpotDiscountPercent,
 	}
 	instanceResource := instance.BuildResource()
 
@@ -17,9 +17,12 @@ type RdsClusterInstance struct {
 	Engine              *string
 	MonthlyCPUCreditHrs *int64 `infracost_usage:"monthly_cpu_credit_hrs"`
 	VcpuCount           *int64 `infracost_usage:"vcpu_count"`
+
+	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
 }
 
-var RdsClusterInstanceUsageSchema = []*schema.UsageItem{{Key: "monthly_cpu_credit_hrs", ValueType: schema.Int64, DefaultValue: 0}, {Key: "vcpu_count", ValueType: schema.Int64, DefaultValue: 0}}
+var RdsClusterInstanceUsageSchema = []*schema.UsageItem{{Key: "monthly_cpu_credit_hrs", ValueType: schema.Int64, DefaultValue: 0}, {Key: "vcpu_count", ValueType: schema.Int64, DefaultValue: 0}, {Key: "reserved_instance_term", ValueType: schema.String, DefaultValue: ""}, {Key: "reserved_instance_payment_option", ValueType: schema.String, DefaultValue: ""}}
 
 func (r *RdsClusterInstance) PopulateUsage(u *schema.UsageData) {
 	resources.PopulateArgsWithUsage(r, u)
@@ -60,6 +63,13 @@ func (r *RdsClusterInstance) BuildResource() *schema.Resource {
 		},
 	}
 
+	// Replace the on-demand instance usage with the reserved instance fees
+	reserved := newReservedUsage(*r.Address, r.ReservedInstanceTerm, r.ReservedInstancePaymentOption, reservedPaymentOptions)
+	if reserved != nil {
+		one := decimal.NewFromInt(1)
+		costComponents = reserved.costComponents(costComponents[0], "Database instance", instanceType, one, "Hrs", one, "instances")
+	}
+
 	if strings.HasPrefix(instanceType, "db.t3") {
 		instanceCPUCreditHours := decimal.Zero
 		if r.MonthlyCPUCreditHrs != nil {
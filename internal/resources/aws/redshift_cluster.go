@@ -21,9 +21,12 @@ type RedshiftCluster struct {
 	ExcessConcurrencyScalingSecs *int64   `infracost_usage:"excess_concurrency_scaling_secs"`
 	SpectrumDataScannedTb        *float64 `infracost_usage:"spectrum_data_scanned_tb"`
 	BackupStorageGb              *float64 `infracost_usage:"backup_storage_gb"`
+
+	ReservedNodeTerm          *string `infracost_usage:"reserved_node_term"`
+	ReservedNodePaymentOption *string `infracost_usage:"reserved_node_payment_option"`
 }
 
-var RedshiftClusterUsageSchema = []*schema.UsageItem{{Key: "managed_storage_gb", ValueType: schema.Float64, DefaultValue: 0}, {Key: "excess_concurrency_scaling_secs", ValueType: schema.Int64, DefaultValue: 0}, {Key: "spectrum_data_scanned_tb", ValueType: schema.Float64, DefaultValue: 0.000000}, {Key: "backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0}}
+var RedshiftClusterUsageSchema = []*schema.UsageItem{{Key: "managed_storage_gb", ValueType: schema.Float64, DefaultValue: 0}, {Key: "excess_concurrency_scaling_secs", ValueType: schema.Int64, DefaultValue: 0}, {Key: "spectrum_data_scanned_tb", ValueType: schema.Float64, DefaultValue: 0.000000}, {Key: "backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0}, {Key: "reserved_node_term", ValueType: schema.String, DefaultValue: ""}, {Key: "reserved_node_payment_option", ValueType: schema.String, DefaultValue: ""}}
 
 func (r *RedshiftCluster) PopulateUsage(u *schema.UsageData) {
 	resources.PopulateArgsWithUsage(r, u)
@@ -59,6 +62,13 @@ func (r *RedshiftCluster) BuildResource() *schema.Resource {
 		},
 	}
 
+	// Replace the on-demand node usage with the reserved node fees
+	reserved := newReservedUsage(*r.Address, r.ReservedNodeTerm, r.ReservedNodePaymentOption, reservedPaymentOptions)
+	if reserved != nil {
+		nodes := decimal.NewFromInt(numberOfNodes)
+		costComponents = reserved.costComponents(costComponents[0], "Cluster usage", nodeType, nodes, "Hrs", nodes, "nodes")
+	}
+
 	if strings.HasPrefix(nodeType, "ra3") {
 		var managedStorage *decimal.Decimal
 		if r.ManagedStorageGb != nil {
@@ -1,18 +1,27 @@
 package aws
 
-// type remediater struct {
-// 	description string
-// 	remediate   func() error
-// }
+import "fmt"
 
-// func (r remediater) Describe() string {
-// 	return r.description
-// }
+// remediater is returned as an error from an estimate function when the
+// usage can't be estimated until a change is made in the cloud.
+type remediater struct {
+	description string
+	apiCalls    []string
+	remediate   func() error
+}
 
-// func (r remediater) Error() string {
-// 	return fmt.Sprintf("Must %s to estimate usage", r.Describe())
-// }
+func (r remediater) Describe() string {
+	return r.description
+}
 
-// func (r remediater) Remediate() error {
-// 	return r.remediate()
-// }
+func (r remediater) APICalls() []string {
+	return r.apiCalls
+}
+
+func (r remediater) Error() string {
+	return fmt.Sprintf("Must %s to estimate usage", r.Describe())
+}
+
+func (r remediater) Remediate() error {
+	return r.remediate()
+}
@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+var reservedTermNames = map[string]string{
+	"1_year": "1yr",
+	"3_year": "3yr",
+}
+
+var reservedPaymentOptionNames = map[string]string{
+	"no_upfront":        "No Upfront",
+	"partial_upfront":   "Partial Upfront",
+	"all_upfront":       "All Upfront",
+	"heavy_utilization": "Heavy Utilization",
+}
+
+var reservedPaymentOptions = []string{"no_upfront", "partial_upfront", "all_upfront"}
+
+// reservedUsage is the term and payment option of a reservation that is set in
+// the usage file, e.g. for reserved DB instances or cache nodes.
+type reservedUsage struct {
+	Term          string
+	PaymentOption string
+}
+
+// newReservedUsage returns the reservation for the term and payment option,
+// or nil if the term isn't set or the options are invalid, in which case the
+// on-demand prices should be used.
+func newReservedUsage(address string, term *string, paymentOption *string, validPaymentOptions []string) *reservedUsage {
+	if strVal(term) == "" {
+		return nil
+	}
+
+	validTerms := []string{"1_year", "3_year"}
+	if !stringInSlice(validTerms, strVal(term)) {
+		log.Warnf("Invalid reserved term for %s, ignoring reserved options. Expected: %s. Got: %s", address, strings.Join(validTerms, ", "), strVal(term))
+		return nil
+	}
+
+	if !stringInSlice(validPaymentOptions, strVal(paymentOption)) {
+		log.Warnf("Invalid reserved payment option for %s, ignoring reserved options. Expected: %s. Got: %s", address, strings.Join(validPaymentOptions, ", "), strVal(paymentOption))
+		return nil
+	}
+
+	return &reservedUsage{
+		Term:          *term,
+		PaymentOption: *paymentOption,
+	}
+}
+
+// label returns the label for the reservation's cost components, e.g.
+// "reserved, 1yr, partial upfront".
+func (r *reservedUsage) label() string {
+	return fmt.Sprintf("reserved, %s, %s", reservedTermNames[r.Term], strings.ToLower(reservedPaymentOptionNames[r.PaymentOption]))
+}
+
+// costComponents returns the cost components for the reservation that replace
+// the onDemand cost component. The hourly fee is priced by hourlyPriceUnit and
+// isn't added for all upfront reservations. The upfront fee is reported as an
+// upfront cost so it isn't included in the monthly cost, and isn't added for
+// no upfront reservations.
+func (r *reservedUsage) costComponents(onDemand *schema.CostComponent, name string, labels string, hourlyQuantity decimal.Decimal, hourlyPriceUnit string, upfrontQuantity decimal.Decimal, upfrontUnit string) []*schema.CostComponent {
+	costComponents := make([]*schema.CostComponent, 0, 2)
+
+	termLength := reservedTermNames[r.Term]
+	termPurchaseOption := reservedPaymentOptionNames[r.PaymentOption]
+
+	if r.PaymentOption != "all_upfront" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           fmt.Sprintf("%s (%s, %s)", name, r.label(), labels),
+			Unit:           onDemand.Unit,
+			UnitMultiplier: onDemand.UnitMultiplier,
+			HourlyQuantity: decimalPtr(hourlyQuantity),
+			ProductFilter:  onDemand.ProductFilter,
+			PriceFilter: &schema.PriceFilter{
+				Unit:               strPtr(hourlyPriceUnit),
+				TermLength:         strPtr(termLength),
+				TermPurchaseOption: strPtr(termPurchaseOption),
+			},
+		})
+	}
+
+	if r.PaymentOption != "no_upfront" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            fmt.Sprintf("%s upfront fee (%s, %s)", name, r.label(), labels),
+			Unit:            upfrontUnit,
+			UnitMultiplier:  decimal.NewFromInt(1),
+			UpfrontQuantity: decimalPtr(upfrontQuantity),
+			ProductFilter:   onDemand.ProductFilter,
+			PriceFilter: &schema.PriceFilter{
+				Unit:               strPtr("Quantity"),
+				TermLength:         strPtr(termLength),
+				TermPurchaseOption: strPtr(termPurchaseOption),
+			},
+		})
+	}
+
+	return costComponents
+}
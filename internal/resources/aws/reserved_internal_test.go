@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestNewReservedUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		term          *string
+		paymentOption *string
+		expected      *reservedUsage
+	}{
+		{"not set", nil, nil, nil},
+		{"empty term", strPtr(""), strPtr("no_upfront"), nil},
+		{"invalid term", strPtr("2_year"), strPtr("no_upfront"), nil},
+		{"invalid payment option", strPtr("1_year"), strPtr("heavy_utilization"), nil},
+		{"valid", strPtr("3_year"), strPtr("partial_upfront"), &reservedUsage{Term: "3_year", PaymentOption: "partial_upfront"}},
+	}
+
+	for _, test := range tests {
+		actual := newReservedUsage("aws_db_instance.db", test.term, test.paymentOption, reservedPaymentOptions)
+		assert.Equal(t, test.expected, actual, test.name)
+	}
+}
+
+func TestReservedUsageCostComponents(t *testing.T) {
+	t.Parallel()
+
+	onDemand := &schema.CostComponent{
+		Name:           "Database instance (on-demand, db.t3.large)",
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(2)),
+		ProductFilter:  &schema.ProductFilter{Service: strPtr("AmazonRDS")},
+		PriceFilter:    &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+	}
+	two := decimal.NewFromInt(2)
+
+	tests := []struct {
+		paymentOption string
+		expected      []string
+	}{
+		{"no_upfront", []string{"Database instance (reserved, 1yr, no upfront, db.t3.large)"}},
+		{"partial_upfront", []string{
+			"Database instance (reserved, 1yr, partial upfront, db.t3.large)",
+			"Database instance upfront fee (reserved, 1yr, partial upfront, db.t3.large)",
+		}},
+		{"all_upfront", []string{"Database instance upfront fee (reserved, 1yr, all upfront, db.t3.large)"}},
+	}
+
+	for _, test := range tests {
+		r := &reservedUsage{Term: "1_year", PaymentOption: test.paymentOption}
+		costComponents := r.costComponents(onDemand, "Database instance", "db.t3.large", two, "Hrs", two, "instances")
+
+		names := make([]string, 0, len(costComponents))
+		for _, c := range costComponents {
+			names = append(names, c.Name)
+			assert.Equal(t, "1yr", strVal(c.PriceFilter.TermLength))
+			assert.Equal(t, onDemand.ProductFilter, c.ProductFilter)
+		}
+		assert.Equal(t, test.expected, names, test.paymentOption)
+	}
+
+	r := &reservedUsage{Term: "3_year", PaymentOption: "partial_upfront"}
+	costComponents := r.costComponents(onDemand, "Database instance", "db.t3.large", two, "Hrs", two, "instances")
+	require.Len(t, costComponents, 2)
+
+	hourly := costComponents[0]
+	assert.Equal(t, "2", hourly.HourlyQuantity.String())
+	assert.Nil(t, hourly.UpfrontQuantity)
+	assert.Equal(t, "Hrs", strVal(hourly.PriceFilter.Unit))
+	assert.Equal(t, "Partial Upfront", strVal(hourly.PriceFilter.TermPurchaseOption))
+
+	upfront := costComponents[1]
+	assert.Nil(t, upfront.HourlyQuantity)
+	assert.Equal(t, "2", upfront.UpfrontQuantity.String())
+	assert.Equal(t, "Quantity", strVal(upfront.PriceFilter.Unit))
+	assert.Equal(t, "3yr", strVal(upfront.PriceFilter.TermLength))
+}
+
+func TestDynamoDBTableReservedCapacity(t *testing.T) {
+	t.Parallel()
+
+	wcu := int64(100)
+	reservedWCU := int64(60)
+	rcu := int64(50)
+	reservedRCU := int64(80)
+
+	table := &DynamoDBTable{
+		Address:                    "aws_dynamodb_table.table",
+		Region:                     "us-east-1",
+		BillingMode:                "PROVISIONED",
+		WriteCapacity:              &wcu,
+		ReadCapacity:               &rcu,
+		ReservedCapacityTerm:       strPtr("1_year"),
+		ReservedWriteCapacityUnits: &reservedWCU,
+		ReservedReadCapacityUnits:  &reservedRCU,
+	}
+
+	quantities := make(map[string]string)
+	for _, c := range table.BuildResource().CostComponents {
+		if c.HourlyQuantity != nil {
+			quantities[c.Name] = c.HourlyQuantity.String()
+		} else if c.UpfrontQuantity != nil {
+			quantities[c.Name] = c.UpfrontQuantity.String()
+		}
+	}
+
+	assert.Equal(t, "40", quantities["Write capacity unit (WCU)"])
+	assert.Equal(t, "60", quantities["Write capacity unit (reserved, 1yr, heavy utilization, WCU)"])
+	assert.Equal(t, "60", quantities["Write capacity unit upfront fee (reserved, 1yr, heavy utilization, WCU)"])
+
+	// The reserved units are capped at the provisioned units
+	assert.Equal(t, "0", quantities["Read capacity unit (RCU)"])
+	assert.Equal(t, "50", quantities["Read capacity unit (reserved, 1yr, heavy utilization, RCU)"])
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
@@ -152,36 +153,47 @@ func (a *S3Bucket) BuildResource() *schema.Resource {
 		}
 
 		if filter == "" {
+			// The storage usage has already been estimated, but the requests can't be
+			// estimated until request metrics are enabled for the bucket.
 			log.Debugf("Unable to find matching metrics filter for S3 bucket, so unable to sync additional metrics")
-		} else {
-			standardStorageClassUsage := u["standard"].(map[string]interface{})
-
-			monthlyTier1Requests, err := aws.S3GetBucketRequests(ctx, a.Region, a.Name, filter, []string{"PutRequests", "PostRequests", "ListRequests"})
-			if err != nil {
-				return err
+			return &remediater{
+				description: "enable bucket metrics",
+				apiCalls: []string{
+					fmt.Sprintf("s3:PutBucketMetricsConfiguration(region: %s, Bucket: %s, Id: %s)", a.Region, a.Name, aws.S3MetricsConfigurationID),
+				},
+				remediate: func() error {
+					return aws.S3EnableBucketMetrics(ctx, a.Region, a.Name)
+				},
 			}
+		}
 
-			monthlyTier2Requests, err := aws.S3GetBucketRequests(ctx, a.Region, a.Name, filter, []string{"GetRequests", "HeadRequests", "SelectRequests"})
-			if err != nil {
-				return err
-			}
+		standardStorageClassUsage := u["standard"].(map[string]interface{})
 
-			selectDataScannedBytes, err := aws.S3GetBucketDataBytes(ctx, a.Region, a.Name, filter, "SelectBytesScanned")
-			if err != nil {
-				return err
-			}
+		monthlyTier1Requests, err := aws.S3GetBucketRequests(ctx, a.Region, a.Name, filter, []string{"PutRequests", "PostRequests", "ListRequests"})
+		if err != nil {
+			return err
+		}
 
-			selectDataReturnedBytes, err := aws.S3GetBucketDataBytes(ctx, a.Region, a.Name, filter, "SelectBytesReturned")
-			if err != nil {
-				return err
-			}
+		monthlyTier2Requests, err := aws.S3GetBucketRequests(ctx, a.Region, a.Name, filter, []string{"GetRequests", "HeadRequests", "SelectRequests"})
+		if err != nil {
+			return err
+		}
 
-			standardStorageClassUsage["monthly_tier_1_requests"] = monthlyTier1Requests
-			standardStorageClassUsage["monthly_tier_2_requests"] = monthlyTier2Requests
-			standardStorageClassUsage["monthly_select_data_scanned_gb"] = selectDataScannedBytes / 1000 / 1000 / 1000
-			standardStorageClassUsage["monthly_select_data_returned_gb"] = selectDataReturnedBytes / 1000 / 1000 / 1000
+		selectDataScannedBytes, err := aws.S3GetBucketDataBytes(ctx, a.Region, a.Name, filter, "SelectBytesScanned")
+		if err != nil {
+			return err
 		}
 
+		selectDataReturnedBytes, err := aws.S3GetBucketDataBytes(ctx, a.Region, a.Name, filter, "SelectBytesReturned")
+		if err != nil {
+			return err
+		}
+
+		standardStorageClassUsage["monthly_tier_1_requests"] = monthlyTier1Requests
+		standardStorageClassUsage["monthly_tier_2_requests"] = monthlyTier2Requests
+		standardStorageClassUsage["monthly_select_data_scanned_gb"] = selectDataScannedBytes / 1000 / 1000 / 1000
+		standardStorageClassUsage["monthly_select_data_returned_gb"] = selectDataReturnedBytes / 1000 / 1000 / 1000
+
 		return nil
 	}
 
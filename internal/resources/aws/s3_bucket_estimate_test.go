@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func stubListBucketMetricsConfigurations(stub *stubbedAWS) {
@@ -184,14 +186,27 @@ func TestS3BucketNoFilter(t *testing.T) {
 	}
 
 	args := resources.S3Bucket{
-		Name: "test-bucket",
+		Name:   "test-bucket",
+		Region: "us-east-1",
 	}
 	resource := args.BuildResource()
-	estimates := newEstimates(stub.ctx, t, resource)
+
+	u := make(map[string]interface{})
+	err := resource.EstimateUsage(stub.ctx, u)
+
+	// The requests can't be estimated until bucket metrics are enabled, but the storage is still estimated
+	rem, ok := err.(schema.Remediater)
+	require.True(t, ok, "Expected a schema.Remediater error, got %v", err)
+	assert.Equal(t, "enable bucket metrics", rem.Describe())
+	assert.Equal(t, "Must enable bucket metrics to estimate usage", err.Error())
+	assert.Equal(t, []string{"s3:PutBucketMetricsConfiguration(region: us-east-1, Bucket: test-bucket, Id: infracost)"}, rem.APICalls())
 
 	assert.Equal(t, map[string]interface{}{
 		"storage_gb": 2.1,
-	}, estimates.usage["standard"])
+	}, u["standard"])
+
+	stub.WhenBody("<Id>infracost</Id>").Then(200, "")
+	assert.NoError(t, rem.Remediate())
 }
 
 func TestS3BucketNoStandard(t *testing.T) {
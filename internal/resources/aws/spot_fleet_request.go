@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type SpotFleetRequest struct {
+	// "required" args that can't really be missing.
+	Address string
+	Region  string
+
+	// "optional" args, that may be empty depending on the resource config
+	LaunchTemplate *LaunchTemplate
+}
+
+var SpotFleetRequestUsageSchema = append([]*schema.UsageItem{
+	{Key: "instances", DefaultValue: 0, ValueType: schema.Int64},
+}, InstanceUsageSchema...)
+
+func (a *SpotFleetRequest) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+
+	// The usage keys for the Launch Template are specified on the Spot Fleet Request resource
+	if a.LaunchTemplate != nil {
+		resources.PopulateArgsWithUsage(a.LaunchTemplate, u)
+	}
+}
+
+func (a *SpotFleetRequest) BuildResource() *schema.Resource {
+	r := &schema.Resource{
+		Name:        a.Address,
+		UsageSchema: SpotFleetRequestUsageSchema,
+	}
+
+	// The fleet's instances are either from a Launch Template or a launch specification, which we
+	// build as a Launch Template so the spot and on-demand instances are split the same way as an
+	// Autoscaling Group's mixed instances policy.
+	if a.LaunchTemplate != nil {
+		lt := a.LaunchTemplate.BuildResource()
+		// If the Launch Template returns nil it is not supported so the Spot Fleet Request should also return nil
+		if lt == nil {
+			return nil
+		}
+		r.SubResources = append(r.SubResources, lt)
+		r.EstimateUsage = lt.EstimateUsage
+	}
+
+	return r
+}
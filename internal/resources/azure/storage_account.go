@@ -1,12 +1,15 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 )
@@ -22,6 +25,9 @@ import (
 type StorageAccount struct {
 	Address string
 	Region  string
+	// ID is the Azure resource ID of the deployed storage account, used to
+	// estimate usage from Azure Monitor.
+	ID string
 
 	AccessTier             string
 	AccountKind            string
@@ -113,9 +119,38 @@ func (r *StorageAccount) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		UsageSchema:    StorageAccountUsageSchema,
 		CostComponents: costComponents,
+		EstimateUsage:  r.estimateUsage,
 	}
 }
 
+// estimateUsage estimates the storage and operations from the Azure Monitor
+// metrics of the account. File storage operations aren't split by the
+// metrics so only the data at rest is estimated for them.
+func (r *StorageAccount) estimateUsage(ctx context.Context, values map[string]interface{}) error {
+	bytes, err := azure.StorageAccountGetUsedCapacityBytes(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	gb := math.Ceil(bytes / 1024 / 1024 / 1024)
+
+	if r.isFileStorage() {
+		values["data_at_rest_storage_gb"] = gb
+		return nil
+	}
+	values["storage_gb"] = gb
+
+	ops, err := azure.StorageAccountGetOperations(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	values["monthly_read_operations"] = ops.Read
+	values["monthly_write_operations"] = ops.Write
+	values["monthly_list_and_create_container_operations"] = ops.ListAndCreateContainer
+	values["monthly_other_operations"] = ops.Other
+
+	return nil
+}
+
 // buildProductFilter returns a product filter for the Storage Account's products.
 func (r *StorageAccount) buildProductFilter(meterName string) *schema.ProductFilter {
 	var productName string
@@ -0,0 +1,321 @@
+// Package savingsplans models the coverage of the eligible AWS costs in a run
+// by the Savings Plans set in the config file. The hourly commitment of each
+// plan pays for the on-demand costs of EC2 instances, Fargate and Lambda at
+// the plan's discounted rates, and any commitment that isn't used is still
+// paid for.
+package savingsplans
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	serviceEC2     = "ec2"
+	serviceFargate = "fargate"
+	serviceLambda  = "lambda"
+)
+
+// defaultDiscountPercents are the approximate discounts from the on-demand
+// prices, keyed by the plan type, service, term and payment option. The actual
+// discounts vary by instance type and region, so they can be overridden for
+// each plan in the config file.
+var defaultDiscountPercents = map[string]map[string]map[string]map[string]float64{
+	config.SavingsPlanTypeCompute: {
+		serviceEC2: {
+			"1_year": {"no_upfront": 27, "partial_upfront": 31, "all_upfront": 33},
+			"3_year": {"no_upfront": 46, "partial_upfront": 51, "all_upfront": 54},
+		},
+		serviceFargate: {
+			"1_year": {"no_upfront": 20, "partial_upfront": 22, "all_upfront": 23},
+			"3_year": {"no_upfront": 45, "partial_upfront": 49, "all_upfront": 52},
+		},
+		serviceLambda: {
+			"1_year": {"no_upfront": 12, "partial_upfront": 13, "all_upfront": 14},
+			"3_year": {"no_upfront": 15, "partial_upfront": 16, "all_upfront": 17},
+		},
+	},
+	config.SavingsPlanTypeEC2Instance: {
+		serviceEC2: {
+			"1_year": {"no_upfront": 37, "partial_upfront": 40, "all_upfront": 42},
+			"3_year": {"no_upfront": 56, "partial_upfront": 59, "all_upfront": 62},
+		},
+	},
+}
+
+// eligibleCost is a cost component that can be covered by a Savings Plan.
+type eligibleCost struct {
+	projectIndex int
+	service      string
+	region       string
+	family       string
+	hourlyCost   decimal.Decimal
+	// uncoveredHourlyCost is the on-demand cost that isn't covered by the
+	// plans that have been applied so far.
+	uncoveredHourlyCost decimal.Decimal
+}
+
+type projectCoverage struct {
+	eligible    decimal.Decimal
+	covered     decimal.Decimal
+	savingsPlan decimal.Decimal
+}
+
+// Apply calculates the coverage of the eligible costs in the projects by the
+// plans and sets it on the output. The projects must be in the same order as
+// the output projects. EC2 Instance Savings Plans are applied before Compute
+// Savings Plans, as AWS does, and each plan is applied to the costs with the
+// highest discount first.
+func Apply(plans []*config.SavingsPlan, projects []*schema.Project, out *output.Root) {
+	sorted := make([]*config.SavingsPlan, 0, len(plans))
+	for _, plan := range plans {
+		if plan != nil {
+			sorted = append(sorted, plan)
+		}
+	}
+
+	if len(sorted) == 0 {
+		return
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Type == config.SavingsPlanTypeEC2Instance && sorted[j].Type != config.SavingsPlanTypeEC2Instance
+	})
+
+	costs := eligibleCosts(projects)
+
+	coverage := make([]projectCoverage, len(projects))
+	for _, c := range costs {
+		coverage[c.projectIndex].eligible = coverage[c.projectIndex].eligible.Add(c.hourlyCost)
+	}
+
+	outPlans := make([]output.SavingsPlan, 0, len(sorted))
+	totalCovered := decimal.Zero
+	totalCommitment := decimal.Zero
+	totalUnused := decimal.Zero
+
+	for _, plan := range sorted {
+		commitment := decimal.NewFromFloat(plan.HourlyCommitment)
+		remaining := commitment
+		covered := decimal.Zero
+
+		candidates := make([]*eligibleCost, 0, len(costs))
+		for _, c := range costs {
+			if planCovers(plan, c) {
+				candidates = append(candidates, c)
+			}
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return discountPercent(plan, candidates[i].service) > discountPercent(plan, candidates[j].service)
+		})
+
+		for _, c := range candidates {
+			if !remaining.IsPositive() {
+				break
+			}
+
+			if !c.uncoveredHourlyCost.IsPositive() {
+				continue
+			}
+
+			rate := decimal.NewFromInt(1).Sub(decimal.NewFromFloat(discountPercent(plan, c.service) / 100))
+			spend := decimal.Min(remaining, c.uncoveredHourlyCost.Mul(rate))
+			componentCovered := spend.Div(rate)
+
+			c.uncoveredHourlyCost = c.uncoveredHourlyCost.Sub(componentCovered)
+			remaining = remaining.Sub(spend)
+			covered = covered.Add(componentCovered)
+
+			coverage[c.projectIndex].covered = coverage[c.projectIndex].covered.Add(componentCovered)
+			coverage[c.projectIndex].savingsPlan = coverage[c.projectIndex].savingsPlan.Add(spend)
+		}
+
+		used := commitment.Sub(remaining)
+
+		outPlans = append(outPlans, output.SavingsPlan{
+			Name:                        plan.DisplayName(),
+			Type:                        plan.Type,
+			Term:                        plan.Term,
+			PaymentOption:               plan.PaymentOption,
+			HourlyCommitment:            decimalPtr(commitment),
+			CoveredMonthlyCost:          monthly(covered),
+			UsedCommitmentMonthlyCost:   monthly(used),
+			UnusedCommitmentMonthlyCost: monthly(remaining),
+			UtilizationPercent:          decimalPtr(used.Div(commitment).Mul(decimal.NewFromInt(100))),
+		})
+
+		totalCovered = totalCovered.Add(covered)
+		totalCommitment = totalCommitment.Add(commitment)
+		totalUnused = totalUnused.Add(remaining)
+	}
+
+	totalEligible := decimal.Zero
+	for _, c := range costs {
+		totalEligible = totalEligible.Add(c.hourlyCost)
+	}
+
+	totalHourlyCost := decimal.Zero
+	if out.TotalHourlyCost != nil {
+		totalHourlyCost = *out.TotalHourlyCost
+	}
+	effectiveHourlyCost := totalHourlyCost.Sub(totalCovered).Add(totalCommitment)
+
+	out.SavingsPlans = &output.SavingsPlans{
+		Plans:                       outPlans,
+		EligibleMonthlyCost:         monthly(totalEligible),
+		CoveredMonthlyCost:          monthly(totalCovered),
+		UncoveredMonthlyCost:        monthly(totalEligible.Sub(totalCovered)),
+		CommitmentMonthlyCost:       monthly(totalCommitment),
+		UnusedCommitmentMonthlyCost: monthly(totalUnused),
+		EffectiveTotalHourlyCost:    decimalPtr(effectiveHourlyCost),
+		EffectiveTotalMonthlyCost:   monthly(effectiveHourlyCost),
+	}
+
+	for i := range out.Projects {
+		if i >= len(coverage) {
+			break
+		}
+
+		p := coverage[i]
+
+		projectMonthlyCost := decimal.Zero
+		if out.Projects[i].Breakdown != nil && out.Projects[i].Breakdown.TotalMonthlyCost != nil {
+			projectMonthlyCost = *out.Projects[i].Breakdown.TotalMonthlyCost
+		}
+
+		out.Projects[i].SavingsPlans = &output.ProjectSavingsPlans{
+			EligibleMonthlyCost:       monthly(p.eligible),
+			CoveredMonthlyCost:        monthly(p.covered),
+			UncoveredMonthlyCost:      monthly(p.eligible.Sub(p.covered)),
+			SavingsPlanMonthlyCost:    monthly(p.savingsPlan),
+			EffectiveTotalMonthlyCost: decimalPtr(projectMonthlyCost.Sub(*monthly(p.covered)).Add(*monthly(p.savingsPlan))),
+		}
+	}
+}
+
+// eligibleCosts returns the cost components of the current resources in the
+// projects that can be covered by Savings Plans.
+func eligibleCosts(projects []*schema.Project) []*eligibleCost {
+	costs := make([]*eligibleCost, 0)
+
+	var addResource func(i int, r *schema.Resource)
+	addResource = func(i int, r *schema.Resource) {
+		for _, c := range r.CostComponents {
+			if c.HourlyCost == nil || !c.HourlyCost.IsPositive() {
+				continue
+			}
+
+			service, ok := componentService(c)
+			if !ok {
+				continue
+			}
+
+			costs = append(costs, &eligibleCost{
+				projectIndex:        i,
+				service:             service,
+				region:              strVal(c.ProductFilter.Region),
+				family:              instanceFamily(c),
+				hourlyCost:          *c.HourlyCost,
+				uncoveredHourlyCost: *c.HourlyCost,
+			})
+		}
+
+		for _, s := range r.SubResources {
+			addResource(i, s)
+		}
+	}
+
+	for i, p := range projects {
+		for _, r := range p.Resources {
+			if !r.IsSkipped {
+				addResource(i, r)
+			}
+		}
+	}
+
+	return costs
+}
+
+// componentService returns the service of the cost component if it is the
+// on-demand usage of EC2 instances, Fargate or Lambda. Cost components that
+// were loaded from Infracost JSON files don't have product filters so they
+// are never eligible.
+func componentService(c *schema.CostComponent) (string, bool) {
+	f := c.ProductFilter
+	if f == nil || f.Service == nil || f.ProductFamily == nil {
+		return "", false
+	}
+
+	switch *f.Service {
+	case "AmazonEC2":
+		if *f.ProductFamily == "Compute Instance" && c.PriceFilter != nil && strVal(c.PriceFilter.PurchaseOption) == "on_demand" {
+			return serviceEC2, true
+		}
+	case "AmazonECS", "AmazonEKS":
+		if *f.ProductFamily == "Compute" && strings.Contains(strVal(attributeFilter(f, "usagetype").ValueRegex), "Fargate") {
+			return serviceFargate, true
+		}
+	case "AWSLambda":
+		if strings.HasPrefix(strVal(attributeFilter(f, "group").Value), "AWS-Lambda-Duration") {
+			return serviceLambda, true
+		}
+	}
+
+	return "", false
+}
+
+// instanceFamily returns the family of the EC2 instance type, e.g. m5 for
+// m5.large.
+func instanceFamily(c *schema.CostComponent) string {
+	instanceType := strVal(attributeFilter(c.ProductFilter, "instanceType").Value)
+	return strings.SplitN(instanceType, ".", 2)[0]
+}
+
+func planCovers(plan *config.SavingsPlan, c *eligibleCost) bool {
+	if plan.Type == config.SavingsPlanTypeEC2Instance {
+		return c.service == serviceEC2 && c.region == plan.Region && c.family == plan.InstanceFamily
+	}
+
+	return true
+}
+
+func discountPercent(plan *config.SavingsPlan, service string) float64 {
+	if d, ok := plan.DiscountPercents[service]; ok {
+		return d
+	}
+
+	return defaultDiscountPercents[plan.Type][service][plan.Term][plan.PaymentOption]
+}
+
+func attributeFilter(f *schema.ProductFilter, key string) *schema.AttributeFilter {
+	for _, a := range f.AttributeFilters {
+		if a.Key == key {
+			return a
+		}
+	}
+
+	return &schema.AttributeFilter{}
+}
+
+func monthly(hourly decimal.Decimal) *decimal.Decimal {
+	return decimalPtr(hourly.Mul(schema.HourToMonthUnitMultiplier))
+}
+
+func strVal(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
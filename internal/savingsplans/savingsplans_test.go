@@ -0,0 +1,179 @@
+package savingsplans
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func ec2Component(instanceType string, region string, hourlyCost float64) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name: "Instance usage",
+		ProductFilter: &schema.ProductFilter{
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr(instanceType)},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+		HourlyCost:  decimalPtr(decimal.NewFromFloat(hourlyCost)),
+	}
+}
+
+func fargateComponent(hourlyCost float64) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name: "Per GB per hour",
+		ProductFilter: &schema.ProductFilter{
+			Region:        strPtr("us-east-1"),
+			Service:       strPtr("AmazonECS"),
+			ProductFamily: strPtr("Compute"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/Fargate-GB-Hours/")},
+			},
+		},
+		HourlyCost: decimalPtr(decimal.NewFromFloat(hourlyCost)),
+	}
+}
+
+func testProjects() ([]*schema.Project, output.Root) {
+	projects := []*schema.Project{
+		{
+			Name: "app",
+			Resources: []*schema.Resource{
+				{
+					Name: "aws_instance.web",
+					CostComponents: []*schema.CostComponent{
+						ec2Component("m5.large", "us-east-1", 1),
+						{
+							Name:       "Storage",
+							HourlyCost: decimalPtr(decimal.NewFromFloat(0.5)),
+						},
+					},
+				},
+				{
+					Name:           "aws_spot_instance_request.worker",
+					CostComponents: []*schema.CostComponent{ec2Component("c5.large", "us-east-1", 1)},
+				},
+			},
+		},
+		{
+			Name: "jobs",
+			Resources: []*schema.Resource{
+				{
+					Name:           "aws_ecs_service.jobs",
+					CostComponents: []*schema.CostComponent{fargateComponent(2)},
+				},
+			},
+		},
+	}
+
+	// The spot instance isn't eligible
+	projects[0].Resources[1].CostComponents[0].PriceFilter.PurchaseOption = strPtr("spot")
+
+	out := output.Root{
+		TotalHourlyCost: decimalPtr(decimal.NewFromFloat(4.5)),
+		Projects: []output.Project{
+			{Name: "app", Breakdown: &output.Breakdown{TotalMonthlyCost: monthly(decimal.NewFromFloat(2.5))}},
+			{Name: "jobs", Breakdown: &output.Breakdown{TotalMonthlyCost: monthly(decimal.NewFromFloat(2))}},
+		},
+	}
+
+	return projects, out
+}
+
+func TestApply(t *testing.T) {
+	projects, out := testProjects()
+
+	Apply([]*config.SavingsPlan{
+		{
+			Type:             config.SavingsPlanTypeCompute,
+			HourlyCommitment: 1.4,
+			Term:             "1_year",
+			PaymentOption:    "no_upfront",
+			DiscountPercents: map[string]float64{"fargate": 50},
+		},
+		{
+			Type:             config.SavingsPlanTypeEC2Instance,
+			HourlyCommitment: 1,
+			Term:             "1_year",
+			PaymentOption:    "no_upfront",
+			Region:           "us-east-1",
+			InstanceFamily:   "m5",
+			DiscountPercents: map[string]float64{"ec2": 50},
+		},
+	}, projects, &out)
+
+	require.NotNil(t, out.SavingsPlans)
+	require.Len(t, out.SavingsPlans.Plans, 2)
+
+	// The EC2 Instance plan is applied first and covers all of the m5 usage
+	// for 0.5 of its commitment
+	ec2Plan := out.SavingsPlans.Plans[0]
+	assert.Equal(t, "EC2 Instance (m5, us-east-1) Savings Plan, 1 year no upfront", ec2Plan.Name)
+	assert.Equal(t, "730", ec2Plan.CoveredMonthlyCost.String())
+	assert.Equal(t, "365", ec2Plan.UnusedCommitmentMonthlyCost.String())
+	assert.Equal(t, "50", ec2Plan.UtilizationPercent.String())
+
+	// The Compute plan covers the Fargate usage which is the only eligible
+	// cost left, and has the highest discount
+	computePlan := out.SavingsPlans.Plans[1]
+	assert.Equal(t, "1460", computePlan.CoveredMonthlyCost.String())
+	assert.Equal(t, "292", computePlan.UnusedCommitmentMonthlyCost.String())
+
+	assert.Equal(t, "2190", out.SavingsPlans.EligibleMonthlyCost.String())
+	assert.Equal(t, "2190", out.SavingsPlans.CoveredMonthlyCost.String())
+	assert.Equal(t, "0", out.SavingsPlans.UncoveredMonthlyCost.String())
+	assert.Equal(t, "1752", out.SavingsPlans.CommitmentMonthlyCost.String())
+	// 4.5 total - 3 covered + 2.4 commitment
+	assert.Equal(t, "3.9", out.SavingsPlans.EffectiveTotalHourlyCost.String())
+
+	require.NotNil(t, out.Projects[0].SavingsPlans)
+	assert.Equal(t, "730", out.Projects[0].SavingsPlans.EligibleMonthlyCost.String())
+	assert.Equal(t, "365", out.Projects[0].SavingsPlans.SavingsPlanMonthlyCost.String())
+	assert.Equal(t, "1460", out.Projects[0].SavingsPlans.EffectiveTotalMonthlyCost.String())
+	assert.Equal(t, "730", out.Projects[1].SavingsPlans.EffectiveTotalMonthlyCost.String())
+}
+
+func TestApplyPartialCoverage(t *testing.T) {
+	projects, out := testProjects()
+
+	Apply([]*config.SavingsPlan{
+		{
+			Type:             config.SavingsPlanTypeCompute,
+			HourlyCommitment: 0.46,
+			Term:             "3_year",
+			PaymentOption:    "all_upfront",
+		},
+	}, projects, &out)
+
+	require.NotNil(t, out.SavingsPlans)
+
+	// The EC2 discount of 54% is higher than the Fargate discount so all the
+	// commitment is used for the m5 usage
+	plan := out.SavingsPlans.Plans[0]
+	assert.Equal(t, "100", plan.UtilizationPercent.String())
+	assert.Equal(t, "0", plan.UnusedCommitmentMonthlyCost.String())
+	assert.Equal(t, "730", out.Projects[0].SavingsPlans.CoveredMonthlyCost.String())
+	assert.Equal(t, "0", out.Projects[1].SavingsPlans.CoveredMonthlyCost.String())
+}
+
+func TestApplyNoPlans(t *testing.T) {
+	projects, out := testProjects()
+
+	Apply(nil, projects, &out)
+
+	assert.Nil(t, out.SavingsPlans)
+	assert.Nil(t, out.Projects[0].SavingsPlans)
+}
@@ -16,8 +16,27 @@ type CostComponent struct {
 	MonthlyDiscountPerc  float64
 	price                decimal.Decimal
 	priceHash            string
+	priceWarnings        []PriceWarning
 	HourlyCost           *decimal.Decimal
 	MonthlyCost          *decimal.Decimal
+
+	// FallbackPriceFilter is used to look up the price when PriceFilter
+	// doesn't match any prices. The fallback price is multiplied by
+	// FallbackPriceMultiplier, e.g. to estimate spot prices from on-demand
+	// prices in regions that have no spot prices.
+	FallbackPriceFilter     *PriceFilter
+	FallbackPriceMultiplier decimal.Decimal
+
+	// UpfrontQuantity is the quantity of a one-off fee, e.g. the upfront fee
+	// of a reservation. The fee is set as the UpfrontCost and isn't included
+	// in the hourly and monthly costs.
+	UpfrontQuantity *decimal.Decimal
+	UpfrontCost     *decimal.Decimal
+
+	// ListMonthlyCost is the monthly cost at the list price when the price
+	// was changed by a negotiated discount or custom price.
+	ListMonthlyCost *decimal.Decimal
+	listPrice       *decimal.Decimal
 }
 
 func (c *CostComponent) CalculateCosts() {
@@ -28,6 +47,12 @@ func (c *CostComponent) CalculateCosts() {
 	if c.MonthlyQuantity != nil {
 		discountMul := decimal.NewFromFloat(1.0 - c.MonthlyDiscountPerc)
 		c.MonthlyCost = decimalPtr(c.price.Mul(*c.MonthlyQuantity).Mul(discountMul))
+		if c.listPrice != nil {
+			c.ListMonthlyCost = decimalPtr(c.listPrice.Mul(*c.MonthlyQuantity).Mul(discountMul))
+		}
+	}
+	if c.UpfrontQuantity != nil {
+		c.UpfrontCost = decimalPtr(c.price.Mul(*c.UpfrontQuantity))
 	}
 }
 
@@ -47,6 +72,18 @@ func (c *CostComponent) Price() decimal.Decimal {
 	return c.price
 }
 
+// SetListPrice sets the price before any negotiated discount or custom price
+// was applied.
+func (c *CostComponent) SetListPrice(price decimal.Decimal) {
+	c.listPrice = &price
+}
+
+// ListPrice returns the price before any negotiated discount or custom price
+// was applied, or nil if the price wasn't changed.
+func (c *CostComponent) ListPrice() *decimal.Decimal {
+	return c.listPrice
+}
+
 func (c *CostComponent) SetPriceHash(priceHash string) {
 	c.priceHash = priceHash
 }
@@ -55,10 +92,28 @@ func (c *CostComponent) PriceHash() string {
 	return c.priceHash
 }
 
+func (c *CostComponent) AddPriceWarning(warningType PriceWarningType, message string) {
+	c.priceWarnings = append(c.priceWarnings, PriceWarning{Type: warningType, Message: message})
+}
+
+func (c *CostComponent) PriceWarnings() []PriceWarning {
+	return c.priceWarnings
+}
+
 func (c *CostComponent) UnitMultiplierPrice() decimal.Decimal {
 	return c.Price().Mul(c.UnitMultiplier)
 }
 
+// UnitMultiplierListPrice returns the list price multiplied by the unit
+// multiplier, or nil if the price wasn't changed.
+func (c *CostComponent) UnitMultiplierListPrice() *decimal.Decimal {
+	if c.listPrice == nil {
+		return nil
+	}
+	m := c.listPrice.Mul(c.UnitMultiplier)
+	return &m
+}
+
 func (c *CostComponent) UnitMultiplierHourlyQuantity() *decimal.Decimal {
 	if c.HourlyQuantity == nil {
 		return nil
@@ -86,6 +86,8 @@ func diffResourcesByKey(resourceKey string, pastResMap, currentResMap map[string
 
 		HourlyCost:  diffDecimals(current.HourlyCost, past.HourlyCost),
 		MonthlyCost: diffDecimals(current.MonthlyCost, past.MonthlyCost),
+
+		UpfrontCost: diffOptionalDecimals(current.UpfrontCost, past.UpfrontCost),
 	}
 	for _, subResource := range past.SubResources {
 		subKey := fmt.Sprintf("%v.%v", resourceKey, subResource.Name)
@@ -187,6 +189,12 @@ func diffCostComponents(past *CostComponent, current *CostComponent) (bool, *Cos
 		PriceFilter:          baseCostComponent.PriceFilter,
 		priceHash:            baseCostComponent.priceHash,
 
+		FallbackPriceFilter:     baseCostComponent.FallbackPriceFilter,
+		FallbackPriceMultiplier: baseCostComponent.FallbackPriceMultiplier,
+
+		UpfrontQuantity: diffOptionalDecimals(current.UpfrontQuantity, past.UpfrontQuantity),
+		UpfrontCost:     diffOptionalDecimals(current.UpfrontCost, past.UpfrontCost),
+
 		HourlyQuantity:      diffDecimals(current.HourlyQuantity, past.HourlyQuantity),
 		MonthlyQuantity:     diffDecimals(current.MonthlyQuantity, past.MonthlyQuantity),
 		MonthlyDiscountPerc: current.MonthlyDiscountPerc - past.MonthlyDiscountPerc,
@@ -196,7 +204,8 @@ func diffCostComponents(past *CostComponent, current *CostComponent) (bool, *Cos
 	}
 	if !diff.HourlyQuantity.IsZero() || !diff.MonthlyQuantity.IsZero() ||
 		diff.MonthlyDiscountPerc != 0 || !diff.price.IsZero() ||
-		!diff.HourlyCost.IsZero() || !diff.MonthlyCost.IsZero() {
+		!diff.HourlyCost.IsZero() || !diff.MonthlyCost.IsZero() ||
+		(diff.UpfrontCost != nil && !diff.UpfrontCost.IsZero()) {
 		changed = true
 	}
 
@@ -241,6 +250,16 @@ func diffDecimals(current *decimal.Decimal, past *decimal.Decimal) *decimal.Deci
 	return &diff
 }
 
+// diffOptionalDecimals calculates the diff between two decimals, returning nil
+// if neither is set, e.g. for upfront costs which most resources don't have.
+func diffOptionalDecimals(current *decimal.Decimal, past *decimal.Decimal) *decimal.Decimal {
+	if past == nil && current == nil {
+		return nil
+	}
+
+	return diffDecimals(current, past)
+}
+
 // diffName creates a new cost component name for the diff cost component based on the existing cost components.
 // Anything that is in brackets is treated as a label and any difference in the labels across the past and current
 // are represented as "old → new"
@@ -253,6 +253,14 @@ func TestDiffDecimals(t *testing.T) {
 	assert.Equal(t, decimal.Zero, *diffDecimals(nil, nil))
 }
 
+func TestDiffOptionalDecimals(t *testing.T) {
+	dc1 := decimalPtr(decimal.NewFromInt(10))
+
+	assert.Nil(t, diffOptionalDecimals(nil, nil))
+	assert.Equal(t, decimal.NewFromInt(10), *diffOptionalDecimals(dc1, nil))
+	assert.Equal(t, decimal.NewFromInt(-10), *diffOptionalDecimals(nil, dc1))
+}
+
 func TestGetResourcesMap(t *testing.T) {
 	rs1 := &Resource{
 		Name: "rs1",
@@ -14,6 +14,10 @@ type Remediater interface {
 	// The description can be used to prompt the user before taking action.
 	Describe() string
 
+	// APICalls describes the cloud API calls that Remediate would make, so they
+	// can be shown in a dry run without making any changes.
+	APICalls() []string
+
 	// Remediate attempts to fix a problem in the cloud that prevents estimation,
 	// e.g. by enabling metrics collection on certain resources.
 	Remediate() error
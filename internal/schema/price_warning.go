@@ -0,0 +1,20 @@
+package schema
+
+// PriceWarningType is the reason the price lookup for a cost component didn't
+// return exactly one product with one price.
+type PriceWarningType string
+
+const (
+	PriceWarningNoProducts       PriceWarningType = "no_products"
+	PriceWarningMultipleProducts PriceWarningType = "multiple_products"
+	PriceWarningNoPrices         PriceWarningType = "no_prices"
+	PriceWarningMultiplePrices   PriceWarningType = "multiple_prices"
+	PriceWarningInvalidPrice     PriceWarningType = "invalid_price"
+)
+
+// PriceWarning records a problem with the price lookup for a cost component
+// that means its cost might be wrong.
+type PriceWarning struct {
+	Type    PriceWarningType
+	Message string
+}
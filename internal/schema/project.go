@@ -20,6 +20,9 @@ type ProjectMetadata struct {
 	VCSSubPath         string `json:"vcsSubPath,omitempty"`
 	VCSPullRequestURL  string `json:"vcsPullRequestUrl,omitempty"`
 	TerraformWorkspace string `json:"terraformWorkspace,omitempty"`
+	CDKStack           string `json:"cdkStack,omitempty"`
+	PulumiProject      string `json:"pulumiProject,omitempty"`
+	PulumiStack        string `json:"pulumiStack,omitempty"`
 }
 
 // Project contains the existing, planned state of
@@ -94,6 +97,14 @@ func GenerateProjectName(metadata *ProjectMetadata, dashboardEnabled bool) strin
 		n += fmt.Sprintf(" (%s)", metadata.TerraformWorkspace)
 	}
 
+	if metadata.CDKStack != "" {
+		n += fmt.Sprintf(" (%s)", metadata.CDKStack)
+	}
+
+	if metadata.PulumiStack != "" {
+		n += fmt.Sprintf(" (%s)", metadata.PulumiStack)
+	}
+
 	return n
 }
 
@@ -12,19 +12,81 @@ var HourToMonthUnitMultiplier = decimal.NewFromInt(730)
 type ResourceFunc func(*ResourceData, *UsageData) *Resource
 
 type Resource struct {
-	Name              string
-	CostComponents    []*CostComponent
-	SubResources      []*Resource
-	HourlyCost        *decimal.Decimal
-	MonthlyCost       *decimal.Decimal
-	IsSkipped         bool
-	NoPrice           bool
-	SkipMessage       string
-	ResourceType      string
-	Tags              map[string]string
-	UsageSchema       []*UsageItem
-	EstimateUsage     EstimateFunc
-	EstimationSummary map[string]bool
+	Name               string
+	CostComponents     []*CostComponent
+	SubResources       []*Resource
+	HourlyCost         *decimal.Decimal
+	MonthlyCost        *decimal.Decimal
+	IsSkipped          bool
+	NoPrice            bool
+	SkipMessage        string
+	ResourceType       string
+	Tags               map[string]string
+	CloudResourceIDs   []string
+	UsageSchema        []*UsageItem
+	EstimateUsage      EstimateFunc
+	EstimationSummary  map[string]bool
+	EstimatedUsageKeys map[string]bool
+
+	// UpfrontCost is the total of the one-off fees of the cost components
+	// and subresources, which isn't included in the hourly and monthly costs.
+	UpfrontCost *decimal.Decimal
+
+	// ListMonthlyCost is the monthly cost at the list prices. It is only set
+	// if the price of a cost component or subresource was changed by a
+	// negotiated discount or custom price.
+	ListMonthlyCost *decimal.Decimal
+
+	rebuild   func(*UsageData) *Resource
+	usageData *UsageData
+}
+
+// UsageSources returns where the value for each of the resource's usage keys
+// came from. Keys in the usage schema that have no value in the usage data
+// are using their defaults.
+func (r *Resource) UsageSources() map[string]UsageSource {
+	sources := make(map[string]UsageSource, len(r.UsageSchema))
+
+	for _, item := range r.UsageSchema {
+		sources[item.Key] = UsageSourceDefault
+	}
+
+	for key, hasValue := range r.EstimationSummary {
+		if r.EstimatedUsageKeys[key] {
+			sources[key] = UsageSourceEstimate
+		} else if hasValue {
+			sources[key] = UsageSourceUsageFile
+		} else if _, ok := sources[key]; !ok {
+			sources[key] = UsageSourceDefault
+		}
+	}
+
+	return sources
+}
+
+// Rebuild builds the resource again from different usage data, keeping the
+// fields set by the parser. The resource is returned as is if it can't be
+// rebuilt.
+func (r *Resource) Rebuild(u *UsageData) *Resource {
+	if r.rebuild == nil {
+		return r
+	}
+
+	res := r.rebuild(u)
+	if res == nil {
+		return r
+	}
+
+	res.ResourceType = r.ResourceType
+	res.Tags = r.Tags
+	res.CloudResourceIDs = r.CloudResourceIDs
+	res.SetRebuildFunc(u, r.rebuild)
+	if u != nil {
+		res.EstimationSummary = u.CalcEstimationSummary()
+		res.EstimatedUsageKeys = u.EstimatedKeys
+	}
+
+	return res
 }
 
 func CalculateCosts(project *Project) {
@@ -38,6 +100,11 @@ func (r *Resource) CalculateCosts() {
 	m := decimal.Zero
 	hasCost := false
 
+	var upfront *decimal.Decimal
+
+	list := decimal.Zero
+	hasListCost := false
+
 	for _, c := range r.CostComponents {
 		c.CalculateCosts()
 		if c.HourlyCost != nil || c.MonthlyCost != nil {
@@ -49,6 +116,15 @@ func (r *Resource) CalculateCosts() {
 		if c.MonthlyCost != nil {
 			m = m.Add(*c.MonthlyCost)
 		}
+		if c.UpfrontCost != nil {
+			upfront = addUpfrontCost(upfront, *c.UpfrontCost)
+		}
+		if c.ListMonthlyCost != nil {
+			hasListCost = true
+			list = list.Add(*c.ListMonthlyCost)
+		} else if c.MonthlyCost != nil {
+			list = list.Add(*c.MonthlyCost)
+		}
 	}
 
 	for _, s := range r.SubResources {
@@ -62,12 +138,25 @@ func (r *Resource) CalculateCosts() {
 		if s.MonthlyCost != nil {
 			m = m.Add(*s.MonthlyCost)
 		}
+		if s.UpfrontCost != nil {
+			upfront = addUpfrontCost(upfront, *s.UpfrontCost)
+		}
+		if s.ListMonthlyCost != nil {
+			hasListCost = true
+			list = list.Add(*s.ListMonthlyCost)
+		} else if s.MonthlyCost != nil {
+			list = list.Add(*s.MonthlyCost)
+		}
 	}
 
 	if hasCost {
 		r.HourlyCost = &h
 		r.MonthlyCost = &m
 	}
+	r.UpfrontCost = upfront
+	if hasListCost {
+		r.ListMonthlyCost = &list
+	}
 	if r.NoPrice {
 		log.Debugf("Skipping free resource %s", r.Name)
 	}
@@ -112,6 +201,9 @@ func MultiplyQuantities(resource *Resource, multiplier decimal.Decimal) {
 		if costComponent.MonthlyQuantity != nil {
 			costComponent.MonthlyQuantity = decimalPtr(costComponent.MonthlyQuantity.Mul(multiplier))
 		}
+		if costComponent.UpfrontQuantity != nil {
+			costComponent.UpfrontQuantity = decimalPtr(costComponent.UpfrontQuantity.Mul(multiplier))
+		}
 	}
 
 	for _, subResource := range resource.SubResources {
@@ -122,3 +214,11 @@ func MultiplyQuantities(resource *Resource, multiplier decimal.Decimal) {
 func decimalPtr(d decimal.Decimal) *decimal.Decimal {
 	return &d
 }
+
+func addUpfrontCost(total *decimal.Decimal, cost decimal.Decimal) *decimal.Decimal {
+	if total == nil {
+		return &cost
+	}
+
+	return decimalPtr(total.Add(cost))
+}
@@ -9,8 +9,9 @@ import (
 )
 
 type UsageData struct {
-	Address    string
-	Attributes map[string]gjson.Result
+	Address       string
+	Attributes    map[string]gjson.Result
+	EstimatedKeys map[string]bool
 }
 
 func NewUsageData(address string, attributes map[string]gjson.Result) *UsageData {
@@ -95,7 +96,7 @@ func convertArrayKeyToWildcard(key string) string {
 }
 
 // CalcEstimationSummary returns a map where a value of true means the attribute key has an actual estimate, false means
-// it is using the defaults
+// it is using the defaults. Keys in EstimatedKeys were estimated from the cloud provider so they always have an estimate.
 func (u *UsageData) CalcEstimationSummary() map[string]bool {
 	estimationMap := make(map[string]bool)
 	for k, v := range u.Attributes {
@@ -107,7 +108,7 @@ func (u *UsageData) CalcEstimationSummary() map[string]bool {
 		case gjson.String:
 			hasEstimate = v.Str != ""
 		}
-		estimationMap[k] = hasEstimate
+		estimationMap[k] = hasEstimate || u.EstimatedKeys[k]
 	}
 	return estimationMap
 }
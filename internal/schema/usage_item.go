@@ -17,3 +17,12 @@ type UsageItem struct {
 	ValueType    UsageVariableType
 	Description  string
 }
+
+// UsageSource describes where the value for a usage key came from.
+type UsageSource string
+
+const (
+	UsageSourceUsageFile UsageSource = "usage file"
+	UsageSourceEstimate  UsageSource = "estimate"
+	UsageSourceDefault   UsageSource = "default"
+)
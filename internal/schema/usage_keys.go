@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// SetRebuildFunc sets the function that builds the resource again from
+// different usage data, and the usage data the resource was built from. It's
+// used to find which usage keys the quantities of each cost component use.
+func (r *Resource) SetRebuildFunc(u *UsageData, rebuild func(*UsageData) *Resource) {
+	r.usageData = u
+	r.rebuild = rebuild
+}
+
+// CostComponentUsageKeys returns the usage keys that the quantities of each
+// of the resource's cost components use, including the cost components of its
+// subresources. The resource is rebuilt with each numeric usage key changed
+// and a cost component uses the key if its quantities change. Cost components
+// that don't use any usage keys aren't included.
+func (r *Resource) CostComponentUsageKeys() map[*CostComponent][]string {
+	keys := make(map[*CostComponent][]string)
+
+	if r.rebuild == nil {
+		return keys
+	}
+
+	base := r.rebuild(r.usageData)
+	if base == nil {
+		return keys
+	}
+
+	components := make(map[string]*CostComponent)
+	forEachCostComponent(r, func(key string, c *CostComponent) {
+		components[key] = c
+	})
+
+	baseQuantities := costComponentQuantities(base)
+
+	for _, item := range r.usageItems() {
+		u, ok := changedUsageData(r.usageData, r.Name, item)
+		if !ok {
+			continue
+		}
+
+		changed := r.rebuild(u)
+		if changed == nil {
+			continue
+		}
+
+		changedQuantities := costComponentQuantities(changed)
+
+		for key, q := range baseQuantities {
+			if changedQuantities[key] == q {
+				continue
+			}
+
+			if c, ok := components[key]; ok {
+				keys[c] = append(keys[c], item.Key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// usageItems returns the items in the usage schema and any other numeric
+// usage keys in the usage data, since not all resources have a usage schema.
+func (r *Resource) usageItems() []*UsageItem {
+	items := make([]*UsageItem, 0, len(r.UsageSchema))
+	seen := make(map[string]bool, len(r.UsageSchema))
+
+	for _, item := range r.UsageSchema {
+		items = append(items, item)
+		seen[item.Key] = true
+	}
+
+	if r.usageData == nil {
+		return items
+	}
+
+	keys := make([]string, 0, len(r.usageData.Attributes))
+	for k, v := range r.usageData.Attributes {
+		if !seen[k] && v.Type == gjson.Number {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		items = append(items, &UsageItem{Key: k, ValueType: Float64})
+	}
+
+	return items
+}
+
+// forEachCostComponent calls f with each cost component of the resource and
+// its subresources, and a key that identifies it when the resource is built
+// again.
+func forEachCostComponent(r *Resource, f func(key string, c *CostComponent)) {
+	visit := func(prefix string, components []*CostComponent) {
+		seen := make(map[string]int)
+
+		for _, c := range components {
+			key := prefix + "/" + c.Name
+
+			seen[key]++
+			if seen[key] > 1 {
+				key = fmt.Sprintf("%s#%d", key, seen[key])
+			}
+
+			f(key, c)
+		}
+	}
+
+	visit("", r.CostComponents)
+	for _, s := range r.FlattenedSubResources() {
+		visit(s.Name, s.CostComponents)
+	}
+}
+
+func costComponentQuantities(r *Resource) map[string]string {
+	quantities := make(map[string]string)
+
+	forEachCostComponent(r, func(key string, c *CostComponent) {
+		quantities[key] = fmt.Sprintf("%s|%s|%s", formatQuantity(c.HourlyQuantity), formatQuantity(c.MonthlyQuantity), formatQuantity(c.UpfrontQuantity))
+	})
+
+	return quantities
+}
+
+// changedUsageData returns a copy of the usage data with the value of the
+// numeric usage item changed, or false if the item isn't numeric.
+func changedUsageData(u *UsageData, address string, item *UsageItem) (*UsageData, bool) {
+	if item.ValueType != Int64 && item.ValueType != Float64 {
+		return nil, false
+	}
+
+	changed := &UsageData{
+		Address:    address,
+		Attributes: make(map[string]gjson.Result),
+	}
+
+	if u != nil {
+		changed.Address = u.Address
+		changed.EstimatedKeys = u.EstimatedKeys
+
+		for k, v := range u.Attributes {
+			changed.Attributes[k] = v
+		}
+	}
+
+	v := usageItemDefault(item)
+	if existing := changed.Attributes[item.Key]; existing.Type == gjson.Number {
+		v = existing.Float()
+	}
+
+	// Change the value by enough that quantities that are rounded up still
+	// change, and so it doesn't match defaults that resources set themselves
+	v = v*2 + 100
+
+	raw := strconv.FormatFloat(v, 'f', -1, 64)
+	if item.ValueType == Int64 {
+		raw = strconv.FormatInt(int64(v), 10)
+	}
+
+	changed.Attributes[item.Key] = gjson.Parse(raw)
+
+	return changed, true
+}
+
+func usageItemDefault(item *UsageItem) float64 {
+	switch v := item.DefaultValue.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+
+	return 0
+}
+
+func formatQuantity(q *decimal.Decimal) string {
+	if q == nil {
+		return "-"
+	}
+
+	return q.String()
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func usageQuantity(u *UsageData, key string) *decimal.Decimal {
+	if v := u.GetFloat(key); v != nil {
+		return decimalPtr(decimal.NewFromFloat(*v))
+	}
+
+	return nil
+}
+
+func TestCostComponentUsageKeys(t *testing.T) {
+	// A resource without a usage schema, like the older resources that read
+	// the usage data directly
+	build := func(u *UsageData) *Resource {
+		return &Resource{
+			Name: "aws_s3_bucket.bucket",
+			CostComponents: []*CostComponent{
+				{Name: "Storage", MonthlyQuantity: usageQuantity(u, "storage_gb")},
+				{Name: "Bucket", MonthlyQuantity: decimalPtr(decimal.NewFromInt(1))},
+			},
+			SubResources: []*Resource{
+				{
+					Name: "Requests",
+					CostComponents: []*CostComponent{
+						{Name: "PUT requests", MonthlyQuantity: usageQuantity(u, "monthly_put_requests")},
+					},
+				},
+			},
+		}
+	}
+
+	u := NewUsageData("aws_s3_bucket.bucket", map[string]gjson.Result{
+		"storage_gb":           gjson.Parse("100"),
+		"monthly_put_requests": gjson.Parse("1000"),
+		"storage_class":        gjson.Parse(`"STANDARD"`),
+	})
+
+	r := build(u)
+	r.SetRebuildFunc(u, build)
+
+	keys := r.CostComponentUsageKeys()
+
+	assert.Equal(t, []string{"storage_gb"}, keys[r.CostComponents[0]])
+	assert.Nil(t, keys[r.CostComponents[1]])
+	assert.Equal(t, []string{"monthly_put_requests"}, keys[r.SubResources[0].CostComponents[0]])
+
+	assert.Empty(t, (&Resource{Name: "aws_instance.web"}).CostComponentUsageKeys())
+}
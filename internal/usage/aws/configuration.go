@@ -6,12 +6,48 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 type ctxConfigOptsKeyType struct{}
 
 var ctxConfigOptsKey = &ctxConfigOptsKeyType{}
 
+type endpointResolver struct {
+	URL string
+}
+
+func (er *endpointResolver) ResolveEndpoint(service, region string) (aws.Endpoint, error) {
+	return aws.Endpoint{
+		URL: er.URL,
+	}, nil
+}
+
+// WithEndpoint returns a context that sends the AWS API calls made with it to
+// the given endpoint instead of the AWS service endpoints, e.g. a local stub.
+func WithEndpoint(ctx context.Context, url string) context.Context {
+	ctx = withConfigOpts(ctx, config.WithEndpointResolver(&endpointResolver{URL: url}))
+
+	s3Opts := func(o *s3.Options) {
+		// We need this so the SDK doesn't use a subdomain for its requests
+		o.UsePathStyle = true
+	}
+
+	return context.WithValue(ctx, ctxS3ConfigOptsKey, s3Opts)
+}
+
+// withConfigOpts returns a context with the options added to any config
+// options already set in the context.
+func withConfigOpts(ctx context.Context, opts ...func(*config.LoadOptions) error) context.Context {
+	existing, _ := ctx.Value(ctxConfigOptsKey).([]func(*config.LoadOptions) error)
+
+	merged := make([]func(*config.LoadOptions) error, 0, len(existing)+len(opts))
+	merged = append(merged, existing...)
+	merged = append(merged, opts...)
+
+	return context.WithValue(ctx, ctxConfigOptsKey, merged)
+}
+
 func getConfig(ctx context.Context, region string) (aws.Config, error) {
 	opts := []func(*config.LoadOptions) error{
 		config.WithRegion(region),
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -13,6 +14,10 @@ type ctxS3ConfigOptsKeyType struct{}
 
 var ctxS3ConfigOptsKey = &ctxS3ConfigOptsKeyType{}
 
+// S3MetricsConfigurationID is the ID of the request metrics configuration
+// that S3EnableBucketMetrics creates.
+const S3MetricsConfigurationID = "infracost"
+
 func s3NewClient(ctx context.Context, region string) (*s3.Client, error) {
 	cfg, err := getConfig(ctx, region)
 	if err != nil {
@@ -48,6 +53,24 @@ func S3FindMetricsFilter(ctx context.Context, region string, bucket string) (str
 	return "", nil
 }
 
+// S3EnableBucketMetrics enables request metrics for the entire bucket so its
+// requests can be estimated once CloudWatch has collected them.
+func S3EnableBucketMetrics(ctx context.Context, region string, bucket string) error {
+	client, err := s3NewClient(ctx, region)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Calling AWS S3 API: PutBucketMetricsConfiguration(region: %s, Bucket: %s, Id: %s)", region, bucket, S3MetricsConfigurationID)
+	_, err = client.PutBucketMetricsConfiguration(ctx, &s3.PutBucketMetricsConfigurationInput{
+		Bucket: strPtr(bucket),
+		Id:     strPtr(S3MetricsConfigurationID),
+		MetricsConfiguration: &s3types.MetricsConfiguration{
+			Id: strPtr(S3MetricsConfigurationID),
+		},
+	})
+	return err
+}
+
 func S3GetBucketSizeBytes(ctx context.Context, region string, bucket string, storageType string) (float64, error) {
 	log.Debugf("Querying AWS CloudWatch: AWS/S3 BucketSizeBytes (region: %s, BucketName: %s, StorageType: %s)", region, bucket, storageType)
 	stats, err := cloudwatchGetMonthlyStats(ctx, statsRequest{
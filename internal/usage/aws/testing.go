@@ -6,19 +6,8 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
-type testResolver struct {
-	URL string
-}
-
-func (tr *testResolver) ResolveEndpoint(service, region string) (aws.Endpoint, error) {
-	return aws.Endpoint{
-		URL: tr.URL,
-	}, nil
-}
-
 type testCredentials struct {
 }
 
@@ -27,19 +16,10 @@ func (tc *testCredentials) Retrieve(ctx context.Context) (aws.Credentials, error
 }
 
 func WithTestEndpoint(ctx context.Context, url string) context.Context {
-	resolver := &testResolver{URL: url}
-	opts := []func(*config.LoadOptions) error{
-		config.WithEndpointResolver(resolver),
+	ctx = WithEndpoint(ctx, url)
+
+	return withConfigOpts(ctx,
 		config.WithCredentialsProvider(&testCredentials{}),
 		// config.WithClientLogMode(aws.LogRequestWithBody | aws.LogResponseWithBody),
-	}
-	ctx = context.WithValue(ctx, ctxConfigOptsKey, opts)
-
-	s3Opts := func(o *s3.Options) {
-		// We need this so the SDK doesn't use a subdomain for its requests
-		o.UsePathStyle = true
-	}
-
-	ctx = context.WithValue(ctx, ctxS3ConfigOptsKey, s3Opts)
-	return ctx
+	)
 }
@@ -0,0 +1,44 @@
+package azure
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ApplicationGatewayGetBytesProcessed returns the bytes sent and received by
+// the application gateway.
+func ApplicationGatewayGetBytesProcessed(ctx context.Context, resourceID string) (float64, error) {
+	var total float64
+
+	for _, metric := range []string{"BytesReceived", "BytesSent"} {
+		log.Debugf("Querying Azure Monitor: %s (resource: %s)", metric, resourceID)
+		bytes, err := monitorGetMonthlyValue(ctx, metricsRequest{
+			resourceID:  resourceID,
+			metric:      metric,
+			aggregation: aggregationTotal,
+		})
+		if err != nil {
+			return 0, err
+		}
+		total += bytes
+	}
+
+	return total, nil
+}
+
+// ApplicationGatewayGetCapacityUnits returns the monthly capacity units of a
+// v2 application gateway, based on the average capacity units it used.
+func ApplicationGatewayGetCapacityUnits(ctx context.Context, resourceID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: CapacityUnits (resource: %s)", resourceID)
+	avg, err := monitorGetMonthlyValue(ctx, metricsRequest{
+		resourceID:  resourceID,
+		metric:      "CapacityUnits",
+		aggregation: aggregationAverage,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return avg * hoursMonth, nil
+}
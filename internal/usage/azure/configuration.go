@@ -0,0 +1,64 @@
+package azure
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultManagementEndpoint = "https://management.azure.com"
+
+type ctxEndpointKeyType struct{}
+
+var ctxEndpointKey = &ctxEndpointKeyType{}
+
+type ctxAccessTokenKeyType struct{}
+
+var ctxAccessTokenKey = &ctxAccessTokenKeyType{}
+
+// WithEndpoint returns a context that sends the Azure Monitor API calls made
+// with it to the given endpoint instead of the Azure Resource Manager
+// endpoint, e.g. a local fake.
+func WithEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxEndpointKey, strings.TrimSuffix(url, "/"))
+}
+
+// WithTestEndpoint returns a context that sends the Azure Monitor API calls
+// to the given endpoint with a fixed access token.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	ctx = WithEndpoint(ctx, url)
+	return context.WithValue(ctx, ctxAccessTokenKey, "test-token")
+}
+
+func managementEndpoint(ctx context.Context) string {
+	if endpoint, ok := ctx.Value(ctxEndpointKey).(string); ok && endpoint != "" {
+		return endpoint
+	}
+
+	return defaultManagementEndpoint
+}
+
+// accessToken returns the access token for the Azure Resource Manager API
+// calls. It uses the AZURE_ACCESS_TOKEN env variable, or falls back to the
+// Azure CLI.
+func accessToken(ctx context.Context) (string, error) {
+	if token, ok := ctx.Value(ctxAccessTokenKey).(string); ok && token != "" {
+		return token, nil
+	}
+
+	if token := os.Getenv("AZURE_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	log.Debugf("Getting Azure access token from az")
+	out, err := exec.CommandContext(ctx, "az", "account", "get-access-token", "--resource", defaultManagementEndpoint+"/", "--query", "accessToken", "--output", "tsv").Output()
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting Azure access token, set AZURE_ACCESS_TOKEN or log in with az")
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,103 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// cosmosDBResource is the account and the database and collection names of a
+// Cosmos DB resource, which the account metrics can be filtered by.
+type cosmosDBResource struct {
+	accountID  string
+	database   string
+	collection string
+}
+
+// parseCosmosDBResourceID parses the ID of a Cosmos DB database or collection,
+// e.g. /subscriptions/x/resourceGroups/y/providers/Microsoft.DocumentDB/databaseAccounts/acc/sqlDatabases/db/containers/c.
+// Azure Monitor only has metrics for the account so they're filtered by the
+// database and collection. Table API tables are collections in the TablesDB
+// database.
+func parseCosmosDBResourceID(resourceID string) (cosmosDBResource, error) {
+	if resourceID == "" {
+		return cosmosDBResource{}, errNoResourceID
+	}
+
+	parts := strings.Split(strings.Trim(resourceID, "/"), "/")
+
+	accountIdx := -1
+	for i, p := range parts {
+		if strings.EqualFold(p, "databaseAccounts") {
+			accountIdx = i
+			break
+		}
+	}
+
+	if accountIdx == -1 || accountIdx+1 >= len(parts) {
+		return cosmosDBResource{}, errors.Errorf("Invalid Cosmos DB resource ID %s", resourceID)
+	}
+
+	r := cosmosDBResource{
+		accountID: "/" + strings.Join(parts[:accountIdx+2], "/"),
+	}
+
+	children := parts[accountIdx+2:]
+	if len(children) >= 2 && strings.EqualFold(children[0], "tables") {
+		r.database = "TablesDB"
+		r.collection = children[1]
+		return r, nil
+	}
+
+	if len(children) >= 2 {
+		r.database = children[1]
+	}
+	if len(children) >= 4 {
+		r.collection = children[3]
+	}
+
+	return r, nil
+}
+
+func (r cosmosDBResource) dimensionFilters() map[string]string {
+	filters := map[string]string{}
+	if r.database != "" {
+		filters["DatabaseName"] = r.database
+	}
+	if r.collection != "" {
+		filters["CollectionName"] = r.collection
+	}
+	return filters
+}
+
+func CosmosDBGetRequestUnits(ctx context.Context, resourceID string) (float64, error) {
+	r, err := parseCosmosDBResourceID(resourceID)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Debugf("Querying Azure Monitor: TotalRequestUnits (resource: %s)", resourceID)
+	return monitorGetMonthlyValue(ctx, metricsRequest{
+		resourceID:       r.accountID,
+		metric:           "TotalRequestUnits",
+		aggregation:      aggregationTotal,
+		dimensionFilters: r.dimensionFilters(),
+	})
+}
+
+func CosmosDBGetDataUsageBytes(ctx context.Context, resourceID string) (float64, error) {
+	r, err := parseCosmosDBResourceID(resourceID)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Debugf("Querying Azure Monitor: DataUsage (resource: %s)", resourceID)
+	return monitorGetMonthlyValue(ctx, metricsRequest{
+		resourceID:       r.accountID,
+		metric:           "DataUsage",
+		aggregation:      aggregationAverage,
+		dimensionFilters: r.dimensionFilters(),
+	})
+}
@@ -0,0 +1,27 @@
+package azure
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func FunctionAppGetExecutionCount(ctx context.Context, resourceID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: FunctionExecutionCount (resource: %s)", resourceID)
+	return monitorGetMonthlyValue(ctx, metricsRequest{
+		resourceID:  resourceID,
+		metric:      "FunctionExecutionCount",
+		aggregation: aggregationTotal,
+	})
+}
+
+// FunctionAppGetExecutionUnits returns the execution units of the function app
+// in MB-milliseconds.
+func FunctionAppGetExecutionUnits(ctx context.Context, resourceID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: FunctionExecutionUnits (resource: %s)", resourceID)
+	return monitorGetMonthlyValue(ctx, metricsRequest{
+		resourceID:  resourceID,
+		metric:      "FunctionExecutionUnits",
+		aggregation: aggregationTotal,
+	})
+}
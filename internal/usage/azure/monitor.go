@@ -0,0 +1,202 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	aggregationTotal   = "Total"
+	aggregationAverage = "Average"
+
+	metricsAPIVersion = "2018-01-01"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+var errNoResourceID = errors.New("No Azure resource ID found, the resource might not be deployed yet")
+
+type metricsRequest struct {
+	resourceID  string
+	metric      string
+	aggregation string
+	// dimension is the metric dimension the time series are split by, e.g.
+	// "ApiName" for storage account transactions.
+	dimension string
+	// dimensionFilters are the dimension values that the metric is filtered
+	// by, e.g. the database of a Cosmos DB account.
+	dimensionFilters map[string]string
+}
+
+type metricsResponse struct {
+	Value []struct {
+		Timeseries []struct {
+			Metadatavalues []struct {
+				Name struct {
+					Value string `json:"value"`
+				} `json:"name"`
+				Value string `json:"value"`
+			} `json:"metadatavalues"`
+			Data []struct {
+				Total   *float64 `json:"total"`
+				Average *float64 `json:"average"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"value"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// timeSeries is a series returned from Azure Monitor, reduced to a single
+// value for the month.
+type timeSeries struct {
+	// dimensions are keyed by the lower case dimension name since Azure
+	// Monitor doesn't preserve the case used in the request.
+	dimensions map[string]string
+	value      float64
+}
+
+func (r metricsRequest) filter() string {
+	parts := make([]string, 0, len(r.dimensionFilters)+1)
+	for k, v := range r.dimensionFilters {
+		parts = append(parts, fmt.Sprintf("%s eq '%s'", k, strings.ReplaceAll(v, "'", "''")))
+	}
+	sort.Strings(parts)
+
+	if r.dimension != "" {
+		parts = append(parts, fmt.Sprintf("%s eq '*'", r.dimension))
+	}
+
+	return strings.Join(parts, " and ")
+}
+
+// monitorGetMonthlyTimeSeries gets the daily values of the metric for the last
+// month. The values of each series are summed for the Total aggregation and
+// averaged otherwise.
+func monitorGetMonthlyTimeSeries(ctx context.Context, req metricsRequest) ([]timeSeries, error) {
+	if req.resourceID == "" {
+		return nil, errNoResourceID
+	}
+
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-timeMonth)
+
+	q := url.Values{}
+	q.Set("api-version", metricsAPIVersion)
+	q.Set("metricnames", req.metric)
+	q.Set("timespan", fmt.Sprintf("%s/%s", start.Format(time.RFC3339), end.Format(time.RFC3339)))
+	q.Set("interval", "P1D")
+	q.Set("aggregation", req.aggregation)
+	if filter := req.filter(); filter != "" {
+		q.Set("$filter", filter)
+	}
+
+	u := fmt.Sprintf("%s%s/providers/Microsoft.Insights/metrics?%s", managementEndpoint(ctx), req.resourceID, q.Encode())
+
+	resp, err := monitorGet(ctx, u, token)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]timeSeries, 0)
+
+	for _, metric := range resp.Value {
+		for _, ts := range metric.Timeseries {
+			var total float64
+			var count int
+			for _, d := range ts.Data {
+				if req.aggregation == aggregationTotal && d.Total != nil {
+					total += *d.Total
+					count++
+				} else if req.aggregation == aggregationAverage && d.Average != nil {
+					total += *d.Average
+					count++
+				}
+			}
+
+			if req.aggregation != aggregationTotal && count > 0 {
+				total /= float64(count)
+			}
+
+			dimensions := make(map[string]string, len(ts.Metadatavalues))
+			for _, m := range ts.Metadatavalues {
+				dimensions[strings.ToLower(m.Name.Value)] = m.Value
+			}
+
+			series = append(series, timeSeries{
+				dimensions: dimensions,
+				value:      total,
+			})
+		}
+	}
+
+	return series, nil
+}
+
+// monitorGetMonthlyValue returns the value of the metric for the last month.
+func monitorGetMonthlyValue(ctx context.Context, req metricsRequest) (float64, error) {
+	series, err := monitorGetMonthlyTimeSeries(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	var value float64
+	for _, s := range series {
+		value += s.value
+	}
+
+	return value, nil
+}
+
+func monitorGet(ctx context.Context, u string, token string) (*metricsResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error calling Azure Monitor API")
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading Azure Monitor API response")
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error.Message != "" {
+			return nil, fmt.Errorf("Azure Monitor API error: %s", errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("Azure Monitor API error: %s", httpResp.Status)
+	}
+
+	var resp metricsResponse
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing Azure Monitor API response")
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,128 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const storageAccountID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account"
+
+func TestMetricsRequestFilter(t *testing.T) {
+	req := metricsRequest{
+		dimension: "CollectionName",
+		dimensionFilters: map[string]string{
+			"DatabaseName": "o'reilly",
+			"Region":       "East US",
+		},
+	}
+
+	assert.Equal(t, `DatabaseName eq 'o''reilly' and Region eq 'East US' and CollectionName eq '*'`, req.filter())
+}
+
+func TestMonitorGetMonthlyTimeSeries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, storageAccountID+"/providers/Microsoft.Insights/metrics", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "Transactions", r.URL.Query().Get("metricnames"))
+		assert.Equal(t, "Total", r.URL.Query().Get("aggregation"))
+		assert.Equal(t, "ApiName eq '*'", r.URL.Query().Get("$filter"))
+		assert.Equal(t, "P1D", r.URL.Query().Get("interval"))
+
+		_, _ = w.Write([]byte(`{
+			"value": [{
+				"name": {"value": "Transactions"},
+				"timeseries": [
+					{"metadatavalues": [{"name": {"value": "apiname"}, "value": "GetBlob"}], "data": [{"total": 1000}, {"total": 500}]},
+					{"metadatavalues": [{"name": {"value": "apiname"}, "value": "PutBlob"}], "data": [{"total": 100}, {}]},
+					{"metadatavalues": [{"name": {"value": "apiname"}, "value": "ListBlobs"}], "data": [{"total": 20}]},
+					{"metadatavalues": [{"name": {"value": "apiname"}, "value": "DeleteBlob"}], "data": [{"total": 30}]},
+					{"metadatavalues": [{"name": {"value": "apiname"}, "value": "GetBlobProperties"}], "data": [{"total": 5}]}
+				]
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	ctx := WithTestEndpoint(context.TODO(), server.URL)
+
+	ops, err := StorageAccountGetOperations(ctx, storageAccountID)
+	require.NoError(t, err)
+	assert.Equal(t, StorageAccountOperations{
+		Read:                   1500,
+		Write:                  100,
+		ListAndCreateContainer: 20,
+		Other:                  5,
+	}, ops)
+}
+
+func TestMonitorGetMonthlyValueAverage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Average", r.URL.Query().Get("aggregation"))
+
+		_, _ = w.Write([]byte(`{
+			"value": [{
+				"timeseries": [
+					{"data": [{"average": 2}, {"average": 4}, {}]}
+				]
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	ctx := WithTestEndpoint(context.TODO(), server.URL)
+
+	units, err := ApplicationGatewayGetCapacityUnits(ctx, "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/applicationGateways/gw")
+	require.NoError(t, err)
+	assert.Equal(t, float64(3*730), units)
+}
+
+func TestMonitorGetMonthlyValueError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"code": "AuthorizationFailed", "message": "The client does not have authorization to perform action 'microsoft.insights/metrics/read'"}}`))
+	}))
+	defer server.Close()
+
+	ctx := WithTestEndpoint(context.TODO(), server.URL)
+
+	_, err := StorageAccountGetUsedCapacityBytes(ctx, storageAccountID)
+	assert.EqualError(t, err, "Azure Monitor API error: The client does not have authorization to perform action 'microsoft.insights/metrics/read'")
+}
+
+func TestMonitorGetMonthlyValueNoResourceID(t *testing.T) {
+	ctx := WithTestEndpoint(context.TODO(), "http://localhost")
+
+	_, err := FunctionAppGetExecutionCount(ctx, "")
+	assert.EqualError(t, err, "No Azure resource ID found, the resource might not be deployed yet")
+
+	_, err = CosmosDBGetRequestUnits(ctx, "")
+	assert.EqualError(t, err, "No Azure resource ID found, the resource might not be deployed yet")
+}
+
+func TestParseCosmosDBResourceID(t *testing.T) {
+	account := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.DocumentDB/databaseAccounts/acc"
+
+	tests := []struct {
+		id       string
+		expected cosmosDBResource
+	}{
+		{account + "/sqlDatabases/db", cosmosDBResource{accountID: account, database: "db"}},
+		{account + "/sqlDatabases/db/containers/c", cosmosDBResource{accountID: account, database: "db", collection: "c"}},
+		{account + "/mongodbDatabases/db/collections/c", cosmosDBResource{accountID: account, database: "db", collection: "c"}},
+		{account + "/tables/t", cosmosDBResource{accountID: account, database: "TablesDB", collection: "t"}},
+	}
+
+	for _, test := range tests {
+		r, err := parseCosmosDBResourceID(test.id)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, r, test.id)
+	}
+
+	_, err := parseCosmosDBResourceID("/subscriptions/sub/resourceGroups/rg")
+	assert.EqualError(t, err, "Invalid Cosmos DB resource ID /subscriptions/sub/resourceGroups/rg")
+}
@@ -0,0 +1,94 @@
+package azure
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Blob storage operations by the ApiName dimension of the Transactions metric,
+// see https://azure.microsoft.com/en-us/pricing/details/storage/blobs/
+var storageWriteAPINames = map[string]bool{
+	"PutBlob":            true,
+	"PutBlock":           true,
+	"PutBlockFromURL":    true,
+	"PutBlockList":       true,
+	"PutPage":            true,
+	"PutPageFromURL":     true,
+	"AppendBlock":        true,
+	"AppendBlockFromURL": true,
+	"CopyBlob":           true,
+	"CopyBlobFromURL":    true,
+	"SnapshotBlob":       true,
+	"SetBlobTier":        true,
+}
+
+var storageListAndCreateContainerAPINames = map[string]bool{
+	"CreateContainer": true,
+	"ListBlobs":       true,
+	"ListContainers":  true,
+}
+
+var storageReadAPINames = map[string]bool{
+	"GetBlob": true,
+}
+
+// Delete operations are free so aren't counted.
+var storageFreeAPINames = map[string]bool{
+	"DeleteBlob":      true,
+	"DeleteContainer": true,
+}
+
+// StorageAccountOperations are the monthly number of operations of a storage
+// account by the groups that they're priced by.
+type StorageAccountOperations struct {
+	Read                   int64
+	Write                  int64
+	ListAndCreateContainer int64
+	Other                  int64
+}
+
+func StorageAccountGetUsedCapacityBytes(ctx context.Context, resourceID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: UsedCapacity (resource: %s)", resourceID)
+	return monitorGetMonthlyValue(ctx, metricsRequest{
+		resourceID:  resourceID,
+		metric:      "UsedCapacity",
+		aggregation: aggregationAverage,
+	})
+}
+
+func StorageAccountGetOperations(ctx context.Context, resourceID string) (StorageAccountOperations, error) {
+	log.Debugf("Querying Azure Monitor: Transactions (resource: %s)", resourceID)
+	series, err := monitorGetMonthlyTimeSeries(ctx, metricsRequest{
+		resourceID:  resourceID,
+		metric:      "Transactions",
+		aggregation: aggregationTotal,
+		dimension:   "ApiName",
+	})
+	if err != nil {
+		return StorageAccountOperations{}, err
+	}
+
+	var read, write, listAndCreate, other float64
+	for _, s := range series {
+		apiName := s.dimensions["apiname"]
+		switch {
+		case storageReadAPINames[apiName]:
+			read += s.value
+		case storageWriteAPINames[apiName]:
+			write += s.value
+		case storageListAndCreateContainerAPINames[apiName]:
+			listAndCreate += s.value
+		case storageFreeAPINames[apiName]:
+		default:
+			other += s.value
+		}
+	}
+
+	return StorageAccountOperations{
+		Read:                   int64(read),
+		Write:                  int64(write),
+		ListAndCreateContainer: int64(listAndCreate),
+		Other:                  int64(other),
+	}, nil
+}
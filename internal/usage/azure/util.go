@@ -0,0 +1,8 @@
+package azure
+
+import "time"
+
+const timeMonth = time.Hour * 24 * 30
+
+// hoursMonth is the number of hours in a month used for pricing.
+const hoursMonth = 730
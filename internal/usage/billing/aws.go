@@ -0,0 +1,90 @@
+package billing
+
+import (
+	"regexp"
+	"strings"
+)
+
+const awsTagColumnPrefix = "resourcetags/user:"
+
+// awsUsageTypeRegexp matches a CUR usage type with or without the region
+// prefix, e.g. USE1-TimedStorage-ByteHrs.
+func awsUsageTypeRegexp(usageType string) *regexp.Regexp {
+	return regexp.MustCompile(`^([A-Z0-9]+-)?` + regexp.QuoteMeta(usageType) + `$`)
+}
+
+var awsUsageRules = []usageRule{
+	{resourceType: "aws_s3_bucket", service: "AmazonS3", usageType: awsUsageTypeRegexp("TimedStorage-ByteHrs"), key: "standard.storage_gb", component: regexp.MustCompile(`^Storage$`)},
+	{resourceType: "aws_s3_bucket", service: "AmazonS3", usageType: awsUsageTypeRegexp("Requests-Tier1"), key: "standard.monthly_tier_1_requests", integer: true, component: regexp.MustCompile(`^PUT, COPY, POST, LIST requests$`)},
+	{resourceType: "aws_s3_bucket", service: "AmazonS3", usageType: awsUsageTypeRegexp("Requests-Tier2"), key: "standard.monthly_tier_2_requests", integer: true, component: regexp.MustCompile(`^GET, SELECT, and all other requests$`)},
+	{resourceType: "aws_lambda_function", service: "AWSLambda", usageType: awsUsageTypeRegexp("Request"), key: "monthly_requests", integer: true, component: regexp.MustCompile(`^Requests$`)},
+	{resourceType: "aws_dynamodb_table", service: "AmazonDynamoDB", usageType: awsUsageTypeRegexp("ReadRequestUnits"), key: "monthly_read_request_units", integer: true, component: regexp.MustCompile(`^Read request unit`)},
+	{resourceType: "aws_dynamodb_table", service: "AmazonDynamoDB", usageType: awsUsageTypeRegexp("WriteRequestUnits"), key: "monthly_write_request_units", integer: true, component: regexp.MustCompile(`^Write request unit`)},
+	{resourceType: "aws_dynamodb_table", service: "AmazonDynamoDB", usageType: awsUsageTypeRegexp("TimedStorage-ByteHrs"), key: "storage_gb", component: regexp.MustCompile(`^Data storage$`)},
+	{resourceType: "aws_nat_gateway", service: "AmazonEC2", usageType: awsUsageTypeRegexp("NatGateway-Bytes"), key: "monthly_data_processed_gb", component: regexp.MustCompile(`^Data processed$`)},
+	{resourceType: "aws_cloudwatch_log_group", service: "AmazonCloudWatch", usageType: awsUsageTypeRegexp("DataProcessing-Bytes"), key: "monthly_data_ingested_gb", component: regexp.MustCompile(`^Data ingested$`)},
+	{resourceType: "aws_cloudwatch_log_group", service: "AmazonCloudWatch", usageType: awsUsageTypeRegexp("TimedStorage-ByteHrs"), key: "storage_gb", component: regexp.MustCompile(`^Archival Storage$`)},
+	{resourceType: "aws_sqs_queue", service: "AWSQueueService", usageType: awsUsageTypeRegexp("Requests-RBP"), key: "monthly_requests", integer: true, component: regexp.MustCompile(`^Requests$`)},
+	{resourceType: "aws_lambda_function", service: "AWSLambda", usageType: awsUsageTypeRegexp("Lambda-GB-Second"), component: regexp.MustCompile(`^Duration$`)},
+	{resourceType: "aws_nat_gateway", service: "AmazonEC2", usageType: awsUsageTypeRegexp("NatGateway-Hours"), component: regexp.MustCompile(`^NAT gateway$`)},
+	{resourceType: "aws_instance", service: "AmazonEC2", usageType: regexp.MustCompile(`^([A-Z0-9]+-)?(BoxUsage|DedicatedUsage|SpotUsage)`), component: regexp.MustCompile(`^Instance usage`)},
+	{resourceType: "aws_instance", service: "AmazonEC2", usageType: awsUsageTypeRegexp("EBS:VolumeUsage.gp2"), component: regexp.MustCompile(`^Storage \(general purpose SSD, gp2\)$`)},
+	{resourceType: "aws_instance", service: "AmazonEC2", usageType: awsUsageTypeRegexp("EBS:VolumeUsage.gp3"), component: regexp.MustCompile(`^Storage \(general purpose SSD, gp3\)$`)},
+	{resourceType: "aws_instance", service: "AmazonCloudWatch", usageType: awsUsageTypeRegexp("CW:MetricMonitorUsage"), component: regexp.MustCompile(`^EC2 detailed monitoring$`)},
+	{resourceType: "aws_spot_instance_request", service: "AmazonEC2", usageType: regexp.MustCompile(`^([A-Z0-9]+-)?SpotUsage`), component: regexp.MustCompile(`^Instance usage`)},
+}
+
+// awsUsageLineItemTypes are the CUR line item types that have usage. Others,
+// e.g. tax and credits, are ignored.
+var awsUsageLineItemTypes = map[string]bool{
+	"":                        true,
+	"Usage":                   true,
+	"DiscountedUsage":         true,
+	"SavingsPlanCoveredUsage": true,
+}
+
+// awsCostColumns are the columns with the cost of line items that are covered
+// by a reservation or Savings Plan, since their unblended cost doesn't include
+// the discount.
+var awsCostColumns = map[string]string{
+	"DiscountedUsage":         "reservation/effectivecost",
+	"SavingsPlanCoveredUsage": "savingsplan/savingsplaneffectivecost",
+}
+
+// parseAWSLineItem parses a line item from an AWS Cost and Usage Report.
+func parseAWSLineItem(r record) (*LineItem, error) {
+	if !awsUsageLineItemTypes[r.get("lineitem/lineitemtype")] {
+		return nil, nil
+	}
+
+	quantity, err := r.float("quantity", "lineitem/usageamount")
+	if err != nil {
+		return nil, err
+	}
+
+	cost, err := r.float("cost", awsCostColumns[r.get("lineitem/lineitemtype")], "lineitem/unblendedcost")
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for i, h := range r.header {
+		if !strings.HasPrefix(strings.ToLower(h), awsTagColumnPrefix) || i >= len(r.values) {
+			continue
+		}
+
+		if v := strings.TrimSpace(r.values[i]); v != "" {
+			tags[h[len(awsTagColumnPrefix):]] = v
+		}
+	}
+
+	return &LineItem{
+		ResourceID: r.get("lineitem/resourceid"),
+		Tags:       tags,
+		Service:    r.get("lineitem/productcode"),
+		UsageType:  r.get("lineitem/usagetype"),
+		Quantity:   quantity,
+		Cost:       cost,
+		Period:     period(r.get("lineitem/usagestartdate", "bill/billingperiodstartdate")),
+	}, nil
+}
@@ -0,0 +1,65 @@
+package billing
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var azureUsageRules = []usageRule{
+	{resourceType: "azurerm_storage_account", service: "Storage", usageType: regexp.MustCompile(`Data Stored$`), key: "storage_gb", component: regexp.MustCompile(`^(Capacity|Data at rest)$`)},
+	{resourceType: "azurerm_storage_account", service: "Storage", usageType: regexp.MustCompile(`Iterative Write Operations$`), key: "monthly_iterative_write_operations", integer: true, component: regexp.MustCompile(`^Iterative write operations$`)},
+	{resourceType: "azurerm_storage_account", service: "Storage", usageType: regexp.MustCompile(`Iterative Read Operations$`), key: "monthly_iterative_read_operations", integer: true, component: regexp.MustCompile(`^Iterative read operations$`)},
+	{resourceType: "azurerm_storage_account", service: "Storage", usageType: regexp.MustCompile(`List and Create Container Operations$`), key: "monthly_list_and_create_container_operations", integer: true, component: regexp.MustCompile(`^List and create container operations$`)},
+	{resourceType: "azurerm_storage_account", service: "Storage", usageType: regexp.MustCompile(`Write Operations$`), key: "monthly_write_operations", integer: true, component: regexp.MustCompile(`^Write operations$`)},
+	{resourceType: "azurerm_storage_account", service: "Storage", usageType: regexp.MustCompile(`Read Operations$`), key: "monthly_read_operations", integer: true, component: regexp.MustCompile(`^Read operations$`)},
+	{resourceType: "azurerm_storage_account", service: "Storage", usageType: regexp.MustCompile(`All Other Operations$`), key: "monthly_other_operations", integer: true, component: regexp.MustCompile(`^All other operations$`)},
+	{resourceType: "azurerm_function_app", service: "Functions", usageType: regexp.MustCompile(`Total Executions$`), key: "monthly_executions", integer: true, component: regexp.MustCompile(`^Executions$`)},
+	{resourceType: "azurerm_application_gateway", service: "Application Gateway", usageType: regexp.MustCompile(`Data Processed$`), key: "monthly_data_processed_gb", component: regexp.MustCompile(`^Data processing`)},
+	{resourceType: "azurerm_application_gateway", service: "Application Gateway", usageType: regexp.MustCompile(`Capacity Units$`), key: "monthly_v2_capacity_units", integer: true, component: regexp.MustCompile(`^V2 capacity units`)},
+}
+
+// parseAzureLineItem parses a line item from an Azure cost export. The
+// quantities are in the unit of measure of the meter, e.g. 10K operations, so
+// they're converted to the base unit.
+func parseAzureLineItem(r record) (*LineItem, error) {
+	quantity, err := r.float("quantity", "quantity", "usagequantity", "consumedquantity")
+	if err != nil {
+		return nil, err
+	}
+
+	cost, err := r.float("cost", "costinbillingcurrency", "cost", "pretaxcost")
+	if err != nil {
+		return nil, err
+	}
+
+	return &LineItem{
+		ResourceID: r.get("resourceid", "instanceid", "instancename"),
+		Tags:       parseAzureTags(r.get("tags")),
+		Service:    r.get("metercategory"),
+		UsageType:  r.get("metername"),
+		Quantity:   quantity * unitMultiplier(r.get("unitofmeasure")),
+		Cost:       cost,
+		Period:     period(r.get("date", "usagedatetime", "usagedate")),
+	}, nil
+}
+
+// parseAzureTags parses the tags column of an Azure cost export. Depending on
+// the export version these are a JSON object or its contents without braces.
+func parseAzureTags(s string) map[string]string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return map[string]string{}
+	}
+
+	if !strings.HasPrefix(s, "{") {
+		s = "{" + s + "}"
+	}
+
+	tags := make(map[string]string)
+	if err := json.Unmarshal([]byte(s), &tags); err != nil {
+		return map[string]string{}
+	}
+
+	return tags
+}
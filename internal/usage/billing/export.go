@@ -0,0 +1,281 @@
+package billing
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// LineItem is a usage line item from a cloud billing export.
+type LineItem struct {
+	// ResourceID is the ID of the resource the usage is for, e.g. the ARN or
+	// ID for AWS, the resource ID for Azure or the resource name for Google.
+	ResourceID string
+	Tags       map[string]string
+	// Service is the service the usage is billed under, e.g. the product code
+	// for AWS, the meter category for Azure or the service description for
+	// Google.
+	Service string
+	// UsageType is what was used, e.g. the usage type for AWS, the meter name
+	// for Azure or the SKU description for Google.
+	UsageType string
+	// Quantity is the amount used in the pricing unit, e.g. GB-months.
+	Quantity float64
+	// Cost is the cost of the usage before any credits and taxes.
+	Cost float64
+	// Period is the month the usage is for, e.g. 2021-10.
+	Period string
+}
+
+// Export is a cloud billing export with the line items that have usage.
+type Export struct {
+	Provider  string
+	LineItems []*LineItem
+
+	rules []usageRule
+}
+
+type exportFormat struct {
+	provider string
+	// detectColumn is a column that's only in this format of export.
+	detectColumn string
+	rules        []usageRule
+	parse        func(r record) (*LineItem, error)
+}
+
+var exportFormats = []exportFormat{
+	{"aws", "lineitem/resourceid", awsUsageRules, parseAWSLineItem},
+	{"azure", "metercategory", azureUsageRules, parseAzureLineItem},
+	{"google", "sku_description", googleUsageRules, parseGoogleLineItem},
+}
+
+// LoadExport loads a billing export from a CSV file, which can be gzipped, or
+// from an AWS Cost and Usage Report Parquet file.
+func LoadExport(path string) (*Export, error) {
+	if strings.EqualFold(filepath.Ext(path), ".parquet") {
+		return LoadParquetExport(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error opening billing export")
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.EqualFold(filepath.Ext(path), ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading gzipped billing export")
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	return ReadExport(r)
+}
+
+// ReadExport reads a CSV billing export. The format is detected from the
+// header row and can be an AWS Cost and Usage Report, an Azure cost export or
+// a Google Cloud billing export.
+func ReadExport(r io.Reader) (*Export, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading billing export header")
+	}
+
+	columns := columnIndexes(header)
+
+	format := detectFormat(columns)
+	if format == nil {
+		return nil, errors.New("Unknown billing export format, expected an AWS Cost and Usage Report, Azure cost export or Google Cloud billing export CSV file")
+	}
+
+	export := newExport(format)
+
+	for line := 2; ; line++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "Error reading billing export")
+		}
+
+		err = export.addRecord(format, record{header: header, columns: columns, values: values})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error parsing billing export line %d", line)
+		}
+	}
+
+	return export, nil
+}
+
+func columnIndexes(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[normalizeColumn(h)] = i
+	}
+
+	return columns
+}
+
+// detectFormat returns the format of the export with the columns, or nil if
+// it's unknown.
+func detectFormat(columns map[string]int) *exportFormat {
+	for i := range exportFormats {
+		if _, ok := columns[exportFormats[i].detectColumn]; ok {
+			return &exportFormats[i]
+		}
+	}
+
+	return nil
+}
+
+func newExport(format *exportFormat) *Export {
+	return &Export{
+		Provider:  format.provider,
+		LineItems: make([]*LineItem, 0),
+		rules:     format.rules,
+	}
+}
+
+// addRecord parses the record and adds it to the line items if it has usage
+// that can be matched to a resource.
+func (e *Export) addRecord(format *exportFormat, r record) error {
+	item, err := format.parse(r)
+	if err != nil {
+		return err
+	}
+
+	if item != nil && (item.ResourceID != "" || len(item.Tags) > 0) {
+		e.LineItems = append(e.LineItems, item)
+	}
+
+	return nil
+}
+
+// Months returns the number of months the export has usage for, so the
+// quantities and costs can be averaged to monthly values.
+func (e *Export) Months() int {
+	periods := make(map[string]bool)
+	for _, item := range e.LineItems {
+		if item.Period != "" {
+			periods[item.Period] = true
+		}
+	}
+
+	if len(periods) == 0 {
+		return 1
+	}
+
+	return len(periods)
+}
+
+// normalizeColumn normalizes the column names so the Google columns match
+// whether they're from a CSV export, e.g. sku.description, or a query, e.g.
+// sku_description.
+func normalizeColumn(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), ".", "_")
+}
+
+type record struct {
+	header  []string
+	columns map[string]int
+	values  []string
+}
+
+// get returns the first non-empty value of the columns.
+func (r record) get(columns ...string) string {
+	for _, c := range columns {
+		if i, ok := r.columns[c]; ok && i < len(r.values) {
+			if v := strings.TrimSpace(r.values[i]); v != "" {
+				return v
+			}
+		}
+	}
+
+	return ""
+}
+
+// float returns the first non-empty value of the columns as a float. The name
+// is used in the error if the value isn't a number.
+func (r record) float(name string, columns ...string) (float64, error) {
+	v := r.get(columns...)
+	if v == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, v)
+	}
+
+	return f, nil
+}
+
+var periodLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+	"2006-01",
+	"200601",
+}
+
+// period returns the month of a date in any of the formats used by the
+// billing exports.
+func period(date string) string {
+	for _, layout := range periodLayouts {
+		if len(date) < len(layout) {
+			continue
+		}
+
+		t, err := time.Parse(layout, date[:len(layout)])
+		if err == nil {
+			return t.Format("2006-01")
+		}
+	}
+
+	return ""
+}
+
+// unitMultiplier returns the multiplier for a unit of measure like 10K or
+// 1 GB/Month, so quantities can be converted to a count of the base unit.
+func unitMultiplier(unit string) float64 {
+	unit = strings.TrimSpace(unit)
+
+	end := 0
+	for end < len(unit) && (unit[end] >= '0' && unit[end] <= '9' || unit[end] == '.') {
+		end++
+	}
+
+	if end == 0 {
+		return 1
+	}
+
+	m, err := strconv.ParseFloat(unit[:end], 64)
+	if err != nil {
+		return 1
+	}
+
+	rest := strings.ToLower(strings.TrimSpace(unit[end:]))
+	switch {
+	case strings.HasPrefix(rest, "k"), strings.HasPrefix(rest, "thousand"):
+		m *= 1000
+	case strings.HasPrefix(rest, "m ") || rest == "m", strings.HasPrefix(rest, "million"):
+		m *= 1000000
+	}
+
+	return m
+}
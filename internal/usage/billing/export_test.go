@@ -0,0 +1,203 @@
+package billing
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+func TestReadExportAWS(t *testing.T) {
+	export, err := ReadExport(strings.NewReader(`identity/LineItemId,lineItem/LineItemType,lineItem/UsageStartDate,lineItem/ProductCode,lineItem/UsageType,lineItem/ResourceId,lineItem/UsageAmount,resourceTags/user:Name,resourceTags/user:team
+1,Usage,2021-10-01T00:00:00Z,AWSLambda,USE1-Request,arn:aws:lambda:us-east-1:123456789012:function:my-function,1000,,
+2,Tax,2021-10-01T00:00:00Z,AWSLambda,,,0,,
+3,DiscountedUsage,2021-10-02T00:00:00Z,AmazonS3,TimedStorage-ByteHrs,my-bucket,12.5,logs,platform
+4,Usage,2021-10-02T00:00:00Z,AmazonEC2,USE1-DataTransfer-Out-Bytes,,3,,
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "aws", export.Provider)
+	assert.Equal(t, []*LineItem{
+		{
+			ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function",
+			Tags:       map[string]string{},
+			Service:    "AWSLambda",
+			UsageType:  "USE1-Request",
+			Quantity:   1000,
+			Period:     "2021-10",
+		},
+		{
+			ResourceID: "my-bucket",
+			Tags:       map[string]string{"Name": "logs", "team": "platform"},
+			Service:    "AmazonS3",
+			UsageType:  "TimedStorage-ByteHrs",
+			Quantity:   12.5,
+			Period:     "2021-10",
+		},
+	}, export.LineItems)
+}
+
+func TestReadExportAzure(t *testing.T) {
+	export, err := ReadExport(strings.NewReader(`Date,MeterCategory,MeterSubCategory,MeterName,Quantity,UnitOfMeasure,ResourceId,Tags
+10/01/2021,Storage,General Block Blob v2,Hot LRS Write Operations,2.5,10K,/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account,"""env"": ""prod"",""team"": ""platform"""
+10/01/2021,Storage,General Block Blob v2,Hot LRS Data Stored,100,1 GB/Month,/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account,
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "azure", export.Provider)
+	require.Len(t, export.LineItems, 2)
+	assert.Equal(t, &LineItem{
+		ResourceID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account",
+		Tags:       map[string]string{"env": "prod", "team": "platform"},
+		Service:    "Storage",
+		UsageType:  "Hot LRS Write Operations",
+		Quantity:   25000,
+		Period:     "2021-10",
+	}, export.LineItems[0])
+	assert.Equal(t, float64(100), export.LineItems[1].Quantity)
+}
+
+func TestReadExportGoogle(t *testing.T) {
+	export, err := ReadExport(strings.NewReader(`service.description,sku.description,usage.start_time,usage.amount_in_pricing_units,usage.pricing_unit,resource.name,resource.global_name,labels
+Cloud Storage,Standard Storage US Multi-region,2021-10-01 00:00:00 UTC,150,gibibyte month,my-bucket,//storage.googleapis.com/projects/_/buckets/my-bucket,"[{""key"":""team"",""value"":""platform""}]"
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "google", export.Provider)
+	assert.Equal(t, []*LineItem{
+		{
+			ResourceID: "//storage.googleapis.com/projects/_/buckets/my-bucket",
+			Tags:       map[string]string{"team": "platform"},
+			Service:    "Cloud Storage",
+			UsageType:  "Standard Storage US Multi-region",
+			Quantity:   150,
+			Period:     "2021-10",
+		},
+	}, export.LineItems)
+}
+
+func TestReadExportUnknownFormat(t *testing.T) {
+	_, err := ReadExport(strings.NewReader("a,b,c\n1,2,3\n"))
+	assert.EqualError(t, err, "Unknown billing export format, expected an AWS Cost and Usage Report, Azure cost export or Google Cloud billing export CSV file")
+}
+
+func TestReadExportInvalidQuantity(t *testing.T) {
+	_, err := ReadExport(strings.NewReader("lineItem/ResourceId,lineItem/UsageAmount\nmy-bucket,lots\n"))
+	assert.EqualError(t, err, `Error parsing billing export line 2: invalid quantity "lots"`)
+}
+
+type testCURParquetRow struct {
+	LineItemType   string  `parquet:"name=line_item_line_item_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	UsageStartDate int64   `parquet:"name=line_item_usage_start_date, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	ProductCode    string  `parquet:"name=line_item_product_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	UsageType      string  `parquet:"name=line_item_usage_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceID     string  `parquet:"name=line_item_resource_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	UsageAmount    float64 `parquet:"name=line_item_usage_amount, type=DOUBLE"`
+	UnblendedCost  float64 `parquet:"name=line_item_unblended_cost, type=DOUBLE"`
+	EffectiveCost  float64 `parquet:"name=reservation_effective_cost, type=DOUBLE"`
+	Team           *string `parquet:"name=resource_tags_user_team, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+func writeTestCURParquet(t *testing.T, path string, rows []testCURParquetRow) {
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+
+	pw, err := writer.NewParquetWriter(fw, new(testCURParquetRow), 1)
+	require.NoError(t, err)
+
+	for _, row := range rows {
+		require.NoError(t, pw.Write(row))
+	}
+
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+}
+
+func TestLoadExportParquet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cur.parquet")
+	team := "platform"
+	start := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+
+	writeTestCURParquet(t, path, []testCURParquetRow{
+		{LineItemType: "Usage", UsageStartDate: start, ProductCode: "AWSLambda", UsageType: "USE1-Request", ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function", UsageAmount: 1000, UnblendedCost: 0.2},
+		{LineItemType: "Tax", UsageStartDate: start, ProductCode: "AWSLambda"},
+		{LineItemType: "DiscountedUsage", UsageStartDate: start, ProductCode: "AmazonS3", UsageType: "TimedStorage-ByteHrs", ResourceID: "my-bucket", UsageAmount: 12.5, EffectiveCost: 0.3, Team: &team},
+	})
+
+	export, err := LoadExport(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "aws", export.Provider)
+	assert.Equal(t, []*LineItem{
+		{
+			ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function",
+			Tags:       map[string]string{},
+			Service:    "AWSLambda",
+			UsageType:  "USE1-Request",
+			Quantity:   1000,
+			Cost:       0.2,
+			Period:     "2021-10",
+		},
+		{
+			ResourceID: "my-bucket",
+			Tags:       map[string]string{"team": "platform"},
+			Service:    "AmazonS3",
+			UsageType:  "TimedStorage-ByteHrs",
+			Quantity:   12.5,
+			Cost:       0.3,
+			Period:     "2021-10",
+		},
+	}, export.LineItems)
+}
+
+func TestLoadExportParquetUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.parquet")
+
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+
+	pw, err := writer.NewParquetWriter(fw, new(struct {
+		Name string `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	}), 1)
+	require.NoError(t, err)
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	_, err = LoadExport(path)
+	assert.EqualError(t, err, "Unknown Parquet billing export format, expected an AWS Cost and Usage Report Parquet file")
+}
+
+func TestUnitMultiplier(t *testing.T) {
+	tests := map[string]float64{
+		"":                   1,
+		"10K":                10000,
+		"1M":                 1000000,
+		"1 GB/Month":         1,
+		"100 Hours":          100,
+		"gibibyte month":     1,
+		"10 thousand counts": 10000,
+	}
+
+	for unit, expected := range tests {
+		assert.Equal(t, expected, unitMultiplier(unit), unit)
+	}
+}
+
+func TestReadExportAWSCost(t *testing.T) {
+	export, err := ReadExport(strings.NewReader(`lineItem/LineItemType,lineItem/ProductCode,lineItem/UsageType,lineItem/ResourceId,lineItem/UsageAmount,lineItem/UnblendedCost,reservation/EffectiveCost,savingsPlan/SavingsPlanEffectiveCost
+Usage,AmazonEC2,USE1-BoxUsage:t3.micro,i-0123,24,0.25,,
+DiscountedUsage,AmazonEC2,USE1-BoxUsage:t3.micro,i-0456,24,0,0.15,
+SavingsPlanCoveredUsage,AmazonEC2,USE1-BoxUsage:t3.micro,i-0789,24,0.25,,0.18
+`))
+	require.NoError(t, err)
+
+	require.Len(t, export.LineItems, 3)
+	assert.Equal(t, 0.25, export.LineItems[0].Cost)
+	assert.Equal(t, 0.15, export.LineItems[1].Cost)
+	assert.Equal(t, 0.18, export.LineItems[2].Cost)
+}
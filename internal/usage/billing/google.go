@@ -0,0 +1,66 @@
+package billing
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var googleUsageRules = []usageRule{
+	{resourceType: "google_storage_bucket", service: "Cloud Storage", usageType: regexp.MustCompile(`Class A Operations`), key: "monthly_class_a_operations", integer: true, component: regexp.MustCompile(`\(class A\)$`)},
+	{resourceType: "google_storage_bucket", service: "Cloud Storage", usageType: regexp.MustCompile(`Class B Operations`), key: "monthly_class_b_operations", integer: true, component: regexp.MustCompile(`\(class B\)$`)},
+	{resourceType: "google_storage_bucket", service: "Cloud Storage", usageType: regexp.MustCompile(`Data Retrieval`), key: "monthly_data_retrieval_gb", component: regexp.MustCompile(`^Data retrieval$`)},
+	{resourceType: "google_storage_bucket", service: "Cloud Storage", usageType: regexp.MustCompile(`Storage`), key: "storage_gb", component: regexp.MustCompile(`^Storage `)},
+	{resourceType: "google_cloudfunctions_function", service: "Cloud Functions", usageType: regexp.MustCompile(`Invocations`), key: "monthly_function_invocations", integer: true, component: regexp.MustCompile(`^Invocations$`)},
+	{resourceType: "google_pubsub_topic", service: "Cloud Pub/Sub", usageType: regexp.MustCompile(`Message Delivery`), key: "monthly_message_data_tb", component: regexp.MustCompile(`^Message ingestion data$`)},
+}
+
+// parseGoogleLineItem parses a line item from a Google Cloud billing export
+// that's been exported from BigQuery to CSV. The nested columns can either
+// be flattened with dots, e.g. sku.description, or underscores.
+func parseGoogleLineItem(r record) (*LineItem, error) {
+	quantity, err := r.float("quantity", "usage_amount_in_pricing_units")
+	if err != nil {
+		return nil, err
+	}
+
+	cost, err := r.float("cost", "cost")
+	if err != nil {
+		return nil, err
+	}
+
+	return &LineItem{
+		ResourceID: r.get("resource_global_name", "resource_name"),
+		Tags:       parseGoogleLabels(r.get("labels")),
+		Service:    r.get("service_description"),
+		UsageType:  r.get("sku_description"),
+		Quantity:   quantity * unitMultiplier(r.get("usage_pricing_unit")),
+		Cost:       cost,
+		Period:     period(r.get("usage_start_time", "invoice_month")),
+	}, nil
+}
+
+// parseGoogleLabels parses the labels column, which is a JSON array of key
+// and value objects in the BigQuery export.
+func parseGoogleLabels(s string) map[string]string {
+	labels := make(map[string]string)
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return labels
+	}
+
+	var kvs []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(s), &kvs); err != nil {
+		return labels
+	}
+
+	for _, kv := range kvs {
+		labels[kv.Key] = kv.Value
+	}
+
+	return labels
+}
@@ -0,0 +1,169 @@
+package billing
+
+import (
+	"math"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Match matches the line items to the resources and returns the monthly
+// usage values of each matched resource by its name.
+//
+// Line items are matched by the resource IDs from the state first. The
+// exports often use a different form of ID to the state, e.g. the ARN instead
+// of the name, so the last part of the line item ID is also compared. Line
+// items that don't match an ID are matched to resources by their tags, as long
+// as the tags only match a single resource. Billing exports usually only have
+// some of the tags, so the tags match if the tags that both have are equal.
+func (e *Export) Match(resources []*schema.Resource) map[string]map[string]interface{} {
+	matched := e.matchLineItems(resources, func(r *schema.Resource, item *LineItem) bool {
+		rule := e.findRule(r.ResourceType, item)
+		return rule != nil && rule.key != ""
+	})
+
+	months := float64(e.Months())
+
+	usage := make(map[string]map[string]interface{}, len(matched))
+	for r, items := range matched {
+		totals := make(map[string]float64)
+		rules := make(map[string]*usageRule)
+
+		for _, item := range items {
+			rule := e.findRule(r.ResourceType, item)
+			totals[rule.key] += item.Quantity
+			rules[rule.key] = rule
+		}
+
+		values := make(map[string]interface{})
+		for key, total := range totals {
+			setValue(values, key, monthlyValue(total/months, rules[key].integer))
+		}
+		usage[r.Name] = values
+	}
+
+	return usage
+}
+
+// MatchLineItems matches the line items to the resources in the same way as
+// Match and returns all the line items of each matched resource by its name,
+// including the line items that don't have usage for the resource.
+func (e *Export) MatchLineItems(resources []*schema.Resource) map[string][]*LineItem {
+	matched := e.matchLineItems(resources, func(r *schema.Resource, item *LineItem) bool {
+		return true
+	})
+
+	items := make(map[string][]*LineItem, len(matched))
+	for r, rItems := range matched {
+		items[r.Name] = rItems
+	}
+
+	return items
+}
+
+// matchLineItems matches the line items to the resources. Only the line items
+// that are accepted for a resource are matched to it.
+func (e *Export) matchLineItems(resources []*schema.Resource, accept func(r *schema.Resource, item *LineItem) bool) map[*schema.Resource][]*LineItem {
+	itemsByID := make(map[string][]*LineItem)
+	for _, item := range e.LineItems {
+		if item.ResourceID == "" {
+			continue
+		}
+
+		id := strings.ToLower(item.ResourceID)
+		itemsByID[id] = append(itemsByID[id], item)
+
+		if tail := idTail(id); tail != id {
+			itemsByID[tail] = append(itemsByID[tail], item)
+		}
+	}
+
+	matched := make(map[*schema.Resource][]*LineItem)
+	idMatched := make(map[*LineItem]bool)
+
+	for _, r := range resources {
+		seen := make(map[*LineItem]bool)
+		for _, id := range r.CloudResourceIDs {
+			for _, item := range itemsByID[strings.ToLower(id)] {
+				if seen[item] || !accept(r, item) {
+					continue
+				}
+				seen[item] = true
+				idMatched[item] = true
+				matched[r] = append(matched[r], item)
+			}
+		}
+	}
+
+	tagMatches := make(map[*LineItem][]*schema.Resource)
+	for _, r := range resources {
+		if len(matched[r]) > 0 || len(r.Tags) == 0 {
+			continue
+		}
+
+		for _, item := range e.LineItems {
+			if idMatched[item] || !tagsMatch(item.Tags, r.Tags) || !accept(r, item) {
+				continue
+			}
+			tagMatches[item] = append(tagMatches[item], r)
+		}
+	}
+
+	for _, item := range e.LineItems {
+		if rs := tagMatches[item]; len(rs) == 1 {
+			matched[rs[0]] = append(matched[rs[0]], item)
+		}
+	}
+
+	return matched
+}
+
+// idTail returns the last part of an ID or ARN, e.g. the function name of a
+// Lambda function ARN.
+func idTail(id string) string {
+	i := strings.LastIndexAny(id, "/:")
+	if i == -1 || i == len(id)-1 {
+		return id
+	}
+
+	return id[i+1:]
+}
+
+func tagsMatch(itemTags map[string]string, tags map[string]string) bool {
+	shared := 0
+	for k, v := range tags {
+		itemValue, ok := itemTags[k]
+		if !ok {
+			continue
+		}
+		if itemValue != v {
+			return false
+		}
+		shared++
+	}
+
+	return shared > 0
+}
+
+func monthlyValue(v float64, integer bool) interface{} {
+	if integer {
+		return int64(math.Round(v))
+	}
+
+	return math.Round(v*100) / 100
+}
+
+// setValue sets the value of a usage key, creating the maps for nested keys.
+func setValue(values map[string]interface{}, key string, v interface{}) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		m, ok := values[part].(map[string]interface{})
+		if !ok {
+			m = make(map[string]interface{})
+			values[part] = m
+		}
+		values = m
+	}
+
+	values[parts[len(parts)-1]] = v
+}
@@ -0,0 +1,87 @@
+package billing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestExportMatch(t *testing.T) {
+	export := &Export{
+		Provider: "aws",
+		rules:    awsUsageRules,
+		LineItems: []*LineItem{
+			{ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function", Service: "AWSLambda", UsageType: "USE1-Request", Quantity: 1000, Period: "2021-09"},
+			{ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function", Service: "AWSLambda", UsageType: "USE1-Request", Quantity: 3000, Period: "2021-10"},
+			{ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function", Service: "AWSLambda", UsageType: "USE1-Lambda-GB-Second", Quantity: 5, Period: "2021-10"},
+			{ResourceID: "arn:aws:ec2:us-east-1:123456789012:natgateway/nat-0123", Service: "AmazonEC2", UsageType: "USE1-NatGateway-Bytes", Quantity: 20.555, Period: "2021-10"},
+			{ResourceID: "", Tags: map[string]string{"Name": "assets"}, Service: "AmazonS3", UsageType: "TimedStorage-ByteHrs", Quantity: 50, Period: "2021-10"},
+			{ResourceID: "", Tags: map[string]string{"env": "prod"}, Service: "AmazonS3", UsageType: "TimedStorage-ByteHrs", Quantity: 70, Period: "2021-10"},
+		},
+	}
+
+	resources := []*schema.Resource{
+		{Name: "aws_lambda_function.fn", ResourceType: "aws_lambda_function", CloudResourceIDs: []string{"my-function"}},
+		{Name: "aws_nat_gateway.nat", ResourceType: "aws_nat_gateway", CloudResourceIDs: []string{"nat-0123"}},
+		{Name: "aws_s3_bucket.assets", ResourceType: "aws_s3_bucket", Tags: map[string]string{"Name": "assets", "env": "prod"}},
+		{Name: "aws_s3_bucket.logs", ResourceType: "aws_s3_bucket", Tags: map[string]string{"Name": "logs", "env": "prod"}},
+		{Name: "aws_instance.web", ResourceType: "aws_instance", CloudResourceIDs: []string{"i-0123"}},
+	}
+
+	usage := export.Match(resources)
+
+	assert.Equal(t, map[string]map[string]interface{}{
+		// The requests are averaged over the two months in the export
+		"aws_lambda_function.fn": {"monthly_requests": int64(2000)},
+		"aws_nat_gateway.nat":    {"monthly_data_processed_gb": float64(10.28)},
+		// The env tag matches both buckets so the line item isn't used
+		"aws_s3_bucket.assets": {"standard": map[string]interface{}{"storage_gb": float64(25)}},
+	}, usage)
+}
+
+func TestFindRuleOrder(t *testing.T) {
+	export := &Export{rules: azureUsageRules}
+
+	rule := export.findRule("azurerm_storage_account", &LineItem{Service: "Storage", UsageType: "Hot LRS Iterative Write Operations"})
+	assert.Equal(t, "monthly_iterative_write_operations", rule.key)
+
+	rule = export.findRule("azurerm_storage_account", &LineItem{Service: "Storage", UsageType: "Hot LRS Write Operations"})
+	assert.Equal(t, "monthly_write_operations", rule.key)
+
+	assert.Nil(t, export.findRule("azurerm_storage_account", &LineItem{Service: "Bandwidth", UsageType: "Data Transfer Out"}))
+}
+
+func TestIDTail(t *testing.T) {
+	assert.Equal(t, "my-function", idTail("arn:aws:lambda:us-east-1:123456789012:function:my-function"))
+	assert.Equal(t, "my-bucket", idTail("//storage.googleapis.com/projects/_/buckets/my-bucket"))
+	assert.Equal(t, "my-bucket", idTail("my-bucket"))
+	assert.Equal(t, "nat-0123", idTail("arn:aws:ec2:us-east-1:123456789012:natgateway/nat-0123"))
+}
+
+func TestExportMatchLineItems(t *testing.T) {
+	export := &Export{
+		Provider: "aws",
+		rules:    awsUsageRules,
+		LineItems: []*LineItem{
+			{ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function", Service: "AWSLambda", UsageType: "USE1-Request", Cost: 2},
+			{ResourceID: "arn:aws:lambda:us-east-1:123456789012:function:my-function", Service: "AWSLambda", UsageType: "USE1-Lambda-GB-Second", Cost: 5},
+			{ResourceID: "i-0123", Service: "AmazonEC2", UsageType: "USE1-BoxUsage:t3.micro", Cost: 7},
+		},
+	}
+
+	resources := []*schema.Resource{
+		{Name: "aws_lambda_function.fn", ResourceType: "aws_lambda_function", CloudResourceIDs: []string{"my-function"}},
+		{Name: "aws_instance.web", ResourceType: "aws_instance", CloudResourceIDs: []string{"i-0123"}},
+	}
+
+	items := export.MatchLineItems(resources)
+	assert.Len(t, items["aws_lambda_function.fn"], 2)
+	assert.Len(t, items["aws_instance.web"], 1)
+
+	// Line items without usage keys are still matched to cost components
+	assert.True(t, export.MatchesComponent("aws_lambda_function", export.LineItems[1], "Duration"))
+	assert.False(t, export.MatchesComponent("aws_lambda_function", export.LineItems[1], "Requests"))
+	assert.True(t, export.MatchesComponent("aws_instance", export.LineItems[2], "Instance usage (Linux/UNIX, on-demand, t3.micro)"))
+}
@@ -0,0 +1,198 @@
+package billing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/types"
+)
+
+// awsParquetColumns maps the columns of a Cost and Usage Report in Parquet
+// format to the columns of the CSV format, since the Parquet columns are
+// snake case, e.g. line_item_resource_id rather than lineItem/ResourceId.
+var awsParquetColumns = map[string]string{
+	"bill_billing_period_start_date":           "bill/BillingPeriodStartDate",
+	"line_item_line_item_type":                 "lineItem/LineItemType",
+	"line_item_product_code":                   "lineItem/ProductCode",
+	"line_item_resource_id":                    "lineItem/ResourceId",
+	"line_item_unblended_cost":                 "lineItem/UnblendedCost",
+	"line_item_usage_amount":                   "lineItem/UsageAmount",
+	"line_item_usage_start_date":               "lineItem/UsageStartDate",
+	"line_item_usage_type":                     "lineItem/UsageType",
+	"reservation_effective_cost":               "reservation/EffectiveCost",
+	"savings_plan_savings_plan_effective_cost": "savingsPlan/SavingsPlanEffectiveCost",
+}
+
+// awsParquetTagColumnPrefix is the prefix of the user tag columns. The tag
+// keys are lower snake case in the Parquet format, e.g. resource_tags_user_name
+// for the Name tag.
+const awsParquetTagColumnPrefix = "resource_tags_user_"
+
+// parquetBatchSize is the number of rows read from each column at a time.
+const parquetBatchSize = 10000
+
+type parquetColumn struct {
+	path    string
+	element *parquet.SchemaElement
+}
+
+// LoadParquetExport loads an AWS Cost and Usage Report in Parquet format. The
+// columns are mapped to the CSV columns so the line items are parsed the same
+// way as CSV reports.
+func LoadParquetExport(path string) (*Export, error) {
+	f, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error opening billing export")
+	}
+	defer f.Close()
+
+	pr, err := reader.NewParquetColumnReader(f, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading Parquet billing export")
+	}
+	defer pr.ReadStop()
+
+	header, parquetColumns := awsParquetHeader(pr.SchemaHandler)
+	columns := columnIndexes(header)
+
+	format := detectFormat(columns)
+	if format == nil {
+		return nil, errors.New("Unknown Parquet billing export format, expected an AWS Cost and Usage Report Parquet file")
+	}
+
+	export := newExport(format)
+
+	numRows := pr.GetNumRows()
+	for start := int64(0); start < numRows; start += parquetBatchSize {
+		n := numRows - start
+		if n > parquetBatchSize {
+			n = parquetBatchSize
+		}
+
+		rows := make([][]string, n)
+		for i := range rows {
+			rows[i] = make([]string, len(parquetColumns))
+		}
+
+		for i, c := range parquetColumns {
+			values, _, _, err := pr.ReadColumnByPath(c.path, n)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error reading billing export column %s", header[i])
+			}
+
+			for j, v := range values {
+				if int64(j) < n {
+					rows[j][i] = parquetValue(v, c.element)
+				}
+			}
+		}
+
+		for j, values := range rows {
+			err = export.addRecord(format, record{header: header, columns: columns, values: values})
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error parsing billing export row %d", start+int64(j)+1)
+			}
+		}
+	}
+
+	return export, nil
+}
+
+// awsParquetHeader returns the CSV names of the Cost and Usage Report columns
+// in the Parquet schema, and the Parquet columns to read for them. Other
+// columns aren't read.
+func awsParquetHeader(sh *schema.SchemaHandler) ([]string, []parquetColumn) {
+	header := make([]string, 0)
+	columns := make([]parquetColumn, 0)
+
+	for _, inPath := range sh.ValueColumns {
+		// Only top-level columns are in the Cost and Usage Report
+		parts := strings.Split(sh.InPathToExPath[inPath], common.PAR_GO_PATH_DELIMITER)
+		if len(parts) != 2 {
+			continue
+		}
+
+		name := strings.ToLower(parts[1])
+
+		var csvName string
+		if c, ok := awsParquetColumns[name]; ok {
+			csvName = c
+		} else if strings.HasPrefix(name, awsParquetTagColumnPrefix) {
+			csvName = "resourceTags/user:" + strings.TrimPrefix(name, awsParquetTagColumnPrefix)
+		} else {
+			continue
+		}
+
+		index, ok := sh.MapIndex[inPath]
+		if !ok {
+			continue
+		}
+
+		header = append(header, csvName)
+		columns = append(columns, parquetColumn{path: inPath, element: sh.SchemaElements[index]})
+	}
+
+	return header, columns
+}
+
+// parquetValue returns a Parquet value in the format of the CSV reports.
+func parquetValue(v interface{}, element *parquet.SchemaElement) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if element.GetType() == parquet.Type_INT96 {
+			return types.INT96ToTime(v).UTC().Format(time.RFC3339)
+		}
+		return v
+	case int32:
+		if element.IsSetConvertedType() && element.GetConvertedType() == parquet.ConvertedType_DATE {
+			return time.Unix(int64(v)*24*60*60, 0).UTC().Format("2006-01-02")
+		}
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		if t, ok := parquetTimestamp(v, element); ok {
+			return t.UTC().Format(time.RFC3339)
+		}
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(v)
+}
+
+func parquetTimestamp(v int64, element *parquet.SchemaElement) (time.Time, bool) {
+	if element.IsSetLogicalType() && element.GetLogicalType().IsSetTIMESTAMP() {
+		unit := element.GetLogicalType().GetTIMESTAMP().GetUnit()
+		switch {
+		case unit.IsSetMILLIS():
+			return types.TIMESTAMP_MILLISToTime(v, true), true
+		case unit.IsSetMICROS():
+			return types.TIMESTAMP_MICROSToTime(v, true), true
+		case unit.IsSetNANOS():
+			return types.TIMESTAMP_NANOSToTime(v, true), true
+		}
+	}
+
+	if element.IsSetConvertedType() {
+		switch element.GetConvertedType() {
+		case parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return types.TIMESTAMP_MILLISToTime(v, true), true
+		case parquet.ConvertedType_TIMESTAMP_MICROS:
+			return types.TIMESTAMP_MICROSToTime(v, true), true
+		}
+	}
+
+	return time.Time{}, false
+}
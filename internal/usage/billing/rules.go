@@ -0,0 +1,42 @@
+package billing
+
+import "regexp"
+
+// usageRule maps the line items of a service and usage type to a usage key
+// and cost component of a resource type. The first rule that matches a line
+// item is used.
+type usageRule struct {
+	resourceType string
+	service      string
+	usageType    *regexp.Regexp
+	// key is the usage key, with nested keys separated by a dot, e.g.
+	// standard.storage_gb.
+	key string
+	// integer is set for usage keys that are counts, e.g. requests.
+	integer bool
+	// component matches the names of the cost components the line items are
+	// priced in. Rules without a key are only used to match the cost of the
+	// line items to cost components and not for usage.
+	component *regexp.Regexp
+}
+
+func (e *Export) findRule(resourceType string, item *LineItem) *usageRule {
+	for i, rule := range e.rules {
+		if rule.resourceType == resourceType && rule.service == item.Service && rule.usageType.MatchString(item.UsageType) {
+			return &e.rules[i]
+		}
+	}
+
+	return nil
+}
+
+// MatchesComponent returns whether the line item is priced in the cost
+// component with the given name for the resource type.
+func (e *Export) MatchesComponent(resourceType string, item *LineItem, componentName string) bool {
+	rule := e.findRule(resourceType, item)
+	if rule == nil || rule.component == nil {
+		return false
+	}
+
+	return rule.component.MatchString(componentName)
+}
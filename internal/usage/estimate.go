@@ -0,0 +1,159 @@
+package usage
+
+import (
+	"context"
+
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// EstimateResult is the result of estimating the usage of resources from the
+// cloud provider.
+type EstimateResult struct {
+	ResourceCount    int
+	EstimationErrors map[string]error
+}
+
+func (e *EstimateResult) ProjectContext() map[string]interface{} {
+	return map[string]interface{}{
+		"usageEstimateResources": e.ResourceCount,
+		"usageEstimateErrors":    len(e.EstimationErrors),
+	}
+}
+
+type estimateResourceResult struct {
+	name   string
+	values map[string]interface{}
+	err    error
+}
+
+// EstimateUsageData estimates the usage of the resources that support it,
+// e.g. from AWS CloudWatch metrics, and returns a copy of the usage data with
+// the estimated values added. Values that are already set in the usage data
+// take precedence over the estimates. The estimated keys are recorded in
+// UsageData.EstimatedKeys so they can be reported. Resources that fail
+// estimation keep their existing usage data, unless the error is a
+// schema.Remediater since those resources have been partially estimated.
+func EstimateUsageData(ctx context.Context, projects []*schema.Project, usageData map[string]*schema.UsageData) (map[string]*schema.UsageData, *EstimateResult) {
+	result := &EstimateResult{
+		EstimationErrors: make(map[string]error),
+	}
+
+	resources := make([]*schema.Resource, 0)
+	for _, project := range projects {
+		for _, r := range project.Resources {
+			if r.IsSkipped || r.EstimateUsage == nil {
+				continue
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	estimated := make(map[string]*schema.UsageData, len(usageData))
+	for addr, u := range usageData {
+		estimated[addr] = u
+	}
+
+	result.ResourceCount = len(resources)
+	if len(resources) == 0 {
+		return estimated, result
+	}
+
+	numWorkers := estimationWorkers()
+	if numWorkers > len(resources) {
+		numWorkers = len(resources)
+	}
+
+	jobs := make(chan *schema.Resource, len(resources))
+	results := make(chan estimateResourceResult, len(resources))
+
+	for i := 0; i < numWorkers; i++ {
+		go func(jobs <-chan *schema.Resource, results chan<- estimateResourceResult) {
+			for r := range jobs {
+				values := make(map[string]interface{})
+				err := r.EstimateUsage(ctx, values)
+				results <- estimateResourceResult{r.Name, values, err}
+			}
+		}(jobs, results)
+	}
+
+	for _, r := range resources {
+		jobs <- r
+	}
+	close(jobs)
+
+	for i := 0; i < len(resources); i++ {
+		res := <-results
+		if res.err != nil {
+			result.EstimationErrors[res.name] = res.err
+			log.Debugf("Error estimating usage for resource %s: %v", res.name, res.err)
+
+			// Remediation errors mean only some of the usage could be estimated, so
+			// the values that were estimated are still used.
+			if _, ok := res.err.(schema.Remediater); !ok {
+				continue
+			}
+		}
+
+		estimated[res.name] = mergeEstimatedUsage(res.name, usageData[res.name], res.values)
+	}
+
+	return estimated, result
+}
+
+// ApplyEstimatedUsage rebuilds the resources that had their usage estimated so
+// their cost components use the estimated usage. The resources are rebuilt
+// from their parsed data instead of loading the projects again, which for
+// Terraform projects could mean running Terraform again.
+func ApplyEstimatedUsage(projects []*schema.Project, usageData map[string]*schema.UsageData, estimated map[string]*schema.UsageData) {
+	for _, project := range projects {
+		project.PastResources = rebuildEstimatedResources(project.PastResources, usageData, estimated)
+		project.Resources = rebuildEstimatedResources(project.Resources, usageData, estimated)
+	}
+}
+
+func rebuildEstimatedResources(resources []*schema.Resource, usageData map[string]*schema.UsageData, estimated map[string]*schema.UsageData) []*schema.Resource {
+	rebuilt := make([]*schema.Resource, 0, len(resources))
+
+	for _, r := range resources {
+		if u, ok := estimated[r.Name]; ok && u != usageData[r.Name] {
+			r = r.Rebuild(u)
+		}
+
+		rebuilt = append(rebuilt, r)
+	}
+
+	return rebuilt
+}
+
+// mergeEstimatedUsage adds the estimated values to the existing usage data
+// for any keys that don't already have a value.
+func mergeEstimatedUsage(address string, existing *schema.UsageData, values map[string]interface{}) *schema.UsageData {
+	attributes := make(map[string]gjson.Result)
+	estimatedKeys := make(map[string]bool)
+
+	if existing != nil {
+		for k, v := range existing.Attributes {
+			attributes[k] = v
+		}
+		for k, v := range existing.EstimatedKeys {
+			estimatedKeys[k] = v
+		}
+	}
+
+	for k, v := range schema.ParseAttributes(values) {
+		if existing != nil && existing.Get(k).Type != gjson.Null {
+			continue
+		}
+
+		attributes[k] = v
+		estimatedKeys[k] = true
+	}
+
+	u := schema.NewUsageData(address, attributes)
+	u.EstimatedKeys = estimatedKeys
+
+	return u
+}
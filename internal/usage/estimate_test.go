@@ -0,0 +1,186 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+	awsusage "github.com/infracost/infracost/internal/usage/aws"
+)
+
+func TestEstimateUsageData(t *testing.T) {
+	project := schema.NewProject("test", &schema.ProjectMetadata{})
+	project.Resources = []*schema.Resource{
+		{
+			Name: "aws_lambda_function.estimated",
+			EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+				values["monthly_requests"] = int64(1000)
+				values["request_duration_ms"] = int64(0)
+				return nil
+			},
+		},
+		{
+			Name: "aws_lambda_function.from_usage_file",
+			EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+				values["monthly_requests"] = int64(1000)
+				values["request_duration_ms"] = int64(200)
+				return nil
+			},
+		},
+		{
+			Name: "aws_lambda_function.failed",
+			EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+				values["monthly_requests"] = int64(1000)
+				return errors.New("access denied")
+			},
+		},
+		{
+			Name: "aws_s3_bucket.needs_remediation",
+			EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+				values["standard"] = map[string]interface{}{"storage_gb": 10.5}
+				return testRemediater{}
+			},
+		},
+		{
+			Name: "aws_instance.no_estimate",
+		},
+	}
+
+	usageData := schema.NewUsageMap(map[string]interface{}{
+		"aws_lambda_function.from_usage_file": map[string]interface{}{
+			"monthly_requests": 5,
+		},
+		"aws_lambda_function.failed": map[string]interface{}{
+			"request_duration_ms": 100,
+		},
+	})
+
+	estimated, result := EstimateUsageData(context.TODO(), []*schema.Project{project}, usageData)
+
+	assert.Equal(t, 4, result.ResourceCount)
+	assert.Len(t, result.EstimationErrors, 2)
+	assert.EqualError(t, result.EstimationErrors["aws_lambda_function.failed"], "access denied")
+
+	u := estimated["aws_lambda_function.estimated"]
+	assert.Equal(t, int64(1000), u.Get("monthly_requests").Int())
+	assert.Equal(t, map[string]bool{"monthly_requests": true, "request_duration_ms": true}, u.EstimatedKeys)
+	assert.Equal(t, map[string]bool{"monthly_requests": true, "request_duration_ms": true}, u.CalcEstimationSummary())
+
+	u = estimated["aws_lambda_function.from_usage_file"]
+	assert.Equal(t, int64(5), u.Get("monthly_requests").Int())
+	assert.Equal(t, int64(200), u.Get("request_duration_ms").Int())
+	assert.Equal(t, map[string]bool{"request_duration_ms": true}, u.EstimatedKeys)
+
+	u = estimated["aws_lambda_function.failed"]
+	assert.Equal(t, gjson.Null, u.Get("monthly_requests").Type)
+	assert.Equal(t, int64(100), u.Get("request_duration_ms").Int())
+	assert.Empty(t, u.EstimatedKeys)
+
+	u = estimated["aws_s3_bucket.needs_remediation"]
+	assert.Equal(t, 10.5, u.Get("standard.storage_gb").Float())
+	assert.Equal(t, map[string]bool{"standard": true}, u.EstimatedKeys)
+
+	assert.Nil(t, estimated["aws_instance.no_estimate"])
+
+	// The original usage data isn't changed
+	assert.Equal(t, gjson.Null, usageData["aws_lambda_function.from_usage_file"].Get("request_duration_ms").Type)
+}
+
+func TestEstimateUsageDataEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+			<GetMetricStatisticsResponse xmlns="http://monitoring.amazonaws.com/doc/2010-08-01/">
+			  <GetMetricStatisticsResult>
+			    <Datapoints>
+			      <member>
+			        <Unit>Count</Unit>
+			        <Sum>1234.0</Sum>
+			        <Timestamp>1970-01-01T00:00:00Z</Timestamp>
+			      </member>
+			    </Datapoints>
+			    <Label>Invocations</Label>
+			  </GetMetricStatisticsResult>
+			</GetMetricStatisticsResponse>
+		`))
+	}))
+	defer server.Close()
+
+	project := schema.NewProject("test", &schema.ProjectMetadata{})
+	project.Resources = []*schema.Resource{
+		{
+			Name: "aws_lambda_function.fn",
+			EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+				inv, err := awsusage.LambdaGetInvocations(ctx, "us-east-1", "fn")
+				if err != nil {
+					return err
+				}
+				values["monthly_requests"] = int64(inv)
+				return nil
+			},
+		},
+	}
+
+	ctx := awsusage.WithTestEndpoint(context.TODO(), server.URL)
+	estimated, result := EstimateUsageData(ctx, []*schema.Project{project}, schema.NewEmptyUsageMap())
+
+	assert.Empty(t, result.EstimationErrors)
+	assert.Equal(t, int64(1234), estimated["aws_lambda_function.fn"].Get("monthly_requests").Int())
+}
+
+func TestApplyEstimatedUsage(t *testing.T) {
+	build := func(name string) func(u *schema.UsageData) *schema.Resource {
+		return func(u *schema.UsageData) *schema.Resource {
+			var requests *decimal.Decimal
+			if u != nil && u.GetInt("monthly_requests") != nil {
+				q := decimal.NewFromInt(*u.GetInt("monthly_requests"))
+				requests = &q
+			}
+
+			return &schema.Resource{
+				Name: name,
+				CostComponents: []*schema.CostComponent{
+					{Name: "Requests", MonthlyQuantity: requests},
+				},
+			}
+		}
+	}
+
+	estimatedBuild := build("aws_lambda_function.estimated")
+	estimatedResource := estimatedBuild(nil)
+	estimatedResource.ResourceType = "aws_lambda_function"
+	estimatedResource.Tags = map[string]string{"team": "api"}
+	estimatedResource.SetRebuildFunc(nil, estimatedBuild)
+
+	notEstimatedBuild := build("aws_lambda_function.not_estimated")
+	notEstimatedResource := notEstimatedBuild(nil)
+	notEstimatedResource.SetRebuildFunc(nil, notEstimatedBuild)
+
+	project := schema.NewProject("test", &schema.ProjectMetadata{})
+	project.PastResources = []*schema.Resource{estimatedResource}
+	project.Resources = []*schema.Resource{estimatedResource, notEstimatedResource}
+
+	usageData := schema.NewEmptyUsageMap()
+	estimated := map[string]*schema.UsageData{
+		"aws_lambda_function.estimated": mergeEstimatedUsage("aws_lambda_function.estimated", nil, map[string]interface{}{
+			"monthly_requests": int64(1000),
+		}),
+	}
+
+	ApplyEstimatedUsage([]*schema.Project{project}, usageData, estimated)
+
+	for _, r := range []*schema.Resource{project.PastResources[0], project.Resources[0]} {
+		assert.Equal(t, "aws_lambda_function", r.ResourceType)
+		assert.Equal(t, map[string]string{"team": "api"}, r.Tags)
+		assert.Equal(t, "1000", r.CostComponents[0].MonthlyQuantity.String())
+		assert.Equal(t, map[string]bool{"monthly_requests": true}, r.EstimatedUsageKeys)
+	}
+
+	assert.Same(t, notEstimatedResource, project.Resources[1])
+}
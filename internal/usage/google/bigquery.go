@@ -0,0 +1,45 @@
+package google
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// BigQueryGetTableStoredBytes returns the average bytes stored in the table.
+func BigQueryGetTableStoredBytes(ctx context.Context, project string, dataset string, table string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: storage/stored_bytes (project: %s, dataset_id: %s, table: %s)", project, dataset, table)
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project:      project,
+		metric:       "bigquery.googleapis.com/storage/stored_bytes",
+		resourceType: "bigquery_dataset",
+		resourceLabels: map[string]string{
+			"dataset_id": dataset,
+		},
+		metricLabels: map[string]string{
+			"table": table,
+		},
+		aligner: alignMean,
+		reducer: reduceSum,
+	})
+}
+
+// BigQueryGetTableStreamingInsertBytes returns the billable bytes inserted
+// into the table with streaming inserts.
+func BigQueryGetTableStreamingInsertBytes(ctx context.Context, project string, dataset string, table string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: storage/uploaded_bytes_billed (project: %s, dataset_id: %s, table: %s)", project, dataset, table)
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project:      project,
+		metric:       "bigquery.googleapis.com/storage/uploaded_bytes_billed",
+		resourceType: "bigquery_dataset",
+		resourceLabels: map[string]string{
+			"dataset_id": dataset,
+		},
+		metricLabels: map[string]string{
+			"api":   "streaming",
+			"table": table,
+		},
+		aligner: alignSum,
+		reducer: reduceSum,
+	})
+}
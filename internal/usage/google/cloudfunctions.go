@@ -0,0 +1,53 @@
+package google
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func CloudFunctionsGetInvocations(ctx context.Context, project string, region string, fn string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: function/execution_count (project: %s, region: %s, function_name: %s)", project, region, fn)
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project:        project,
+		metric:         "cloudfunctions.googleapis.com/function/execution_count",
+		resourceType:   "cloud_function",
+		resourceLabels: functionLabels(region, fn),
+		aligner:        alignSum,
+		reducer:        reduceSum,
+	})
+}
+
+// CloudFunctionsGetDurationAvg returns the average execution time of the
+// function in milliseconds.
+func CloudFunctionsGetDurationAvg(ctx context.Context, project string, region string, fn string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: function/execution_times (project: %s, region: %s, function_name: %s)", project, region, fn)
+	ns, err := monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project:        project,
+		metric:         "cloudfunctions.googleapis.com/function/execution_times",
+		resourceType:   "cloud_function",
+		resourceLabels: functionLabels(region, fn),
+		aligner:        alignMean,
+		reducer:        reduceMean,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return ns / 1000 / 1000, nil
+}
+
+// functionLabels returns the resource labels for the function. The region is
+// only included if it's known since the provider default region isn't always
+// in the plan.
+func functionLabels(region string, fn string) map[string]string {
+	labels := map[string]string{
+		"function_name": fn,
+	}
+
+	if region != "" {
+		labels["region"] = region
+	}
+
+	return labels
+}
@@ -0,0 +1,79 @@
+package google
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultMonitoringEndpoint = "https://monitoring.googleapis.com"
+
+type ctxEndpointKeyType struct{}
+
+var ctxEndpointKey = &ctxEndpointKeyType{}
+
+type ctxAccessTokenKeyType struct{}
+
+var ctxAccessTokenKey = &ctxAccessTokenKeyType{}
+
+// WithEndpoint returns a context that sends the Cloud Monitoring API calls made
+// with it to the given endpoint instead of the Google endpoint, e.g. a local fake.
+func WithEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxEndpointKey, strings.TrimSuffix(url, "/"))
+}
+
+// WithTestEndpoint returns a context that sends the Cloud Monitoring API calls
+// to the given endpoint with a fixed access token.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	ctx = WithEndpoint(ctx, url)
+	return context.WithValue(ctx, ctxAccessTokenKey, "test-token")
+}
+
+func monitoringEndpoint(ctx context.Context) string {
+	if endpoint, ok := ctx.Value(ctxEndpointKey).(string); ok && endpoint != "" {
+		return endpoint
+	}
+
+	return defaultMonitoringEndpoint
+}
+
+// accessToken returns the OAuth access token for the Google API calls. It uses
+// the GOOGLE_OAUTH_ACCESS_TOKEN env variable that the Terraform Google
+// provider also supports, or falls back to the gcloud CLI.
+func accessToken(ctx context.Context) (string, error) {
+	if token, ok := ctx.Value(ctxAccessTokenKey).(string); ok && token != "" {
+		return token, nil
+	}
+
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	log.Debugf("Getting Google access token from gcloud")
+	out, err := exec.CommandContext(ctx, "gcloud", "auth", "print-access-token").Output()
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting Google access token, set GOOGLE_OAUTH_ACCESS_TOKEN or log in with gcloud")
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// projectID returns the project for the resource, falling back to the project
+// from the environment if the resource doesn't set one.
+func projectID(project string) (string, error) {
+	if project != "" {
+		return project, nil
+	}
+
+	for _, env := range []string{"GOOGLE_PROJECT", "GOOGLE_CLOUD_PROJECT", "CLOUDSDK_CORE_PROJECT"} {
+		if v := os.Getenv(env); v != "" {
+			return v, nil
+		}
+	}
+
+	return "", errors.New("No Google project found for resource, set the project attribute or GOOGLE_PROJECT")
+}
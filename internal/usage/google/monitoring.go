@@ -0,0 +1,212 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	alignSum  = "ALIGN_SUM"
+	alignMean = "ALIGN_MEAN"
+
+	reduceSum  = "REDUCE_SUM"
+	reduceMean = "REDUCE_MEAN"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+type timeSeriesRequest struct {
+	project      string
+	metric       string
+	resourceType string
+	// resourceLabels and metricLabels are the labels that the time series
+	// are filtered by.
+	resourceLabels map[string]string
+	metricLabels   map[string]string
+	aligner        string
+	reducer        string
+	// groupBy are the fields the time series are grouped by when reducing,
+	// e.g. "metric.label.method".
+	groupBy []string
+}
+
+type timeSeriesResponse struct {
+	TimeSeries []struct {
+		Metric struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metric"`
+		Points []struct {
+			Value struct {
+				Int64Value  *string  `json:"int64Value"`
+				DoubleValue *float64 `json:"doubleValue"`
+			} `json:"value"`
+		} `json:"points"`
+	} `json:"timeSeries"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// timeSeries is a series returned from Cloud Monitoring, reduced to a single
+// value for the month.
+type timeSeries struct {
+	metricLabels map[string]string
+	value        float64
+}
+
+func (r timeSeriesRequest) filter() string {
+	parts := []string{
+		fmt.Sprintf("metric.type=%q", r.metric),
+		fmt.Sprintf("resource.type=%q", r.resourceType),
+	}
+
+	parts = append(parts, labelFilters("resource.labels", r.resourceLabels)...)
+	parts = append(parts, labelFilters("metric.labels", r.metricLabels)...)
+
+	return strings.Join(parts, " AND ")
+}
+
+func labelFilters(prefix string, labels map[string]string) []string {
+	filters := make([]string, 0, len(labels))
+	for k, v := range labels {
+		filters = append(filters, fmt.Sprintf("%s.%s=%q", prefix, k, v))
+	}
+	sort.Strings(filters)
+
+	return filters
+}
+
+// monitoringGetMonthlyTimeSeries lists the time series for the last month
+// aligned to a single value per series. The points of each series are summed
+// for ALIGN_SUM and averaged otherwise.
+func monitoringGetMonthlyTimeSeries(ctx context.Context, req timeSeriesRequest) ([]timeSeries, error) {
+	project, err := projectID(req.project)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-timeMonth)
+
+	q := url.Values{}
+	q.Set("filter", req.filter())
+	q.Set("interval.startTime", start.Format(time.RFC3339))
+	q.Set("interval.endTime", end.Format(time.RFC3339))
+	q.Set("aggregation.alignmentPeriod", fmt.Sprintf("%ds", int64(timeMonth.Seconds())))
+	q.Set("aggregation.perSeriesAligner", req.aligner)
+	if req.reducer != "" {
+		q.Set("aggregation.crossSeriesReducer", req.reducer)
+	}
+	for _, field := range req.groupBy {
+		q.Add("aggregation.groupByFields", field)
+	}
+
+	series := make([]timeSeries, 0)
+
+	for {
+		u := fmt.Sprintf("%s/v3/projects/%s/timeSeries?%s", monitoringEndpoint(ctx), url.PathEscape(project), q.Encode())
+
+		resp, err := monitoringGet(ctx, u, token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ts := range resp.TimeSeries {
+			var total float64
+			for _, p := range ts.Points {
+				if p.Value.Int64Value != nil {
+					v, err := strconv.ParseFloat(*p.Value.Int64Value, 64)
+					if err != nil {
+						return nil, errors.Wrap(err, "Invalid Cloud Monitoring int64 value")
+					}
+					total += v
+				} else if p.Value.DoubleValue != nil {
+					total += *p.Value.DoubleValue
+				}
+			}
+
+			if req.aligner != alignSum && len(ts.Points) > 0 {
+				total /= float64(len(ts.Points))
+			}
+
+			series = append(series, timeSeries{
+				metricLabels: ts.Metric.Labels,
+				value:        total,
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		q.Set("pageToken", resp.NextPageToken)
+	}
+
+	return series, nil
+}
+
+// monitoringGetMonthlyValue returns the value for the last month of the time
+// series matching the request, reduced to a single series.
+func monitoringGetMonthlyValue(ctx context.Context, req timeSeriesRequest) (float64, error) {
+	series, err := monitoringGetMonthlyTimeSeries(ctx, req)
+	if err != nil {
+		return 0, err
+	} else if len(series) == 0 {
+		return 0, nil
+	}
+
+	return series[0].value, nil
+}
+
+func monitoringGet(ctx context.Context, u string, token string) (*timeSeriesResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error calling Cloud Monitoring API")
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading Cloud Monitoring API response")
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error.Message != "" {
+			return nil, fmt.Errorf("Cloud Monitoring API error: %s", errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("Cloud Monitoring API error: %s", httpResp.Status)
+	}
+
+	var resp timeSeriesResponse
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing Cloud Monitoring API response")
+	}
+
+	return &resp, nil
+}
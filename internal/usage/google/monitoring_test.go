@@ -0,0 +1,114 @@
+package google
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeSeriesRequestFilter(t *testing.T) {
+	req := timeSeriesRequest{
+		metric:       "bigquery.googleapis.com/storage/stored_bytes",
+		resourceType: "bigquery_dataset",
+		resourceLabels: map[string]string{
+			"dataset_id": "my_dataset",
+		},
+		metricLabels: map[string]string{
+			"table": "my_table",
+		},
+	}
+
+	assert.Equal(t, `metric.type="bigquery.googleapis.com/storage/stored_bytes" AND resource.type="bigquery_dataset" AND resource.labels.dataset_id="my_dataset" AND metric.labels.table="my_table"`, req.filter())
+}
+
+func TestMonitoringGetMonthlyTimeSeries(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		assert.Equal(t, "/v3/projects/my-project/timeSeries", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, `metric.type="storage.googleapis.com/api/request_count" AND resource.type="gcs_bucket" AND resource.labels.bucket_name="my-bucket"`, r.URL.Query().Get("filter"))
+		assert.Equal(t, "ALIGN_SUM", r.URL.Query().Get("aggregation.perSeriesAligner"))
+		assert.Equal(t, "REDUCE_SUM", r.URL.Query().Get("aggregation.crossSeriesReducer"))
+		assert.Equal(t, "metric.label.method", r.URL.Query().Get("aggregation.groupByFields"))
+		assert.Equal(t, "2592000s", r.URL.Query().Get("aggregation.alignmentPeriod"))
+
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{
+				"timeSeries": [
+					{"metric": {"labels": {"method": "WriteObject"}}, "points": [{"value": {"int64Value": "100"}}, {"value": {"int64Value": "50"}}]},
+					{"metric": {"labels": {"method": "ReadObject"}}, "points": [{"value": {"int64Value": "1000"}}]}
+				],
+				"nextPageToken": "page2"
+			}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"timeSeries": [
+				{"metric": {"labels": {"method": "ListObjects"}}, "points": [{"value": {"int64Value": "20"}}]},
+				{"metric": {"labels": {"method": "DeleteObject"}}, "points": [{"value": {"int64Value": "5"}}]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	ctx := WithTestEndpoint(context.TODO(), server.URL)
+
+	classA, classB, err := StorageGetBucketOperations(ctx, "my-project", "my-bucket")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, int64(170), classA)
+	assert.Equal(t, int64(1000), classB)
+}
+
+func TestMonitoringGetMonthlyValueMean(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ALIGN_MEAN", r.URL.Query().Get("aggregation.perSeriesAligner"))
+
+		_, _ = w.Write([]byte(`{
+			"timeSeries": [
+				{"points": [{"value": {"doubleValue": 100000000}}, {"value": {"doubleValue": 300000000}}]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	ctx := WithTestEndpoint(context.TODO(), server.URL)
+
+	dur, err := CloudFunctionsGetDurationAvg(ctx, "my-project", "us-central1", "my-function")
+	require.NoError(t, err)
+	assert.Equal(t, float64(200), dur)
+}
+
+func TestMonitoringGetMonthlyValueNoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx := WithTestEndpoint(context.TODO(), server.URL)
+
+	bytes, err := PubSubGetTopicMessageBytes(ctx, "my-project", "my-topic")
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), bytes)
+}
+
+func TestMonitoringGetMonthlyValueError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "Permission monitoring.timeSeries.list denied", "status": "PERMISSION_DENIED"}}`))
+	}))
+	defer server.Close()
+
+	ctx := WithTestEndpoint(context.TODO(), server.URL)
+
+	_, err := PubSubGetTopicMessageBytes(ctx, "my-project", "my-topic")
+	assert.EqualError(t, err, "Cloud Monitoring API error: Permission monitoring.timeSeries.list denied")
+}
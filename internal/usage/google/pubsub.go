@@ -0,0 +1,39 @@
+package google
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PubSubGetTopicMessageBytes returns the billable bytes of the messages
+// published to the topic.
+func PubSubGetTopicMessageBytes(ctx context.Context, project string, topic string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: topic/byte_cost (project: %s, topic_id: %s)", project, topic)
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project:      project,
+		metric:       "pubsub.googleapis.com/topic/byte_cost",
+		resourceType: "pubsub_topic",
+		resourceLabels: map[string]string{
+			"topic_id": topic,
+		},
+		aligner: alignSum,
+		reducer: reduceSum,
+	})
+}
+
+// PubSubGetSubscriptionMessageBytes returns the billable bytes of the
+// messages pulled by the subscription.
+func PubSubGetSubscriptionMessageBytes(ctx context.Context, project string, subscription string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: subscription/byte_cost (project: %s, subscription_id: %s)", project, subscription)
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project:      project,
+		metric:       "pubsub.googleapis.com/subscription/byte_cost",
+		resourceType: "pubsub_subscription",
+		resourceLabels: map[string]string{
+			"subscription_id": subscription,
+		},
+		aligner: alignSum,
+		reducer: reduceSum,
+	})
+}
@@ -0,0 +1,78 @@
+package google
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Class A and class B operations, see https://cloud.google.com/storage/pricing#operations-by-class
+var storageClassAMethods = map[string]bool{
+	"WriteObject":        true,
+	"ComposeObject":      true,
+	"CopyObject":         true,
+	"RewriteObject":      true,
+	"UpdateObject":       true,
+	"PatchObject":        true,
+	"ListObjects":        true,
+	"ListBuckets":        true,
+	"CreateBucket":       true,
+	"UpdateBucket":       true,
+	"PatchBucket":        true,
+	"SetBucketIamPolicy": true,
+	"SetObjectIamPolicy": true,
+}
+
+var storageClassBMethods = map[string]bool{
+	"ReadObject":         true,
+	"GetObjectMetadata":  true,
+	"GetBucketMetadata":  true,
+	"GetBucketIamPolicy": true,
+	"GetObjectIamPolicy": true,
+}
+
+func StorageGetBucketSizeBytes(ctx context.Context, project string, bucket string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: storage/total_bytes (project: %s, bucket_name: %s)", project, bucket)
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project:      project,
+		metric:       "storage.googleapis.com/storage/total_bytes",
+		resourceType: "gcs_bucket",
+		resourceLabels: map[string]string{
+			"bucket_name": bucket,
+		},
+		aligner: alignMean,
+		reducer: reduceSum,
+	})
+}
+
+// StorageGetBucketOperations returns the number of class A and class B
+// operations for the bucket. Other operations are free so aren't counted.
+func StorageGetBucketOperations(ctx context.Context, project string, bucket string) (int64, int64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: api/request_count (project: %s, bucket_name: %s)", project, bucket)
+	series, err := monitoringGetMonthlyTimeSeries(ctx, timeSeriesRequest{
+		project:      project,
+		metric:       "storage.googleapis.com/api/request_count",
+		resourceType: "gcs_bucket",
+		resourceLabels: map[string]string{
+			"bucket_name": bucket,
+		},
+		aligner: alignSum,
+		reducer: reduceSum,
+		groupBy: []string{"metric.label.method"},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var classA, classB float64
+	for _, s := range series {
+		method := s.metricLabels["method"]
+		if storageClassAMethods[method] {
+			classA += s.value
+		} else if storageClassBMethods[method] {
+			classB += s.value
+		}
+	}
+
+	return int64(classA), int64(classB), nil
+}
@@ -0,0 +1,5 @@
+package google
+
+import "time"
+
+const timeMonth = time.Hour * 24 * 30
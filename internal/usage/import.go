@@ -0,0 +1,55 @@
+package usage
+
+import (
+	"context"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/billing"
+)
+
+// ImportUsageData syncs the usage file with the resources in the projects and
+// sets the usage of the resources that match line items in the billing export
+// to the quantities observed in it. Only the imported usage is estimated so
+// the cloud provider APIs aren't called.
+func ImportUsageData(ctx context.Context, usageFile *UsageFile, projects []*schema.Project, export *billing.Export) (*SyncResult, error) {
+	resources := make([]*schema.Resource, 0)
+	for _, project := range projects {
+		resources = append(resources, project.Resources...)
+	}
+
+	imported := export.Match(resources)
+
+	for _, r := range resources {
+		values, ok := imported[r.Name]
+		if !ok {
+			r.EstimateUsage = nil
+			continue
+		}
+
+		r.EstimateUsage = func(ctx context.Context, usage map[string]interface{}) error {
+			mergeUsageValues(usage, values)
+			return nil
+		}
+	}
+
+	return SyncUsageData(ctx, usageFile, projects)
+}
+
+// mergeUsageValues merges the src usage values into dst, merging the values
+// of nested keys instead of replacing them.
+func mergeUsageValues(dst map[string]interface{}, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, ok := v.(map[string]interface{})
+		if !ok {
+			dst[k] = v
+			continue
+		}
+
+		dstMap, ok := dst[k].(map[string]interface{})
+		if !ok {
+			dstMap = make(map[string]interface{})
+			dst[k] = dstMap
+		}
+		mergeUsageValues(dstMap, srcMap)
+	}
+}
@@ -0,0 +1,72 @@
+package usage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/billing"
+)
+
+func TestImportUsageData(t *testing.T) {
+	export, err := billing.ReadExport(strings.NewReader(`lineItem/LineItemType,lineItem/UsageStartDate,lineItem/ProductCode,lineItem/UsageType,lineItem/ResourceId,lineItem/UsageAmount,resourceTags/user:Name
+Usage,2021-10-01T00:00:00Z,AWSLambda,USE1-Request,arn:aws:lambda:us-east-1:123456789012:function:my-function,1500000,
+Usage,2021-10-01T00:00:00Z,AmazonS3,USE1-TimedStorage-ByteHrs,logs-bucket,120.5,logs
+Usage,2021-10-01T00:00:00Z,AmazonS3,USE1-Requests-Tier1,logs-bucket,25000,logs
+`))
+	require.NoError(t, err)
+
+	usageFile, err := LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.fn:
+    monthly_requests: 100
+    request_duration_ms: 250
+`)
+	require.NoError(t, err)
+
+	project := schema.NewProject("test", &schema.ProjectMetadata{})
+	project.Resources = []*schema.Resource{
+		{
+			Name:             "aws_lambda_function.fn",
+			ResourceType:     "aws_lambda_function",
+			CloudResourceIDs: []string{"my-function", "arn:aws:lambda:us-east-1:123456789012:function:my-function"},
+			EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+				t.Error("the cloud provider estimate shouldn't be called when importing usage")
+				return nil
+			},
+		},
+		{
+			Name:         "aws_s3_bucket.logs",
+			ResourceType: "aws_s3_bucket",
+			Tags:         map[string]string{"Name": "logs", "team": "platform"},
+		},
+		{
+			Name:         "aws_sqs_queue.queue",
+			ResourceType: "aws_sqs_queue",
+		},
+	}
+
+	result, err := ImportUsageData(context.TODO(), usageFile, []*schema.Project{project}, export)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.ResourceCount)
+	assert.Equal(t, 2, result.EstimationCount)
+	assert.Empty(t, result.EstimationErrors)
+
+	usageData := usageFile.ToUsageDataMap()
+
+	fn := usageData["aws_lambda_function.fn"]
+	assert.Equal(t, int64(1500000), fn.Get("monthly_requests").Int())
+	assert.Equal(t, int64(250), fn.Get("request_duration_ms").Int())
+
+	bucket := usageData["aws_s3_bucket.logs"]
+	assert.Equal(t, 120.5, bucket.Get("standard.storage_gb").Float())
+	assert.Equal(t, int64(25000), bucket.Get("standard.monthly_tier_1_requests").Int())
+
+	assert.Contains(t, usageData, "aws_sqs_queue.queue")
+}
@@ -0,0 +1,68 @@
+package usage
+
+import (
+	"sort"
+
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// Remediation is a change that needs to be made to a resource in the cloud
+// before its usage can be estimated, e.g. enabling S3 bucket metrics.
+type Remediation struct {
+	ResourceName string
+	Remediater   schema.Remediater
+}
+
+// Remediations returns the remediations for the resources that failed
+// estimation, sorted by resource name.
+func (s *SyncResult) Remediations() []Remediation {
+	remediations := make([]Remediation, 0)
+
+	for name, err := range s.EstimationErrors {
+		if rem, ok := err.(schema.Remediater); ok {
+			remediations = append(remediations, Remediation{
+				ResourceName: name,
+				Remediater:   rem,
+			})
+		}
+	}
+
+	sort.Slice(remediations, func(i, j int) bool {
+		return remediations[i].ResourceName < remediations[j].ResourceName
+	})
+
+	return remediations
+}
+
+// Remediate makes the remediations for the resources that failed estimation.
+// The confirm func is called before each remediation and the remediation is
+// skipped if it returns false. If confirm is nil all the remediations are
+// made. Remediations that fail are recorded in RemediationErrors.
+func (s *SyncResult) Remediate(confirm func(Remediation) (bool, error)) error {
+	for _, r := range s.Remediations() {
+		if confirm != nil {
+			ok, err := confirm(r)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		s.RemediationAttempts++
+
+		err := r.Remediater.Remediate()
+		if err != nil {
+			if s.RemediationErrors == nil {
+				s.RemediationErrors = make(map[string]error)
+			}
+			s.RemediationErrors[r.ResourceName] = err
+			log.Warnf("Error remediating resource %s: %v", r.ResourceName, err)
+		}
+	}
+
+	return nil
+}
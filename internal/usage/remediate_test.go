@@ -0,0 +1,95 @@
+package usage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRemediater struct {
+	remediated *bool
+	err        error
+}
+
+func (r testRemediater) Describe() string {
+	return "enable bucket metrics"
+}
+
+func (r testRemediater) APICalls() []string {
+	return []string{"s3:PutBucketMetricsConfiguration"}
+}
+
+func (r testRemediater) Error() string {
+	return "Must enable bucket metrics to estimate usage"
+}
+
+func (r testRemediater) Remediate() error {
+	if r.remediated != nil {
+		*r.remediated = true
+	}
+	return r.err
+}
+
+func TestSyncResultRemediations(t *testing.T) {
+	s := &SyncResult{
+		EstimationErrors: map[string]error{
+			"aws_s3_bucket.b":        testRemediater{},
+			"aws_lambda_function.fn": errors.New("access denied"),
+			"aws_s3_bucket.a":        testRemediater{},
+		},
+	}
+
+	remediations := s.Remediations()
+	require.Len(t, remediations, 2)
+	assert.Equal(t, "aws_s3_bucket.a", remediations[0].ResourceName)
+	assert.Equal(t, "aws_s3_bucket.b", remediations[1].ResourceName)
+	assert.Equal(t, "enable bucket metrics", remediations[0].Remediater.Describe())
+}
+
+func TestSyncResultRemediate(t *testing.T) {
+	var remediatedA, remediatedB, remediatedC bool
+
+	s := &SyncResult{
+		EstimationErrors: map[string]error{
+			"aws_s3_bucket.a": testRemediater{remediated: &remediatedA},
+			"aws_s3_bucket.b": testRemediater{remediated: &remediatedB},
+			"aws_s3_bucket.c": testRemediater{remediated: &remediatedC, err: errors.New("access denied")},
+		},
+	}
+
+	err := s.Remediate(func(r Remediation) (bool, error) {
+		return r.ResourceName != "aws_s3_bucket.b", nil
+	})
+	require.NoError(t, err)
+
+	assert.True(t, remediatedA)
+	assert.False(t, remediatedB)
+	assert.True(t, remediatedC)
+	assert.Equal(t, 2, s.RemediationAttempts)
+	assert.Len(t, s.RemediationErrors, 1)
+	assert.EqualError(t, s.RemediationErrors["aws_s3_bucket.c"], "access denied")
+
+	ctx := s.ProjectContext()
+	assert.Equal(t, 3, ctx["remediationOpportunities"])
+	assert.Equal(t, 2, ctx["remediationAttempts"])
+	assert.Equal(t, 1, ctx["remediationErrors"])
+}
+
+func TestSyncResultRemediateConfirmError(t *testing.T) {
+	var remediated bool
+
+	s := &SyncResult{
+		EstimationErrors: map[string]error{
+			"aws_s3_bucket.a": testRemediater{remediated: &remediated},
+		},
+	}
+
+	err := s.Remediate(func(r Remediation) (bool, error) {
+		return false, errors.New("prompt closed")
+	})
+	assert.EqualError(t, err, "prompt closed")
+	assert.False(t, remediated)
+	assert.Equal(t, 0, s.RemediationAttempts)
+}
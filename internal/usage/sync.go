@@ -13,9 +13,11 @@ import (
 )
 
 type SyncResult struct {
-	ResourceCount    int
-	EstimationCount  int
-	EstimationErrors map[string]error
+	ResourceCount       int
+	EstimationCount     int
+	EstimationErrors    map[string]error
+	RemediationAttempts int
+	RemediationErrors   map[string]error
 }
 
 type ReplaceResourceUsagesOpts struct {
@@ -28,6 +30,14 @@ func (s *SyncResult) Merge(other *SyncResult) {
 	for k, v := range other.EstimationErrors {
 		s.EstimationErrors[k] = v
 	}
+
+	s.RemediationAttempts += other.RemediationAttempts
+	for k, v := range other.RemediationErrors {
+		if s.RemediationErrors == nil {
+			s.RemediationErrors = make(map[string]error)
+		}
+		s.RemediationErrors[k] = v
+	}
 }
 
 func (s *SyncResult) ProjectContext() map[string]interface{} {
@@ -37,12 +47,9 @@ func (s *SyncResult) ProjectContext() map[string]interface{} {
 	r["usageEstimates"] = s.EstimationCount
 	r["usageEstimateErrors"] = len(s.EstimationErrors)
 
-	var remediable, remAttempts, remErrors int
-	for _, err := range s.EstimationErrors {
-		if _, ok := err.(schema.Remediater); ok {
-			remediable++
-		}
-	}
+	remediable := len(s.Remediations())
+	remAttempts := s.RemediationAttempts
+	remErrors := len(s.RemediationErrors)
 
 	r["remediationOpportunities"] = remediable
 	r["remediationAttempts"] = remAttempts
@@ -51,7 +58,10 @@ func (s *SyncResult) ProjectContext() map[string]interface{} {
 	return r
 }
 
-func SyncUsageData(usageFile *UsageFile, projects []*schema.Project) (*SyncResult, error) {
+// SyncUsageData syncs the usage file with the resources in the projects and
+// estimates the usage of the resources that support it. The context is passed
+// to the estimate functions so the cloud provider endpoints can be set.
+func SyncUsageData(ctx context.Context, usageFile *UsageFile, projects []*schema.Project) (*SyncResult, error) {
 	referenceFile, err := LoadReferenceFile()
 	if err != nil {
 		return nil, err
@@ -64,7 +74,7 @@ func SyncUsageData(usageFile *UsageFile, projects []*schema.Project) (*SyncResul
 		resources = append(resources, project.Resources...)
 	}
 
-	syncResult := syncResourceUsages(usageFile, resources, referenceFile)
+	syncResult := syncResourceUsages(ctx, usageFile, resources, referenceFile)
 
 	return syncResult, nil
 }
@@ -74,7 +84,7 @@ type syncResourceResult struct {
 	sr *SyncResult
 }
 
-func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, referenceFile *ReferenceFile) *SyncResult {
+func syncResourceUsages(ctx context.Context, usageFile *UsageFile, resources []*schema.Resource, referenceFile *ReferenceFile) *SyncResult {
 	syncResult := &SyncResult{
 		EstimationErrors: make(map[string]error),
 	}
@@ -96,14 +106,7 @@ func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, refe
 		}
 	}
 
-	numWorkers := 4
-	numCPU := runtime.NumCPU()
-	if numCPU*4 > numWorkers {
-		numWorkers = numCPU * 4
-	}
-	if numWorkers > 16 {
-		numWorkers = 16
-	}
+	numWorkers := estimationWorkers()
 	numJobs := len(resources)
 	jobs := make(chan *schema.Resource, numJobs)
 	results := make(chan syncResourceResult, numJobs)
@@ -112,7 +115,7 @@ func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, refe
 	for i := 0; i < numWorkers; i++ {
 		go func(jobs <-chan *schema.Resource, results chan<- syncResourceResult) {
 			for r := range jobs {
-				ru, sr := syncResource(r, referenceFile, existingResourceUsagesMap)
+				ru, sr := syncResource(ctx, r, referenceFile, existingResourceUsagesMap)
 				results <- syncResourceResult{ru, sr}
 			}
 		}(jobs, results)
@@ -137,6 +140,22 @@ func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, refe
 	return syncResult
 }
 
+// estimationWorkers returns the number of workers to use for estimating
+// usage. Estimation is bound by the cloud provider API calls so this is more
+// than the number of CPUs.
+func estimationWorkers() int {
+	numWorkers := 4
+	numCPU := runtime.NumCPU()
+	if numCPU*4 > numWorkers {
+		numWorkers = numCPU * 4
+	}
+	if numWorkers > 16 {
+		numWorkers = 16
+	}
+
+	return numWorkers
+}
+
 func syncWildCardResource(wildCardResources map[string]bool, resource *schema.Resource, referenceFile *ReferenceFile, existingResourceUsagesMap map[string]*ResourceUsage) *ResourceUsage {
 	var resourceUsage *ResourceUsage
 
@@ -174,7 +193,7 @@ func syncWildCardResource(wildCardResources map[string]bool, resource *schema.Re
 	return resourceUsage
 }
 
-func syncResource(resource *schema.Resource, referenceFile *ReferenceFile, existingResourceUsagesMap map[string]*ResourceUsage) (*ResourceUsage, *SyncResult) {
+func syncResource(ctx context.Context, resource *schema.Resource, referenceFile *ReferenceFile, existingResourceUsagesMap map[string]*ResourceUsage) (*ResourceUsage, *SyncResult) {
 	syncResult := &SyncResult{
 		EstimationErrors: make(map[string]error),
 	}
@@ -208,7 +227,7 @@ func syncResource(resource *schema.Resource, referenceFile *ReferenceFile, exist
 		syncResult.EstimationCount++
 
 		resourceUsageMap := resourceUsage.Map()
-		err := resource.EstimateUsage(context.TODO(), resourceUsageMap)
+		err := resource.EstimateUsage(ctx, resourceUsageMap)
 		if err != nil {
 			syncResult.EstimationErrors[resource.Name] = err
 			log.Warnf("Error estimating usage for resource %s: %v", resource.Name, err)